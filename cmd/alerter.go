@@ -1,19 +1,25 @@
 package cmd
 
 import (
+	"time"
 
 	"github.com/opsway-io/backend/internal/alerting"
 	"github.com/opsway-io/backend/internal/connectors/postgres"
 	connectorRedis "github.com/opsway-io/backend/internal/connectors/redis"
 	"github.com/opsway-io/backend/internal/event"
+	"github.com/opsway-io/backend/internal/maintenance"
 	"github.com/opsway-io/backend/internal/monitor"
+	"github.com/opsway-io/backend/internal/notification/delivery"
 	"github.com/opsway-io/backend/internal/notification/email"
+	"github.com/opsway-io/backend/internal/notification/phone"
+	"github.com/opsway-io/backend/internal/secret"
 	"github.com/opsway-io/backend/internal/storage"
 	"github.com/opsway-io/backend/internal/statuspage"
 	"github.com/opsway-io/backend/internal/team"
 	"github.com/opsway-io/backend/internal/escalation"
 	"github.com/opsway-io/backend/internal/incident"
 	"github.com/opsway-io/backend/internal/llm"
+	"github.com/opsway-io/backend/internal/webhook"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -69,6 +75,17 @@ func runAlerter(cmd *cobra.Command, args []string) {
 		emailSender = email.NewSendgridSender(conf.Email)
 	}
 
+	phoneSender, err := phone.NewSender(conf.Phone)
+	if err != nil {
+		l.WithError(err).Fatal("Failed to create phone sender")
+	}
+
+	if conf.Phone.Provider == phone.ProviderTwilio {
+		l.Info("Using Twilio phone sender")
+	} else {
+		l.Warn("Using fake phone sender, SMS and voice alerts will not be sent")
+	}
+
 	alertingRepository := alerting.NewRepository(db)
 	alertingService := alerting.NewService(alertingRepository)
 
@@ -79,7 +96,7 @@ func runAlerter(cmd *cobra.Command, args []string) {
 	teamCache := team.NewCache(redisClient)
 	teamService := team.NewService(conf.Team, teamRepository, storageService, emailSender, teamCache)
 
-	monitorService := monitor.NewService(db, redisClient)
+	monitorService := monitor.NewService(db, redisClient, secret.NewService(conf.Secrets))
 
 	statuspageRepo := statuspage.NewRepository(db)
 	statuspageService := statuspage.NewService(statuspageRepo, nil) // nil k8sService is fine since alerting worker doesn't modify ingresses
@@ -95,6 +112,19 @@ func runAlerter(cmd *cobra.Command, args []string) {
 	incidentRepo := incident.NewRepository(db)
 	incidentSvc := incident.NewService(incidentRepo, eventService)
 
+	maintenanceRepository := maintenance.NewRepository(db)
+	maintenanceService := maintenance.NewService(maintenanceRepository, eventService)
+
+	deliveryRepo := delivery.NewRepository(db)
+	deliverySvc := delivery.NewService(deliveryRepo)
+	deliveryWorker := delivery.NewWorker(l.WithField("module", "alerter"), deliverySvc, time.Second)
+
+	go func() {
+		if err := deliveryWorker.Start(ctx); err != nil {
+			l.WithError(err).Error("failed to start delivery worker")
+		}
+	}()
+
 	worker := alerting.NewWorker(
 		workerConfig,
 		eventService,
@@ -103,8 +133,11 @@ func runAlerter(cmd *cobra.Command, args []string) {
 		monitorService,
 		statuspageService,
 		emailSender,
+		phoneSender,
 		escalationSvc,
 		incidentSvc,
+		deliverySvc,
+		maintenanceService,
 		l.WithField("module", "alerter"),
 	)
 
@@ -122,6 +155,20 @@ func runAlerter(cmd *cobra.Command, args []string) {
 		}
 	}()
 
+	webhookRepo := webhook.NewRepository(db)
+	webhookSvc := webhook.NewService(webhookRepo, deliverySvc)
+	webhookWorker := webhook.NewWorker(
+		eventService,
+		webhookSvc,
+		l.WithField("module", "webhook_worker"),
+	)
+
+	go func() {
+		if err := webhookWorker.Start(ctx); err != nil {
+			l.WithError(err).Error("failed to start webhook worker")
+		}
+	}()
+
 	l.Info("Alerter daemon is listening for incidents...")
 	if err := worker.Start(ctx); err != nil {
 		l.WithError(err).Fatal("Alerter worker exited with error")
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 
 	"github.com/opsway-io/backend/internal/alerting"
 	"github.com/opsway-io/backend/internal/authentication"
@@ -16,17 +17,22 @@ import (
 	"github.com/opsway-io/backend/internal/heartbeats"
 	"github.com/opsway-io/backend/internal/incident"
 	"github.com/opsway-io/backend/internal/k8s"
+	"github.com/opsway-io/backend/internal/llm"
 	"github.com/opsway-io/backend/internal/maintenance"
 	"github.com/opsway-io/backend/internal/monitor"
+	"github.com/opsway-io/backend/internal/notification/delivery"
+	"github.com/opsway-io/backend/internal/postmortem"
 	"github.com/opsway-io/backend/internal/notification/email"
 	"github.com/opsway-io/backend/internal/report"
 	"github.com/opsway-io/backend/internal/rest"
+	"github.com/opsway-io/backend/internal/secret"
 	"github.com/opsway-io/backend/internal/statuspage"
 	"github.com/opsway-io/backend/internal/apikey"
 	"github.com/opsway-io/backend/internal/storage"
 	"github.com/opsway-io/backend/internal/team"
 	"github.com/opsway-io/backend/internal/escalation"
 	"github.com/opsway-io/backend/internal/user"
+	"github.com/opsway-io/backend/internal/webhook"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -75,12 +81,17 @@ func runAPI(cmd *cobra.Command, args []string) {
 		entities.Monitor{},
 		entities.MonitorSettings{},
 		entities.MonitorAssertion{},
+		entities.MonitorDependency{},
 		entities.AlertRule{},
 		entities.Maintenance{},
 		entities.MaintenanceSettings{},
 		entities.MaintenanceComment{},
 		entities.Incident{},
 		entities.IncidentComment{},
+		entities.IncidentUpdate{},
+		entities.IncidentResponder{},
+		entities.Postmortem{},
+		entities.PostmortemActionItem{},
 		entities.Changelog{},
 		entities.ChangelogEntry{},
 		entities.Report{},
@@ -89,6 +100,9 @@ func runAPI(cmd *cobra.Command, args []string) {
 		entities.APIKey{},
 		entities.EscalationPolicy{},
 		entities.OnCallRotation{},
+		entities.EscalationStep{},
+		entities.NotificationAttempt{},
+		entities.WebhookEndpoint{},
 		entities.TeamInvitation{},
 	)
 
@@ -130,7 +144,29 @@ func runAPI(cmd *cobra.Command, args []string) {
 	teamCache := team.NewCache(redisClient)
 	teamService := team.NewService(conf.Team, teamRepository, storageService, emailSender, teamCache)
 
-	monitorService := monitor.NewService(db, redisClient)
+	monitorService := monitor.NewService(db, redisClient, secret.NewService(conf.Secrets))
+
+	// Probes that do not speak HTTP no longer report fake status codes
+	migrated, err := monitorService.MigrateLegacyAssertions(ctx)
+	if err != nil {
+		l.WithError(err).Fatal("Failed to migrate legacy monitor assertions")
+	}
+
+	if migrated > 0 {
+		l.WithField("count", migrated).Info("Migrated legacy monitor assertions")
+	}
+
+	// Database monitors no longer keep their credentials in their URL
+	migrated, err = monitorService.MigrateConnectionStrings(ctx)
+	if errors.Is(err, secret.ErrMissingKey) {
+		l.Warn("No secrets key configured, database monitors cannot be created or updated")
+	} else if err != nil {
+		l.WithError(err).Fatal("Failed to encrypt monitor connection strings")
+	}
+
+	if migrated > 0 {
+		l.WithField("count", migrated).Info("Encrypted monitor connection strings")
+	}
 
 	httpResultService := check.NewService(ch_db)
 
@@ -155,6 +191,26 @@ func runAPI(cmd *cobra.Command, args []string) {
 	incidentRepository := incident.NewRepository(db)
 	incidentService := incident.NewService(incidentRepository, eventService)
 
+	// Incidents are deduplicated by fingerprint within their team. The open
+	// incidents from before are fingerprinted first, as the old per target
+	// unique indexes prevent a heartbeat or assertion from ever failing twice
+	migrated, err = incidentService.MigrateLegacyFingerprints(ctx)
+	if err != nil {
+		l.WithError(err).Fatal("Failed to fingerprint legacy incidents")
+	}
+
+	if migrated > 0 {
+		l.WithField("count", migrated).Info("Fingerprinted legacy incidents")
+	}
+
+	for _, idx := range []string{"unresolved_monitor_incident", "unresolved_heartbeat_incident", "open_incident_fingerprint"} {
+		if db.Migrator().HasIndex(&entities.Incident{}, idx) {
+			if err := db.Migrator().DropIndex(&entities.Incident{}, idx); err != nil {
+				l.WithError(err).Fatalf("Failed to drop incident index %s", idx)
+			}
+		}
+	}
+
 	reportRepository := report.NewRepository(db)
 	reportsService := report.NewService(reportRepository)
 
@@ -164,6 +220,15 @@ func runAPI(cmd *cobra.Command, args []string) {
 	escalationRepo := escalation.NewRepository(db)
 	escalationService := escalation.NewService(escalationRepo)
 
+	deliveryRepository := delivery.NewRepository(db)
+	deliveryService := delivery.NewService(deliveryRepository)
+
+	webhookRepository := webhook.NewRepository(db)
+	webhookService := webhook.NewService(webhookRepository, deliveryService)
+
+	postmortemRepository := postmortem.NewRepository(db)
+	postmortemService := postmortem.NewService(postmortemRepository, incidentService, httpResultService, llm.NewClient("", "", ""))
+
 	srv, err := rest.NewServer(
 		conf.REST,
 		conf.OAuth,
@@ -183,6 +248,9 @@ func runAPI(cmd *cobra.Command, args []string) {
 		reportsService,
 		statuspageService,
 		escalationService,
+		deliveryService,
+		webhookService,
+		postmortemService,
 		eventService,
 		apiKeyService,
 		emailSender,
@@ -190,6 +258,8 @@ func runAPI(cmd *cobra.Command, args []string) {
 		db,
 		ch_db,
 		conf.StatusPage.BaseURL,
+		conf.Phone,
+		conf.Slack,
 	)
 	if err != nil {
 		l.WithError(err).Fatal("Failed to create REST server")
@@ -6,7 +6,9 @@ import (
 	"strings"
 
 	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/notification/phone"
 	"github.com/opsway-io/backend/internal/rest/controllers"
+	"github.com/opsway-io/backend/internal/rest/controllers/webhooks"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -56,7 +58,12 @@ func runGenerate(cmd *cobra.Command, args []string) {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
 		"",
+		phone.Config{},
+		webhooks.SlackConfig{},
 	)
 
 	var routes []Route
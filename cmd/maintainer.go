@@ -11,6 +11,7 @@ import (
 	"github.com/opsway-io/backend/internal/maintenance"
 	"github.com/opsway-io/backend/internal/monitor"
 	"github.com/opsway-io/backend/internal/notification/email"
+	"github.com/opsway-io/backend/internal/secret"
 	"github.com/opsway-io/backend/internal/statuspage"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -41,7 +42,7 @@ var MaintainerCmd = &cobra.Command{
 			logger.WithError(err).Fatal("failed to connect to redis")
 		}
 
-		monitorService := monitor.NewService(db, redisClient)
+		monitorService := monitor.NewService(db, redisClient, secret.NewService(conf.Secrets))
 		eventService, err := event.NewService(redisClient)
 		if err != nil {
 			logger.WithError(err).Fatal("failed to create event service")
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -18,6 +19,8 @@ import (
 	"github.com/opsway-io/backend/internal/event"
 	"github.com/opsway-io/backend/internal/event/events"
 	"github.com/opsway-io/backend/internal/incident"
+	"github.com/opsway-io/backend/internal/monitor"
+	"github.com/opsway-io/backend/internal/probes"
 	"github.com/opsway-io/backend/internal/probes/dns"
 	"github.com/opsway-io/backend/internal/probes/http"
 	"github.com/opsway-io/backend/internal/probes/http/asserter"
@@ -27,7 +30,7 @@ import (
 	probeRedis "github.com/opsway-io/backend/internal/probes/redis"
 	"github.com/opsway-io/backend/internal/probes/tcp"
 	"github.com/opsway-io/backend/internal/probes/browser"
-	"github.com/redis/go-redis/v9"
+	"github.com/opsway-io/backend/internal/secret"
 	"github.com/sirupsen/logrus"
 
 	"github.com/spf13/cobra"
@@ -47,6 +50,13 @@ var proberCmd = &cobra.Command{
 
 var asserterInst = asserter.New()
 
+// Max number of bytes of the response of TCP monitors kept with the check
+const maxBannerLength = 1024
+
+// Max number of rows of the query result of database monitors kept with the
+// check
+const maxQueryRows = 10
+
 //nolint:gochecknoinits
 func init() {
 	rootCmd.AddCommand(proberCmd)
@@ -95,10 +105,13 @@ func runProber(cmd *cobra.Command, args []string) {
 	incidentRepository := incident.NewRepository(db)
 	incidentService := incident.NewService(incidentRepository, eventService)
 
+	monitorState := monitor.NewState(redisClient)
+	monitorService := monitor.NewService(db, redisClient, secret.NewService(conf.Secrets))
+
 	httpProber := http.NewService(conf.HTTPProbe)
-	tcpProber := tcp.NewService()
-	icmpProber := icmp.NewService()
-	dnsProber := dns.NewService()
+	tcpProber := tcp.NewService(conf.TCPProbe)
+	icmpProber := icmp.NewService(conf.ICMPProbe)
+	dnsProber := dns.NewService(conf.DNSProbe)
 	postgresProber := probePostgres.NewService()
 	mysqlProber := probeMysql.NewService()
 	redisProber := probeRedis.NewService()
@@ -131,56 +144,75 @@ func runProber(cmd *cobra.Command, args []string) {
 					return
 				}
 
-				handleTask(ctx, l, httpProber, tcpProber, icmpProber, dnsProber, postgresProber, mysqlProber, redisProber, browserProber, task.Monitor, httpResultService, incidentService, conf.Prober.Location, redisClient)
+				handleTask(ctx, l, httpProber, tcpProber, icmpProber, dnsProber, postgresProber, mysqlProber, redisProber, browserProber, task.Monitor, httpResultService, incidentService, conf.Prober.Location, monitorState, monitorService)
 				msg.Ack()
 			})
 		}
 	}
-
-	wp.StopWait()
-
-	l.Info("Goodbye!")
 }
 
-func handleTask(ctx context.Context, logger *logrus.Logger, httpProber http.Service, tcpProber tcp.Service, icmpProber icmp.Service, dnsProber dns.Service, postgresProber probePostgres.Service, mysqlProber probeMysql.Service, redisProber probeRedis.Service, browserProber browser.Service, m *entities.Monitor, c check.Service, i incident.Service, location string, rc *redis.Client) {
+func handleTask(ctx context.Context, logger *logrus.Logger, httpProber http.Service, tcpProber tcp.Service, icmpProber icmp.Service, dnsProber dns.Service, postgresProber probePostgres.Service, mysqlProber probeMysql.Service, redisProber probeRedis.Service, browserProber browser.Service, m *entities.Monitor, c check.Service, i incident.Service, location string, ms monitor.State, monitorService monitor.Service) {
 	l := logger.WithFields(logrus.Fields{
 		"monitor_id": m.ID,
 		"location":   location,
 	})
 
-	var res *http.Result
+	var res *probes.Result
 	var err error
 
-	timeout := time.Duration(time.Second * 5)
+	timeout := m.Settings.GetTimeout()
+
+	// Database monitors connect with their decrypted connection string
+	target, err := monitorService.GetProbeTarget(m)
+	if err != nil {
+		l.WithError(err).Error("failed to get probe target")
+
+		return
+	}
 
 	switch m.Settings.Method {
 	case "TCP":
-		res, err = tcpProber.Probe(ctx, m.Settings.URL, timeout)
+		res, err = tcpProber.Probe(ctx, m.Settings.URL, tcp.Options{
+			Send:      []byte(m.Settings.TCP.Send),
+			Expect:    m.Settings.TCP.Expect,
+			ReadBytes: int(m.Settings.TCP.ReadBytes),
+			TLS:       m.Settings.TCP.TLS,
+		}, timeout)
 	case "ICMP":
-		res, err = icmpProber.Probe(ctx, m.Settings.URL, timeout)
+		res, err = icmpProber.Probe(ctx, m.Settings.URL, icmp.Options{
+			PacketCount: int(m.Settings.ICMP.PacketCount),
+		}, timeout)
 	case "DNS":
-		res, err = dnsProber.Probe(ctx, m.Settings.URL, timeout)
+		res, err = dnsProber.Probe(ctx, m.Settings.URL, dns.Options{
+			RecordType: m.Settings.DNS.RecordType,
+			Nameserver: m.Settings.DNS.Nameserver,
+			Protocol:   m.Settings.DNS.Protocol,
+		}, timeout)
 	case "POSTGRES":
-		res, err = postgresProber.Probe(ctx, m.Settings.URL, timeout)
+		res, err = postgresProber.Probe(ctx, target, probePostgres.Options{
+			Query: m.Settings.Database.Query,
+		}, timeout)
 	case "MYSQL":
-		res, err = mysqlProber.Probe(ctx, m.Settings.URL, timeout)
+		res, err = mysqlProber.Probe(ctx, target, probeMysql.Options{
+			Query: m.Settings.Database.Query,
+		}, timeout)
 	case "REDIS":
-		res, err = redisProber.Probe(ctx, m.Settings.URL, timeout)
+		res, err = redisProber.Probe(ctx, target, probeRedis.Options{
+			Query: m.Settings.Database.Query,
+		}, timeout)
 	case "BROWSER":
 		var scriptJSON string
 		if m.Settings.Body.Content != nil {
 			scriptJSON = string(*m.Settings.Body.Content)
 		}
-		// Browser needs longer timeout, give it 15 seconds
-		browserTimeout := time.Duration(time.Second * 15)
-		res, err = browserProber.Probe(ctx, m.Settings.URL, scriptJSON, browserTimeout)
+		res, err = browserProber.Probe(ctx, m.Settings.URL, scriptJSON, timeout)
 	default:
 		res, err = httpProber.Probe(
 			ctx,
 			m.Settings.Method,
 			m.Settings.URL,
-			nil,
-			nil,
+			mapMonitorHeaders(m),
+			newMonitorRequestBody(m),
 			timeout,
 		)
 	}
@@ -192,10 +224,18 @@ func handleTask(ctx context.Context, logger *logrus.Logger, httpProber http.Serv
 	}
 
 	l = l.WithFields(logrus.Fields{
-		"status":     res.Response.StatusCode,
+		"success":    res.Success,
 		"total_time": fmt.Sprintf("%v", res.Timing.Phases.Total),
 	})
 
+	if res.HTTP != nil {
+		l = l.WithField("status", res.HTTP.StatusCode)
+	}
+
+	if res.Error != nil {
+		l = l.WithField("error_kind", res.Error.Kind)
+	}
+
 	newCheck := mapResultToCheck(m, res, location)
 
 	if err = c.Create(ctx, newCheck); err != nil {
@@ -219,39 +259,91 @@ func handleTask(ctx context.Context, logger *logrus.Logger, httpProber http.Serv
 		"assertions_failed": failedCount,
 	})
 
-	failKey := fmt.Sprintf("monitor:%d:failures", m.ID)
+	policy := m.Settings.IncidentPolicy
+	totalLocations := len(m.Settings.GetLocations())
+
+	// Failing locations are considered to fail at the same time if they have
+	// reported within the same interval, with one interval of slack for drift
+	quorumWindow := 2 * m.Settings.Frequency
+
+	state, err := ms.RecordCheck(ctx, m.ID, location, failedCount == 0, policy.GetHistorySize())
+	if err != nil {
+		l.WithError(err).Error("failed to record check state")
+
+		return
+	}
+
+	flapping := updateFlapping(ctx, l, m, location, state, ms, monitorService, i)
 
 	if failedCount > 0 {
-		l.Info("some assertions failed, incrementing failure counter")
-		val, err := rc.Incr(ctx, failKey).Result()
-		if err != nil {
-			l.WithError(err).Error("failed to increment failure counter")
-		}
-		
-		// Hardcoded threshold of 3 for MVP
-		if val == 3 {
-			l.Info("failure threshold reached, triggering incident")
-			if err = triggerIncident(ctx, m, res, &failed, i); err != nil {
-				l.WithError(err).Error("failed to trigger incident")
+		l.WithField("consecutive_failures", state.ConsecutiveFailures).Info("some assertions failed")
+
+		if policy.IsFailing(state.ConsecutiveFailures, state.RecentResults) {
+			failingLocations, err := ms.SetLocationDown(ctx, m.ID, location, quorumWindow)
+			if err != nil {
+				l.WithError(err).Error("failed to mark location as down")
+
+				return
+			}
+
+			l = l.WithField("failing_locations", failingLocations)
+
+			if policy.IsQuorumReached(len(failingLocations), totalLocations) {
+				down, err := ms.MarkDown(ctx, m.ID)
+				if err != nil {
+					l.WithError(err).Error("failed to mark monitor as down")
+				} else if down {
+					var suppressedReason *string
+					if flapping {
+						reason := entities.IncidentSuppressedFlapping
+						suppressedReason = &reason
+					} else if suppressedReason, err = upstreamSuppression(ctx, m, monitorService, i); err != nil {
+						l.WithError(err).Error("failed to check upstream monitors")
+					}
+
+					if suppressedReason != nil {
+						l.WithField("reason", *suppressedReason).Info("failure threshold reached, triggering suppressed incident")
+					} else {
+						l.Info("failure threshold reached, triggering incident")
+					}
+
+					if err = triggerIncident(ctx, m, res, &failed, failingLocations, suppressedReason, i); err != nil {
+						l.WithError(err).Error("failed to trigger incident")
+					}
+				} else {
+					liftSuppression(ctx, l, m, flapping, monitorService, i)
+				}
+			} else {
+				l.Info("failure threshold reached, waiting for location quorum")
 			}
-		} else if val > 3 {
-			// Already triggered, could optionally update the incident here
 		}
 	} else {
-		l.Info("all assertions passed")
-		
-		// Reset counter
-		rc.Del(ctx, failKey)
-		
-		// Auto-resolve any open incidents for this monitor
-		openIncidents, err := i.GetByMonitorIDWithAssertionPaginated(ctx, m.ID, nil, nil)
-		if err == nil && openIncidents != nil {
-			for _, inc := range *openIncidents {
-				if !inc.Incident.Resolved && inc.Incident.Title != "Anomaly Detected" && inc.Incident.Title != "SSL/TLS Cert Expiry" {
-					l.WithField("incident_id", inc.Incident.ID).Info("auto-resolving incident")
-					inc.Incident.Resolved = true
-					if err := i.Update(ctx, &inc.Incident); err != nil {
-						l.WithError(err).Error("failed to resolve incident")
+		l.WithField("consecutive_successes", state.ConsecutiveSuccesses).Info("all assertions passed")
+
+		if policy.IsRecovered(state.ConsecutiveSuccesses) {
+			failingLocations, err := ms.SetLocationUp(ctx, m.ID, location, quorumWindow)
+			if err != nil {
+				l.WithError(err).Error("failed to mark location as up")
+
+				return
+			}
+
+			if !policy.IsQuorumReached(len(failingLocations), totalLocations) {
+				if err := ms.MarkUp(ctx, m.ID); err != nil {
+					l.WithError(err).Error("failed to mark monitor as up")
+				}
+
+				// Auto-resolve any open incidents for this monitor
+				openIncidents, err := i.GetByMonitorIDWithAssertionPaginated(ctx, m.ID, nil, nil)
+				if err == nil && openIncidents != nil {
+					for _, inc := range *openIncidents {
+						if !inc.Incident.Resolved && inc.Incident.Kind == entities.IncidentKindAssertionFailure {
+							l.WithField("incident_id", inc.Incident.ID).Info("auto-resolving incident")
+							inc.Incident.Resolved = true
+							if err := i.Update(ctx, &inc.Incident); err != nil {
+								l.WithError(err).Error("failed to resolve incident")
+							}
+						}
 					}
 				}
 			}
@@ -263,29 +355,22 @@ func handleTask(ctx context.Context, logger *logrus.Logger, httpProber http.Serv
 	if err != nil {
 		l.WithError(err).Error("failed to check for anomaly")
 	} else if isAnomaly {
-		hasOpenAnomalyIncident := false
-		openIncidents, err := i.GetByMonitorIDWithAssertionPaginated(ctx, m.ID, nil, nil)
-		if err == nil && openIncidents != nil {
-			for _, inc := range *openIncidents {
-				if inc.Title == "Anomaly Detected" {
-					hasOpenAnomalyIncident = true
-					break
-				}
-			}
+		desc := "Response time anomaly detected by forecaster"
+		fingerprint := entities.MonitorIncidentFingerprint(entities.IncidentKindAnomaly, m.ID)
+		anomalyIncident := &entities.Incident{
+			MonitorID:   &m.ID,
+			TeamID:      m.TeamID,
+			Title:       "Anomaly Detected",
+			Description: &desc,
+			Source:      entities.IncidentSourceMonitor,
+			Kind:        entities.IncidentKindAnomaly,
+			Fingerprint: &fingerprint,
+			Severity:    entities.IncidentSeverityMinor,
 		}
-
-		if !hasOpenAnomalyIncident {
-			l.Info("anomaly detected, triggering incident")
-			desc := "Response time anomaly detected by forecaster"
-			anomalyIncident := entities.Incident{
-				MonitorID:   &m.ID,
-				TeamID:      m.TeamID,
-				Title:       "Anomaly Detected",
-				Description: &desc,
-			}
-			if err := i.Create(ctx, &[]entities.Incident{anomalyIncident}); err != nil {
-				l.WithError(err).Error("failed to trigger anomaly incident")
-			}
+		if _, opened, err := i.Open(ctx, anomalyIncident); err != nil {
+			l.WithError(err).Error("failed to trigger anomaly incident")
+		} else if opened {
+			l.Info("anomaly detected, triggered incident")
 		}
 	}
 
@@ -295,40 +380,35 @@ func handleTask(ctx context.Context, logger *logrus.Logger, httpProber http.Serv
 		timeRemaining := time.Until(expiry)
 
 		if timeRemaining > 0 && timeRemaining < 30*24*time.Hour {
-			hasOpenSSLIncident := false
-			openIncidents, err := i.GetByMonitorIDWithAssertionPaginated(ctx, m.ID, nil, nil)
-			if err == nil && openIncidents != nil {
-				for _, inc := range *openIncidents {
-					if inc.Title == "SSL/TLS Cert Expiry" {
-						hasOpenSSLIncident = true
-						break
-					}
-				}
+			desc := fmt.Sprintf("SSL/TLS certificate for %s expires in %.1f days (on %s)",
+				m.Settings.URL,
+				timeRemaining.Hours()/24,
+				expiry.Format(time.RFC822),
+			)
+			fingerprint := entities.MonitorIncidentFingerprint(entities.IncidentKindCertExpiry, m.ID)
+			sslIncident := &entities.Incident{
+				MonitorID:   &m.ID,
+				TeamID:      m.TeamID,
+				Title:       "SSL/TLS Cert Expiry",
+				Description: &desc,
+				Source:      entities.IncidentSourceMonitor,
+				Kind:        entities.IncidentKindCertExpiry,
+				Fingerprint: &fingerprint,
+				Severity:    certExpirySeverity(timeRemaining),
 			}
 
-			if !hasOpenSSLIncident {
-				l.Warn("SSL/TLS certificate is expiring soon, triggering incident")
-				desc := fmt.Sprintf("SSL/TLS certificate for %s expires in %.1f days (on %s)",
-					m.Settings.URL,
-					timeRemaining.Hours()/24,
-					expiry.Format(time.RFC822),
-				)
-				sslIncident := entities.Incident{
-					MonitorID:   &m.ID,
-					TeamID:      m.TeamID,
-					Title:       "SSL/TLS Cert Expiry",
-					Description: &desc,
-				}
-				if err := i.Create(ctx, &[]entities.Incident{sslIncident}); err != nil {
-					l.WithError(err).Error("failed to trigger SSL/TLS cert expiry incident")
-				}
+			// The open incident is raised in severity as the expiry gets closer
+			if _, opened, err := i.Open(ctx, sslIncident); err != nil {
+				l.WithError(err).Error("failed to trigger SSL/TLS cert expiry incident")
+			} else if opened {
+				l.Warn("SSL/TLS certificate is expiring soon, triggered incident")
 			}
 		} else {
 			// Auto-resolve any open SSL/TLS cert expiry incidents if the cert is now valid for >= 30 days
 			openIncidents, err := i.GetByMonitorIDWithAssertionPaginated(ctx, m.ID, nil, nil)
 			if err == nil && openIncidents != nil {
 				for _, inc := range *openIncidents {
-					if inc.Title == "SSL/TLS Cert Expiry" {
+					if inc.Kind == entities.IncidentKindCertExpiry {
 						l.Info("SSL/TLS certificate is now valid, resolving open incident")
 						inc.Incident.Resolved = true
 						if err := i.Update(ctx, &inc.Incident); err != nil {
@@ -341,7 +421,20 @@ func handleTask(ctx context.Context, logger *logrus.Logger, httpProber http.Serv
 	}
 }
 
-func checkAnomaly(monitorID uint, res *http.Result) (bool, error) {
+// certExpirySeverity returns the severity of a certificate that expires in
+// the given time
+func certExpirySeverity(remaining time.Duration) entities.IncidentSeverity {
+	switch {
+	case remaining < 24*time.Hour:
+		return entities.IncidentSeverityCritical
+	case remaining < 7*24*time.Hour:
+		return entities.IncidentSeverityMajor
+	default:
+		return entities.IncidentSeverityMinor
+	}
+}
+
+func checkAnomaly(monitorID uint, res *probes.Result) (bool, error) {
 	reqBody := fmt.Sprintf(`{"monitor_id": %d, "timings": [{"response_time": %f, "dns_lookup": %f, "tcp_connection": %f, "tls_handshake": %f, "server_processing": %f, "content_transfer": %f}]}`, 
 		monitorID, 
 		float64(res.Timing.Phases.Total.Milliseconds()),
@@ -369,14 +462,14 @@ func checkAnomaly(monitorID uint, res *http.Result) (bool, error) {
 	return strings.Contains(string(body), `"anomalies":[true]`) || strings.Contains(string(body), `"anomalies": [true]`), nil
 }
 
-func assertResult(httpResult *http.Result, assertions []entities.MonitorAssertion) ([]entities.MonitorAssertion, []entities.MonitorAssertion, error) {
+func assertResult(res *probes.Result, assertions []entities.MonitorAssertion) ([]entities.MonitorAssertion, []entities.MonitorAssertion, error) {
 	if len(assertions) == 0 {
 		return nil, nil, nil
 	}
 
 	rules := mapMonitorAssertionsToAssertionRules(assertions)
 
-	assertResult, err := asserterInst.Assert(httpResult, rules)
+	assertResult, err := asserterInst.Assert(res, rules)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -410,11 +503,34 @@ func mapMonitorAssertionsToAssertionRules(ma []entities.MonitorAssertion) []asse
 	return rules
 }
 
-func mapResultToCheck(m *entities.Monitor, res *http.Result, location string) *check.Check {
+func mapMonitorHeaders(m *entities.Monitor) map[string]string {
+	headers := make(map[string]string, len(m.Settings.Headers)+1)
+
+	// Explicitly configured headers take precedence over the body type
+	if contentType := m.Settings.Body.GetContentType(); contentType != "" && m.Settings.Body.Content != nil {
+		headers["Content-Type"] = contentType
+	}
+
+	for _, h := range m.Settings.Headers {
+		headers[xhttp.CanonicalHeaderKey(h.Key)] = h.Value
+	}
+
+	return headers
+}
+
+func newMonitorRequestBody(m *entities.Monitor) io.Reader {
+	if m.Settings.Body.Type == "NONE" || m.Settings.Body.Content == nil {
+		return nil
+	}
+
+	return bytes.NewReader(*m.Settings.Body.Content)
+}
+
+func mapResultToCheck(m *entities.Monitor, res *probes.Result, location string) *check.Check {
 	c := &check.Check{
 		MonitorID:  uint64(m.ID),
 		TeamID:     uint64(m.TeamID),
-		StatusCode: uint64(res.Response.StatusCode),
+		Success:    res.Success,
 		Method:     m.Settings.Method,
 		URL:        m.Settings.URL,
 		Location:   location,
@@ -428,6 +544,62 @@ func mapResultToCheck(m *entities.Monitor, res *http.Result, location string) *c
 		},
 	}
 
+	if res.HTTP != nil {
+		c.StatusCode = uint64(res.HTTP.StatusCode)
+	}
+
+	if res.Error != nil {
+		c.ErrorKind = string(res.Error.Kind)
+		c.Error = res.Error.Message
+	}
+
+	if res.DNS != nil {
+		c.DNSRecords = make([]check.DNSRecord, len(res.DNS.Records))
+		for i, r := range res.DNS.Records {
+			c.DNSRecords[i] = check.DNSRecord{
+				Name:  r.Name,
+				Type:  r.Type,
+				TTL:   r.TTL,
+				Value: r.Value,
+			}
+		}
+	}
+
+	// Only the start of the response is kept, which is enough to tell what
+	// answered
+	if res.TCP != nil {
+		banner := res.TCP.Data
+		if len(banner) > maxBannerLength {
+			banner = banner[:maxBannerLength]
+		}
+
+		c.Banner = strings.ToValidUTF8(string(banner), "\uFFFD")
+	}
+
+	if res.Query != nil {
+		rows := res.Query.Rows
+		if len(rows) > maxQueryRows {
+			rows = rows[:maxQueryRows]
+		}
+
+		c.Query = &check.Query{
+			Columns: res.Query.Columns,
+			Rows:    rows,
+		}
+	}
+
+	if res.Ping != nil {
+		c.Ping = &check.Ping{
+			Sent:       uint64(res.Ping.Sent),
+			Received:   uint64(res.Ping.Received),
+			PacketLoss: res.Ping.PacketLoss,
+			MinRTT:     res.Ping.MinRTT,
+			AvgRTT:     res.Ping.AvgRTT,
+			MaxRTT:     res.Ping.MaxRTT,
+			Jitter:     res.Ping.Jitter,
+		}
+	}
+
 	if res.TLS != nil {
 		c.TLS = &check.TLS{
 			Version:   res.TLS.Version,
@@ -442,20 +614,222 @@ func mapResultToCheck(m *entities.Monitor, res *http.Result, location string) *c
 	return c
 }
 
-func triggerIncident(ctx context.Context, m *entities.Monitor, hr *http.Result, failed *[]entities.MonitorAssertion, i incident.Service) error {
-	incidents := make([]entities.Incident, len(*failed))
+// updateFlapping detects whether the monitor oscillates between passing and
+// failing in the location. A monitor flaps while any of its locations does. A
+// single flapping incident is opened when the first location starts flapping,
+// and resolved once all are stable again. It returns whether the monitor is
+// flapping.
+func updateFlapping(ctx context.Context, l *logrus.Entry, m *entities.Monitor, location string, state *monitor.CheckState, ms monitor.State, monitorService monitor.Service, i incident.Service) bool {
+	policy := m.Settings.IncidentPolicy
+
+	wasFlapping, err := ms.IsLocationFlapping(ctx, m.ID, location)
+	if err != nil {
+		l.WithError(err).Error("failed to get flapping state")
+
+		return false
+	}
+
+	flapping := policy.IsFlapping(state.RecentResults, wasFlapping)
+
+	switch {
+	case flapping && !wasFlapping:
+		started, err := ms.MarkFlapping(ctx, m.ID, location)
+		if err != nil {
+			l.WithError(err).Error("failed to mark location as flapping")
+
+			return true
+		}
+
+		if !started {
+			return true
+		}
+
+		l.Info("monitor is flapping, triggering incident")
+
+		if err := monitorService.SetFlapping(ctx, m.ID, true); err != nil {
+			l.WithError(err).Error("failed to set monitor flapping")
+		}
+
+		desc := fmt.Sprintf("Monitor changed state in %d%% of the last %d checks, notifications are suppressed until it is stable",
+			policy.StateChangePercent(state.RecentResults),
+			policy.FlapWindow,
+		)
+		fingerprint := entities.MonitorIncidentFingerprint(entities.IncidentKindFlapping, m.ID)
+		flappingIncident := &entities.Incident{
+			MonitorID:   &m.ID,
+			TeamID:      m.TeamID,
+			Title:       "Monitor Flapping",
+			Description: &desc,
+			Source:      entities.IncidentSourceMonitor,
+			Kind:        entities.IncidentKindFlapping,
+			Fingerprint: &fingerprint,
+			Severity:    entities.IncidentSeverityMinor,
+		}
+		if _, _, err := i.Open(ctx, flappingIncident); err != nil {
+			l.WithError(err).Error("failed to trigger flapping incident")
+		}
+	case !flapping && wasFlapping:
+		stopped, err := ms.MarkStable(ctx, m.ID, location)
+		if err != nil {
+			l.WithError(err).Error("failed to mark location as stable")
+
+			return false
+		}
+
+		if !stopped {
+			return isMonitorFlapping(ctx, l, m, ms)
+		}
+
+		l.Info("monitor is stable again, resolving flapping incident")
+
+		if err := monitorService.SetFlapping(ctx, m.ID, false); err != nil {
+			l.WithError(err).Error("failed to clear monitor flapping")
+		}
+
+		openIncidents, err := i.GetByMonitorIDWithAssertionPaginated(ctx, m.ID, nil, nil)
+		if err == nil && openIncidents != nil {
+			for _, inc := range *openIncidents {
+				if inc.Kind == entities.IncidentKindFlapping {
+					inc.Incident.Resolved = true
+					if err := i.Update(ctx, &inc.Incident); err != nil {
+						l.WithError(err).Error("failed to resolve flapping incident")
+					}
+				}
+			}
+		}
+	}
+
+	if flapping {
+		return true
+	}
+
+	return isMonitorFlapping(ctx, l, m, ms)
+}
+
+func isMonitorFlapping(ctx context.Context, l *logrus.Entry, m *entities.Monitor, ms monitor.State) bool {
+	flapping, err := ms.IsFlapping(ctx, m.ID)
+	if err != nil {
+		l.WithError(err).Error("failed to get flapping state")
+
+		return false
+	}
 
+	return flapping
+}
+
+// upstreamSuppression returns the reason to suppress new incidents of the
+// monitor, nil if none of the monitors it depends on has an open incident.
+func upstreamSuppression(ctx context.Context, m *entities.Monitor, monitorService monitor.Service, i incident.Service) (*string, error) {
+	upstream, err := monitorService.GetUpstreamIDs(ctx, m.TeamID, m.ID)
+	if err != nil || len(upstream) == 0 {
+		return nil, err
+	}
+
+	open, err := i.GetActiveByMonitorIDs(ctx, upstream)
+	if err != nil || len(open) == 0 {
+		return nil, err
+	}
+
+	reason := entities.IncidentSuppressedUpstreamDown
+
+	return &reason, nil
+}
+
+// liftSuppression pages for the open incidents of the monitor that were
+// suppressed because it was flapping or a monitor it depends on was down, once
+// it is stable and none is while the monitor is still down. Incidents grouped
+// under an upstream incident that has been resolved since are taken out of the
+// group.
+func liftSuppression(ctx context.Context, l *logrus.Entry, m *entities.Monitor, flapping bool, monitorService monitor.Service, i incident.Service) {
+	if flapping {
+		return
+	}
+
+	open, err := i.GetActiveByMonitorIDs(ctx, []uint{m.ID})
+	if err != nil {
+		l.WithError(err).Error("failed to get open incidents")
+
+		return
+	}
+
+	var suppressed []entities.Incident
+	for _, inc := range open {
+		if inc.SuppressedReason == nil {
+			continue
+		}
+
+		switch *inc.SuppressedReason {
+		case entities.IncidentSuppressedFlapping, entities.IncidentSuppressedUpstreamDown:
+			suppressed = append(suppressed, inc)
+		}
+	}
+
+	if len(suppressed) == 0 {
+		return
+	}
+
+	if reason, err := upstreamSuppression(ctx, m, monitorService, i); err != nil {
+		l.WithError(err).Error("failed to check upstream monitors")
+
+		return
+	} else if reason != nil {
+		return
+	}
+
+	for j := range suppressed {
+		inc := &suppressed[j]
+
+		if inc.ParentID != nil {
+			parent, err := i.GetByID(ctx, *inc.ParentID)
+			if err != nil {
+				l.WithError(err).WithField("incident_id", inc.ID).Error("failed to get parent incident")
+
+				continue
+			}
+
+			if parent.Resolved {
+				if err := i.SetParent(ctx, inc, nil); err != nil {
+					l.WithError(err).WithField("incident_id", inc.ID).Error("failed to ungroup incident")
+
+					continue
+				}
+			}
+		}
+
+		l.WithFields(logrus.Fields{
+			"incident_id": inc.ID,
+			"reason":      *inc.SuppressedReason,
+		}).Info("monitor is still down, paging for suppressed incident")
+
+		if err := i.Unsuppress(ctx, inc); err != nil {
+			l.WithError(err).WithField("incident_id", inc.ID).Error("failed to unsuppress incident")
+		}
+	}
+}
+
+func triggerIncident(ctx context.Context, m *entities.Monitor, hr *probes.Result, failed *[]entities.MonitorAssertion, locations []string, suppressedReason *string, i incident.Service) error {
 	for j := range *failed {
 		assertion := (*failed)[j]
-		
-		incidents[j] = entities.Incident{
+		fingerprint := entities.AssertionIncidentFingerprint(assertion.ID)
+
+		in := &entities.Incident{
 			MonitorID:          &assertion.MonitorID,
 			TeamID:             m.TeamID,
 			Title:              assertion.Source,
 			Description:        &assertion.Source,
 			MonitorAssertionID: &assertion.ID,
+			FailedLocations:    locations,
+			Source:             entities.IncidentSourceMonitor,
+			Kind:               entities.IncidentKindAssertionFailure,
+			Fingerprint:        &fingerprint,
+			Severity:           entities.IncidentSeverityMajor,
+			SuppressedReason:   suppressedReason,
+		}
+
+		if _, _, err := i.Open(ctx, in); err != nil {
+			return err
 		}
 	}
 
-	return i.Upsert(ctx, &incidents)
+	return nil
 }
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/gammazero/workerpool"
 	"github.com/opsway-io/backend/internal/check"
@@ -151,6 +153,21 @@ func runReport(cmd *cobra.Command, args []string) {
 					return
 				}
 				reportData.Incident = incidentReport
+			case "INCIDENT_ANALYTICS":
+				start, end, err := parseReportRange(task.Start, task.End)
+				if err == nil {
+					reportData.IncidentAnalytics, err = incidentService.GetAnalytics(ctx, task.TeamID, incident.AnalyticsFilter{
+						Start: start,
+						End:   end,
+					})
+				}
+				if err != nil {
+					l.WithError(err).Error("failed to get incident analytics report")
+					rep.Status = entities.ReportStatusFailed
+					_ = reportService.Update(ctx, rep)
+					msg.Ack()
+					return
+				}
 			}
 
 			rep.Report = datatypes.NewJSONType(reportData)
@@ -170,3 +187,25 @@ func runReport(cmd *cobra.Command, args []string) {
 	l.Info("Shutting down report generator...")
 	wp.StopWait()
 }
+
+// parseReportRange parses the start and end of a report, given as RFC 3339
+// timestamps or dates. The end date is included in the range.
+func parseReportRange(start, end string) (time.Time, time.Time, error) {
+	s, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		if s, err = time.Parse(time.DateOnly, start); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid report start: %w", err)
+		}
+	}
+
+	e, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		if e, err = time.Parse(time.DateOnly, end); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid report end: %w", err)
+		}
+
+		e = e.AddDate(0, 0, 1)
+	}
+
+	return s, e, nil
+}
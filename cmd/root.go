@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -11,9 +12,15 @@ import (
 	"github.com/opsway-io/backend/internal/connectors/postgres"
 	"github.com/opsway-io/backend/internal/connectors/redis"
 	"github.com/opsway-io/backend/internal/notification/email"
+	"github.com/opsway-io/backend/internal/notification/phone"
+	"github.com/opsway-io/backend/internal/probes/dns"
 	"github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes/icmp"
+	"github.com/opsway-io/backend/internal/probes/tcp"
 	"github.com/opsway-io/backend/internal/rest"
 	"github.com/opsway-io/backend/internal/rest/controllers/authentication"
+	"github.com/opsway-io/backend/internal/rest/controllers/webhooks"
+	"github.com/opsway-io/backend/internal/secret"
 	"github.com/opsway-io/backend/internal/storage"
 	"github.com/opsway-io/backend/internal/team"
 	"github.com/opsway-io/backend/internal/user"
@@ -42,7 +49,13 @@ type Config struct {
 	ObjectStorage  storage.ObjectStorageRepositoryConfig `mapstructure:"object_storage"`
 	Prober         ProberConfig                          `mapstructure:"prober"`
 	HTTPProbe      http.Config                           `mapstructure:"http_probe"`
+	DNSProbe       dns.Config                            `mapstructure:"dns_probe"`
+	ICMPProbe      icmp.Config                           `mapstructure:"icmp_probe"`
+	TCPProbe       tcp.Config                            `mapstructure:"tcp_probe"`
+	Secrets        secret.Config                         `mapstructure:"secrets"`
 	Email          email.Config                          `mapstructure:"email"`
+	Phone          phone.Config                          `mapstructure:"phone"`
+	Slack          webhooks.SlackConfig                  `mapstructure:"slack"`
 	Team           team.Config                           `mapstructure:"team"`
 	User           user.Config                           `mapstructure:"user"`
 	Stripe         billing.Config                        `mapstructure:"stripe"`
@@ -105,6 +118,10 @@ func loadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	if config.Phone.CallbackBaseURL == "" {
+		config.Phone.CallbackBaseURL = fmt.Sprintf("http://localhost:%d", config.REST.Port)
+	}
+
 	if err := validate.Struct(config); err != nil {
 		return nil, err
 	}
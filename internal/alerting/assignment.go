@@ -0,0 +1,185 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/opsway-io/backend/internal/entities"
+	"github.com/opsway-io/backend/internal/event/events"
+	"github.com/opsway-io/backend/internal/notification/delivery"
+	"github.com/opsway-io/backend/internal/notification/email/templates"
+	"github.com/opsway-io/backend/internal/team"
+)
+
+func (w *worker) processAssignmentMessage(ctx context.Context, payload []byte) {
+	var ev events.IncidentAssignedEvent
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		w.logger.WithError(err).Error("failed to unmarshal event")
+		return
+	}
+
+	if ev.Incident == nil {
+		return
+	}
+
+	w.notifyAssignment(ctx, ev.Incident, ev.AssigneeID, ev.AssignedBy)
+}
+
+// notifyAssignment tells the assignee and the team chats who was assigned
+// to the incident.
+func (w *worker) notifyAssignment(ctx context.Context, incident *entities.Incident, assigneeID, assignedByID uint) {
+	assignee, assignedBy := w.findAssignmentUsers(ctx, incident.TeamID, assigneeID, assignedByID)
+	if assignee == nil {
+		w.logger.WithField("user_id", assigneeID).Warn("assignee is not a member of the incident team")
+		return
+	}
+
+	incidentID := incident.ID
+	d := delivery.Delivery{
+		TeamID:     incident.TeamID,
+		IncidentID: &incidentID,
+		RetryContext: map[string]string{
+			retryKind:       retryKindAssignment,
+			retryAssigneeID: strconv.FormatUint(uint64(assigneeID), 10),
+			retryAssignedBy: strconv.FormatUint(uint64(assignedByID), 10),
+		},
+	}
+
+	monitorName := w.getMonitorName(ctx, incident)
+	dashboardURL := fmt.Sprintf("%s/dashboard/incidents", w.config.ApplicationURL)
+
+	assignedByName := "A team member"
+	if assignedBy != nil {
+		assignedByName = userDisplayName(assignedBy)
+	}
+
+	tpl := &templates.IncidentAssignedTemplate{
+		Name:          userDisplayName(assignee),
+		AssignedBy:    assignedByName,
+		MonitorName:   monitorName,
+		IncidentTitle: incident.Title,
+		DashboardURL:  dashboardURL,
+	}
+
+	d.Channel = "email"
+	d.Recipient = assignee.Email
+	w.deliver(ctx, d, func(ctx context.Context) (int, error) {
+		return 0, w.emailSender.Send(ctx, "", assignee.Email, tpl)
+	})
+
+	text := fmt.Sprintf("%s assigned the incident \"%s\" on %s to %s", assignedByName, incident.Title, monitorName, userDisplayName(assignee))
+	w.sendChatMessages(ctx, d, text, dashboardURL)
+}
+
+// findAssignmentUsers looks up the assignee and the user who made the
+// assignment among the members of the team.
+func (w *worker) findAssignmentUsers(ctx context.Context, teamID, assigneeID, assignedByID uint) (assignee, assignedBy *team.TeamUser) {
+	offset := 0
+	limit := 100
+	query := ""
+
+	for {
+		users, err := w.teamService.GetUsersByID(ctx, teamID, &offset, &limit, &query, nil)
+		if err != nil {
+			w.logger.WithError(err).Error("failed to get team users")
+			return assignee, assignedBy
+		}
+
+		for i := range *users {
+			u := &(*users)[i]
+			switch u.ID {
+			case assigneeID:
+				assignee = u
+			case assignedByID:
+				assignedBy = u
+			}
+		}
+
+		if (assignee != nil && assignedBy != nil) || len(*users) < limit {
+			return assignee, assignedBy
+		}
+
+		offset += limit
+	}
+}
+
+// sendChatMessages posts a plain text message to every chat webhook configured
+// for the team.
+func (w *worker) sendChatMessages(ctx context.Context, d delivery.Delivery, text string, dashboardURL string) {
+	t, err := w.teamService.GetByID(ctx, d.TeamID)
+	if err != nil {
+		w.logger.WithError(err).Error("failed to get team for chat message")
+		return
+	}
+
+	webhooks := []struct {
+		channel string
+		url     *string
+		payload map[string]interface{}
+	}{
+		{"slack", t.SlackWebhookURL, map[string]interface{}{"text": fmt.Sprintf("%s\n<%s|View incident>", text, dashboardURL)}},
+		{"discord", t.DiscordWebhookURL, map[string]interface{}{"content": fmt.Sprintf("%s\n[View incident](%s)", text, dashboardURL)}},
+		{"msteams", t.MSTeamsWebhookURL, msTeamsTextCard(text, dashboardURL)},
+		{"mattermost", t.MattermostWebhookURL, map[string]interface{}{"username": "Opsway", "text": fmt.Sprintf("%s\n[View incident](%s)", text, dashboardURL)}},
+		{"google_chat", t.GoogleChatWebhookURL, map[string]interface{}{"text": fmt.Sprintf("%s\n%s", text, dashboardURL)}},
+	}
+
+	for _, wh := range webhooks {
+		if wh.url == nil || *wh.url == "" {
+			continue
+		}
+
+		endpoint := *wh.url
+		payload := wh.payload
+
+		d.Channel = wh.channel
+		d.Recipient = webhookRecipient(endpoint)
+		w.deliver(ctx, d, func(ctx context.Context) (int, error) {
+			return postJSON(ctx, endpoint, payload)
+		})
+	}
+}
+
+func msTeamsTextCard(text string, dashboardURL string) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{
+			{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body": []map[string]interface{}{
+						{
+							"type": "TextBlock",
+							"text": text,
+							"wrap": true,
+						},
+					},
+					"actions": []map[string]interface{}{
+						{
+							"type":  "Action.OpenUrl",
+							"title": "View incident",
+							"url":   dashboardURL,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func userDisplayName(u *team.TeamUser) string {
+	if u.DisplayName != nil && *u.DisplayName != "" {
+		return *u.DisplayName
+	}
+
+	if u.Name != "" {
+		return u.Name
+	}
+
+	return u.Email
+}
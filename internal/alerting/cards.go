@@ -0,0 +1,112 @@
+package alerting
+
+import "fmt"
+
+// AlertCard is the content of an incident alert posted to a chat channel
+type AlertCard struct {
+	IncidentID   uint
+	Monitor      string
+	Issue        string
+	DashboardURL string
+}
+
+// MSTeamsAlertPayload renders the alert as an adaptive card for a Microsoft
+// Teams workflow webhook
+func MSTeamsAlertPayload(card AlertCard) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{
+			{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body": []map[string]interface{}{
+						{
+							"type":   "TextBlock",
+							"text":   "🚨 Incident Alert",
+							"size":   "Large",
+							"weight": "Bolder",
+							"color":  "Attention",
+						},
+						{
+							"type": "FactSet",
+							"facts": []map[string]interface{}{
+								{"title": "Monitor", "value": card.Monitor},
+								{"title": "Issue", "value": card.Issue},
+							},
+						},
+					},
+					"actions": []map[string]interface{}{
+						{
+							"type":  "Action.OpenUrl",
+							"title": "Go to Dashboard",
+							"url":   card.DashboardURL,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// MattermostAlertPayload renders the alert as an attachment for a Mattermost
+// incoming webhook
+func MattermostAlertPayload(card AlertCard) map[string]interface{} {
+	return map[string]interface{}{
+		"username": "Opsway",
+		"attachments": []map[string]interface{}{
+			{
+				"fallback":   fmt.Sprintf("Incident Alert: %s - %s", card.Monitor, card.Issue),
+				"color":      "#FF0000",
+				"title":      "🚨 Incident Alert",
+				"title_link": card.DashboardURL,
+				"fields": []map[string]interface{}{
+					{"short": true, "title": "Monitor", "value": card.Monitor},
+					{"short": true, "title": "Issue", "value": card.Issue},
+				},
+				"text": fmt.Sprintf("[View details and manage this incident on the dashboard](%s)", card.DashboardURL),
+			},
+		},
+	}
+}
+
+// GoogleChatAlertPayload renders the alert as a card for a Google Chat space
+// webhook
+func GoogleChatAlertPayload(card AlertCard) map[string]interface{} {
+	return map[string]interface{}{
+		"text": fmt.Sprintf("Incident Alert: %s - %s", card.Monitor, card.Issue),
+		"cardsV2": []map[string]interface{}{
+			{
+				"cardId": fmt.Sprintf("incident-%d", card.IncidentID),
+				"card": map[string]interface{}{
+					"header": map[string]interface{}{
+						"title":    "🚨 Incident Alert",
+						"subtitle": card.Monitor,
+					},
+					"sections": []map[string]interface{}{
+						{
+							"widgets": []map[string]interface{}{
+								{"decoratedText": map[string]interface{}{"topLabel": "Monitor", "text": card.Monitor}},
+								{"decoratedText": map[string]interface{}{"topLabel": "Issue", "text": card.Issue}},
+								{
+									"buttonList": map[string]interface{}{
+										"buttons": []map[string]interface{}{
+											{
+												"text": "Go to Dashboard",
+												"onClick": map[string]interface{}{
+													"openLink": map[string]interface{}{"url": card.DashboardURL},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,94 @@
+package alerting_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/opsway-io/backend/internal/alerting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertPayloads(t *testing.T) {
+	t.Parallel()
+
+	card := alerting.AlertCard{
+		IncidentID:   42,
+		Monitor:      "API",
+		Issue:        "STATUS_CODE",
+		DashboardURL: "https://app.opsway.eu/dashboard/incidents",
+	}
+
+	tests := []struct {
+		name    string
+		payload map[string]interface{}
+		want    string
+	}{
+		{
+			name:    "microsoft teams",
+			payload: alerting.MSTeamsAlertPayload(card),
+			want: `{
+				"type": "message",
+				"attachments": [{
+					"contentType": "application/vnd.microsoft.card.adaptive",
+					"content": {
+						"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+						"type": "AdaptiveCard",
+						"version": "1.4",
+						"body": [
+							{"type": "TextBlock", "text": "🚨 Incident Alert", "size": "Large", "weight": "Bolder", "color": "Attention"},
+							{"type": "FactSet", "facts": [{"title": "Monitor", "value": "API"}, {"title": "Issue", "value": "STATUS_CODE"}]}
+						],
+						"actions": [{"type": "Action.OpenUrl", "title": "Go to Dashboard", "url": "https://app.opsway.eu/dashboard/incidents"}]
+					}
+				}]
+			}`,
+		},
+		{
+			name:    "mattermost",
+			payload: alerting.MattermostAlertPayload(card),
+			want: `{
+				"username": "Opsway",
+				"attachments": [{
+					"fallback": "Incident Alert: API - STATUS_CODE",
+					"color": "#FF0000",
+					"title": "🚨 Incident Alert",
+					"title_link": "https://app.opsway.eu/dashboard/incidents",
+					"fields": [
+						{"short": true, "title": "Monitor", "value": "API"},
+						{"short": true, "title": "Issue", "value": "STATUS_CODE"}
+					],
+					"text": "[View details and manage this incident on the dashboard](https://app.opsway.eu/dashboard/incidents)"
+				}]
+			}`,
+		},
+		{
+			name:    "google chat",
+			payload: alerting.GoogleChatAlertPayload(card),
+			want: `{
+				"text": "Incident Alert: API - STATUS_CODE",
+				"cardsV2": [{
+					"cardId": "incident-42",
+					"card": {
+						"header": {"title": "🚨 Incident Alert", "subtitle": "API"},
+						"sections": [{
+							"widgets": [
+								{"decoratedText": {"topLabel": "Monitor", "text": "API"}},
+								{"decoratedText": {"topLabel": "Issue", "text": "STATUS_CODE"}},
+								{"buttonList": {"buttons": [{"text": "Go to Dashboard", "onClick": {"openLink": {"url": "https://app.opsway.eu/dashboard/incidents"}}}]}}
+							]
+						}]
+					}
+				}]
+			}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.payload)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package alerting
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/opsway-io/backend/internal/entities"
+)
+
+var (
+	ErrInvalidSource     = errors.New("invalid incident source")
+	ErrInvalidSeverity   = errors.New("invalid incident severity")
+	ErrInvalidTimeWindow = errors.New("invalid time window")
+)
+
+// Subject is what an alert rule is evaluated against, an incident or a
+// maintenance window.
+type Subject struct {
+	Title       string
+	Source      entities.IncidentSource
+	Severity    entities.IncidentSeverity
+	MonitorIDs  []uint
+	MonitorTags []string
+	Locations   []string
+}
+
+func NewIncidentSubject(incident *entities.Incident, monitor *entities.Monitor) Subject {
+	s := Subject{
+		Title:     incident.Title,
+		Source:    incident.Source,
+		Severity:  incident.Severity,
+		Locations: incident.FailedLocations,
+	}
+
+	if incident.MonitorID != nil {
+		s.MonitorIDs = []uint{*incident.MonitorID}
+	}
+
+	if monitor != nil {
+		s.MonitorTags = monitor.Tags
+	}
+
+	return s
+}
+
+func NewMaintenanceSubject(maintenance *entities.Maintenance) Subject {
+	s := Subject{
+		Title: maintenance.Title,
+	}
+
+	for _, m := range maintenance.Monitors {
+		s.MonitorIDs = append(s.MonitorIDs, m.ID)
+		s.MonitorTags = append(s.MonitorTags, m.Tags...)
+	}
+
+	return s
+}
+
+// ValidateConditions checks that every value of the conditions is known and
+// that the time window can be evaluated.
+func ValidateConditions(c *entities.AlertRuleConditions) error {
+	if c == nil {
+		return nil
+	}
+
+	for _, source := range c.Sources {
+		if !slices.Contains(entities.IncidentSources, source) {
+			return fmt.Errorf("%w: %s", ErrInvalidSource, source)
+		}
+	}
+
+	for _, severity := range c.Severities {
+		if !slices.Contains(entities.IncidentSeverities, severity) {
+			return fmt.Errorf("%w: %s", ErrInvalidSeverity, severity)
+		}
+	}
+
+	if c.TimeWindow != nil {
+		if _, _, _, err := parseTimeWindow(c.TimeWindow); err != nil {
+			return err
+		}
+
+		for _, day := range c.TimeWindow.Days {
+			if day < time.Sunday || day > time.Saturday {
+				return fmt.Errorf("%w: invalid day %d", ErrInvalidTimeWindow, day)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RuleMatches reports whether the rule applies to the subject at the given time.
+func RuleMatches(rule *entities.AlertRule, s Subject, now time.Time) bool {
+	if rule.Conditions == nil {
+		return legacyConditionMatches(rule.Condition, s.Title)
+	}
+
+	c := rule.Conditions
+
+	if len(c.MonitorIDs) > 0 && !containsAny(c.MonitorIDs, s.MonitorIDs) {
+		return false
+	}
+
+	if len(c.MonitorTags) > 0 && !containsAny(c.MonitorTags, s.MonitorTags) {
+		return false
+	}
+
+	if len(c.Sources) > 0 && !slices.Contains(c.Sources, s.Source) {
+		return false
+	}
+
+	if len(c.Severities) > 0 && !slices.Contains(c.Severities, s.Severity) {
+		return false
+	}
+
+	if len(c.Locations) > 0 && !containsAny(c.Locations, s.Locations) {
+		return false
+	}
+
+	if c.TimeWindow != nil && !timeWindowMatches(c.TimeWindow, now) {
+		return false
+	}
+
+	return true
+}
+
+func legacyConditionMatches(condition string, title string) bool {
+	return condition == "*" ||
+		condition == "monitor_down" ||
+		strings.Contains(strings.ToLower(title), strings.ToLower(condition))
+}
+
+func timeWindowMatches(w *entities.AlertRuleTimeWindow, now time.Time) bool {
+	start, end, loc, err := parseTimeWindow(w)
+	if err != nil {
+		return false
+	}
+
+	now = now.In(loc)
+	minute := now.Hour()*60 + now.Minute()
+
+	inside := len(w.Days) == 0 || slices.Contains(w.Days, now.Weekday())
+	if inside {
+		if start <= end {
+			inside = minute >= start && minute < end
+		} else {
+			inside = minute >= start || minute < end
+		}
+	}
+
+	return inside != w.Outside
+}
+
+func parseTimeWindow(w *entities.AlertRuleTimeWindow) (int, int, *time.Location, error) {
+	start, err := parseTimeOfDay(w.Start)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	end, err := parseTimeOfDay(w.End)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	loc := time.UTC
+	if w.Timezone != "" {
+		loc, err = time.LoadLocation(w.Timezone)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("%w: unknown time zone %s", ErrInvalidTimeWindow, w.Timezone)
+		}
+	}
+
+	return start, end, loc, nil
+}
+
+func parseTimeOfDay(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s is not formatted as HH:MM", ErrInvalidTimeWindow, s)
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func containsAny[T comparable](values []T, candidates []T) bool {
+	for _, c := range candidates {
+		if slices.Contains(values, c) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,193 @@
+package alerting_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opsway-io/backend/internal/alerting"
+	"github.com/opsway-io/backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleMatches(t *testing.T) {
+	t.Parallel()
+
+	monitorID := uint(7)
+
+	subject := alerting.NewIncidentSubject(&entities.Incident{
+		MonitorID:       &monitorID,
+		Title:           "STATUS_CODE",
+		Source:          entities.IncidentSourceMonitor,
+		Severity:        entities.IncidentSeverityCritical,
+		FailedLocations: []string{"eu-central-1"},
+	}, &entities.Monitor{
+		ID:   monitorID,
+		Tags: []string{"production", "api"},
+	})
+
+	// Saturday 2024-01-06 22:30 UTC
+	weekendNight := time.Date(2024, 1, 6, 22, 30, 0, 0, time.UTC)
+	// Tuesday 2024-01-09 10:00 UTC
+	weekdayMorning := time.Date(2024, 1, 9, 10, 0, 0, 0, time.UTC)
+
+	businessHours := &entities.AlertRuleTimeWindow{
+		Days:  []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Start: "08:00",
+		End:   "18:00",
+	}
+
+	outsideBusinessHours := *businessHours
+	outsideBusinessHours.Outside = true
+
+	tests := []struct {
+		name string
+		rule entities.AlertRule
+		now  time.Time
+		want bool
+	}{
+		{
+			name: "Empty conditions match anything",
+			rule: entities.AlertRule{Conditions: &entities.AlertRuleConditions{}},
+			now:  weekdayMorning,
+			want: true,
+		},
+		{
+			name: "Monitor ID matches",
+			rule: entities.AlertRule{Conditions: &entities.AlertRuleConditions{MonitorIDs: []uint{1, 7}}},
+			now:  weekdayMorning,
+			want: true,
+		},
+		{
+			name: "Monitor ID does not match",
+			rule: entities.AlertRule{Conditions: &entities.AlertRuleConditions{MonitorIDs: []uint{1}}},
+			now:  weekdayMorning,
+			want: false,
+		},
+		{
+			name: "Monitor tag matches",
+			rule: entities.AlertRule{Conditions: &entities.AlertRuleConditions{MonitorTags: []string{"production"}}},
+			now:  weekdayMorning,
+			want: true,
+		},
+		{
+			name: "Source does not match",
+			rule: entities.AlertRule{Conditions: &entities.AlertRuleConditions{Sources: []entities.IncidentSource{entities.IncidentSourceHeartbeat}}},
+			now:  weekdayMorning,
+			want: false,
+		},
+		{
+			name: "Severity matches",
+			rule: entities.AlertRule{Conditions: &entities.AlertRuleConditions{Severities: []entities.IncidentSeverity{entities.IncidentSeverityCritical}}},
+			now:  weekdayMorning,
+			want: true,
+		},
+		{
+			name: "Location does not match",
+			rule: entities.AlertRule{Conditions: &entities.AlertRuleConditions{Locations: []string{"us-east-1"}}},
+			now:  weekdayMorning,
+			want: false,
+		},
+		{
+			name: "Production monitors outside business hours on a weekend night",
+			rule: entities.AlertRule{Conditions: &entities.AlertRuleConditions{
+				MonitorTags: []string{"production"},
+				TimeWindow:  &outsideBusinessHours,
+			}},
+			now:  weekendNight,
+			want: true,
+		},
+		{
+			name: "Production monitors outside business hours during business hours",
+			rule: entities.AlertRule{Conditions: &entities.AlertRuleConditions{
+				MonitorTags: []string{"production"},
+				TimeWindow:  &outsideBusinessHours,
+			}},
+			now:  weekdayMorning,
+			want: false,
+		},
+		{
+			name: "Window wrapping around midnight",
+			rule: entities.AlertRule{Conditions: &entities.AlertRuleConditions{
+				TimeWindow: &entities.AlertRuleTimeWindow{Start: "22:00", End: "06:00"},
+			}},
+			now:  weekendNight,
+			want: true,
+		},
+		{
+			name: "Window in another time zone",
+			rule: entities.AlertRule{Conditions: &entities.AlertRuleConditions{
+				TimeWindow: &entities.AlertRuleTimeWindow{Start: "08:00", End: "12:00", Timezone: "America/New_York"},
+			}},
+			now:  weekdayMorning,
+			want: false,
+		},
+		{
+			name: "Legacy wildcard condition",
+			rule: entities.AlertRule{Condition: "*"},
+			now:  weekdayMorning,
+			want: true,
+		},
+		{
+			name: "Legacy title condition",
+			rule: entities.AlertRule{Condition: "status"},
+			now:  weekdayMorning,
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := alerting.RuleMatches(&tt.rule, subject, tt.now)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateConditions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		conditions *entities.AlertRuleConditions
+		wantErr    error
+	}{
+		{
+			name:       "Valid conditions",
+			conditions: &entities.AlertRuleConditions{Sources: []entities.IncidentSource{entities.IncidentSourceDatadog}},
+		},
+		{
+			name:       "Unknown source",
+			conditions: &entities.AlertRuleConditions{Sources: []entities.IncidentSource{"PINGDOM"}},
+			wantErr:    alerting.ErrInvalidSource,
+		},
+		{
+			name:       "Unknown severity",
+			conditions: &entities.AlertRuleConditions{Severities: []entities.IncidentSeverity{"URGENT"}},
+			wantErr:    alerting.ErrInvalidSeverity,
+		},
+		{
+			name:       "Malformed time of day",
+			conditions: &entities.AlertRuleConditions{TimeWindow: &entities.AlertRuleTimeWindow{Start: "8am", End: "18:00"}},
+			wantErr:    alerting.ErrInvalidTimeWindow,
+		},
+		{
+			name:       "Unknown time zone",
+			conditions: &entities.AlertRuleConditions{TimeWindow: &entities.AlertRuleTimeWindow{Start: "08:00", End: "18:00", Timezone: "Mars/Olympus"}},
+			wantErr:    alerting.ErrInvalidTimeWindow,
+		},
+		{
+			name:       "Invalid day",
+			conditions: &entities.AlertRuleConditions{TimeWindow: &entities.AlertRuleTimeWindow{Days: []time.Weekday{7}, Start: "08:00", End: "18:00"}},
+			wantErr:    alerting.ErrInvalidTimeWindow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := alerting.ValidateConditions(tt.conditions)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
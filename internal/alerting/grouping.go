@@ -0,0 +1,133 @@
+package alerting
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/opsway-io/backend/internal/entities"
+)
+
+// GroupingMatches reports whether a new incident belongs to the group of the
+// parent incident under the strategy. Monitors are nil for incidents that are
+// not opened by a monitor, upstream are the IDs of the monitors the monitor
+// depends on. The time window is applied when finding parents.
+func GroupingMatches(strategy entities.IncidentGroupingStrategy, monitor, parentMonitor *entities.Monitor, upstream []uint) bool {
+	switch strategy {
+	case entities.IncidentGroupingTimeWindow:
+		return true
+	case entities.IncidentGroupingDomain:
+		if monitor == nil || parentMonitor == nil {
+			return false
+		}
+
+		host := monitorHost(monitor)
+
+		return host != "" && host == monitorHost(parentMonitor)
+	case entities.IncidentGroupingTag:
+		if monitor == nil || parentMonitor == nil {
+			return false
+		}
+
+		return slices.ContainsFunc(monitor.Tags, func(tag string) bool {
+			return slices.Contains(parentMonitor.Tags, tag)
+		})
+	case entities.IncidentGroupingDependency:
+		if parentMonitor == nil {
+			return false
+		}
+
+		return slices.Contains(upstream, parentMonitor.ID)
+	default:
+		return false
+	}
+}
+
+func monitorHost(m *entities.Monitor) string {
+	u, err := url.Parse(m.Settings.URL)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(u.Hostname())
+}
+
+// groupIncident folds the incident into the oldest open incident of the team
+// it matches under the grouping strategy of the team, and returns the parent.
+// Nil is returned if the incident opens a group of its own.
+func (w *worker) groupIncident(ctx context.Context, incident *entities.Incident, mon *entities.Monitor) *entities.Incident {
+	team, err := w.teamService.GetByID(ctx, incident.TeamID)
+	if err != nil {
+		w.logger.WithError(err).Error("failed to get team for incident grouping")
+		return nil
+	}
+
+	if team.IncidentGrouping == entities.IncidentGroupingNone || !team.IncidentGrouping.IsValid() {
+		return nil
+	}
+
+	openedAt := incident.CreatedAt
+	if openedAt.IsZero() {
+		openedAt = time.Now()
+	}
+
+	window := time.Duration(team.IncidentGroupingWindow) * time.Second
+
+	var upstream []uint
+	if team.IncidentGrouping == entities.IncidentGroupingDependency && incident.MonitorID != nil {
+		if upstream, err = w.monitorSvc.GetUpstreamIDs(ctx, incident.TeamID, *incident.MonitorID); err != nil {
+			w.logger.WithError(err).Error("failed to get upstream monitors for grouping")
+			return nil
+		}
+
+		if len(upstream) == 0 {
+			return nil
+		}
+	}
+
+	parents, err := w.incidentSvc.GetOpenParents(ctx, incident.TeamID, openedAt.Add(-window))
+	if err != nil {
+		w.logger.WithError(err).Error("failed to get open incidents for grouping")
+		return nil
+	}
+
+	monitors := map[uint]*entities.Monitor{}
+
+	for i := range parents {
+		parent := &parents[i]
+
+		// Only older incidents can be parents, so two incidents opened at the
+		// same time never end up grouped under each other
+		if parent.ID >= incident.ID {
+			continue
+		}
+
+		var parentMonitor *entities.Monitor
+		if parent.MonitorID != nil && team.IncidentGrouping != entities.IncidentGroupingTimeWindow {
+			var ok bool
+			if parentMonitor, ok = monitors[*parent.MonitorID]; !ok {
+				parentMonitor, err = w.monitorSvc.GetMonitorAndSettingsByTeamIDAndID(ctx, parent.TeamID, *parent.MonitorID)
+				if err != nil {
+					w.logger.WithError(err).Warn("failed to get monitor of incident, skipping it for grouping")
+				}
+
+				monitors[*parent.MonitorID] = parentMonitor
+			}
+		}
+
+		if !GroupingMatches(team.IncidentGrouping, mon, parentMonitor, upstream) {
+			continue
+		}
+
+		if err := w.incidentSvc.SetParent(ctx, incident, parent); err != nil {
+			w.logger.WithError(err).WithField("incident_id", incident.ID).Error("failed to group incident")
+			return nil
+		}
+
+		return parent
+	}
+
+	return nil
+}
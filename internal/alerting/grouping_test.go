@@ -0,0 +1,116 @@
+package alerting_test
+
+import (
+	"testing"
+
+	"github.com/opsway-io/backend/internal/alerting"
+	"github.com/opsway-io/backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupingMatches(t *testing.T) {
+	t.Parallel()
+
+	api := &entities.Monitor{
+		ID:       1,
+		Tags:     []string{"production", "api"},
+		Settings: entities.MonitorSettings{URL: "https://Example.com/api/health"},
+	}
+	web := &entities.Monitor{
+		ID:       2,
+		Tags:     []string{"production", "web"},
+		Settings: entities.MonitorSettings{URL: "https://example.com:8443/"},
+	}
+	docs := &entities.Monitor{
+		ID:       3,
+		Tags:     []string{"docs"},
+		Settings: entities.MonitorSettings{URL: "https://docs.example.org"},
+	}
+
+	tests := []struct {
+		name          string
+		strategy      entities.IncidentGroupingStrategy
+		monitor       *entities.Monitor
+		parentMonitor *entities.Monitor
+		upstream      []uint
+		want          bool
+	}{
+		{
+			name:          "None never groups",
+			strategy:      entities.IncidentGroupingNone,
+			monitor:       api,
+			parentMonitor: web,
+			want:          false,
+		},
+		{
+			name:          "Time window groups any incident",
+			strategy:      entities.IncidentGroupingTimeWindow,
+			monitor:       api,
+			parentMonitor: docs,
+			want:          true,
+		},
+		{
+			name:     "Time window groups incidents without monitors",
+			strategy: entities.IncidentGroupingTimeWindow,
+			want:     true,
+		},
+		{
+			name:          "Domain groups monitors on the same host",
+			strategy:      entities.IncidentGroupingDomain,
+			monitor:       api,
+			parentMonitor: web,
+			want:          true,
+		},
+		{
+			name:          "Domain does not group monitors on other hosts",
+			strategy:      entities.IncidentGroupingDomain,
+			monitor:       api,
+			parentMonitor: docs,
+			want:          false,
+		},
+		{
+			name:     "Domain does not group incidents without monitors",
+			strategy: entities.IncidentGroupingDomain,
+			monitor:  api,
+			want:     false,
+		},
+		{
+			name:          "Tag groups monitors sharing a tag",
+			strategy:      entities.IncidentGroupingTag,
+			monitor:       api,
+			parentMonitor: web,
+			want:          true,
+		},
+		{
+			name:          "Tag does not group monitors without shared tags",
+			strategy:      entities.IncidentGroupingTag,
+			monitor:       web,
+			parentMonitor: docs,
+			want:          false,
+		},
+		{
+			name:          "Dependency groups under monitors depended on",
+			strategy:      entities.IncidentGroupingDependency,
+			monitor:       web,
+			parentMonitor: api,
+			upstream:      []uint{api.ID},
+			want:          true,
+		},
+		{
+			name:          "Dependency does not group under other monitors",
+			strategy:      entities.IncidentGroupingDependency,
+			monitor:       web,
+			parentMonitor: docs,
+			upstream:      []uint{api.ID},
+			want:          false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, alerting.GroupingMatches(tt.strategy, tt.monitor, tt.parentMonitor, tt.upstream))
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/opsway-io/backend/internal/entities"
+	"github.com/opsway-io/backend/internal/event/events"
+	"github.com/opsway-io/backend/internal/notification/delivery"
+)
+
+// Channels that open alerts in external incident management tools, which are
+// kept in sync when the incident is acknowledged or resolved in opsway
+const (
+	channelPagerDuty = "pagerduty"
+	channelOpsgenie  = "opsgenie"
+)
+
+const (
+	pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+	opsgenieAPIURL     = "https://api.opsgenie.com"
+	opsgenieEUAPIURL   = "https://api.eu.opsgenie.com"
+
+	// Opsgenie rejects alert messages longer than this
+	opsgenieMaxMessageLength = 130
+)
+
+var pagerDutySeverities = map[entities.IncidentSeverity]string{
+	entities.IncidentSeverityCritical: "critical",
+	entities.IncidentSeverityMajor:    "error",
+	entities.IncidentSeverityMinor:    "warning",
+}
+
+var opsgeniePriorities = map[entities.IncidentSeverity]string{
+	entities.IncidentSeverityCritical: "P1",
+	entities.IncidentSeverityMajor:    "P2",
+	entities.IncidentSeverityMinor:    "P3",
+}
+
+func (w *worker) sendPagerDutyAlert(ctx context.Context, incident *entities.Incident, d delivery.Delivery) {
+	// Maintenance windows are not incidents and have no dedup key
+	if d.IncidentID == nil {
+		w.logger.Debug("pagerduty alerts are only sent for incidents")
+		return
+	}
+
+	team, err := w.teamService.GetByID(ctx, incident.TeamID)
+	if err != nil {
+		w.logger.WithError(err).Error("failed to get team for pagerduty alert")
+		return
+	}
+
+	if team.PagerDutyRoutingKey == nil || *team.PagerDutyRoutingKey == "" {
+		w.logger.Debug("pagerduty routing key not configured for team")
+		return
+	}
+
+	monitorName := w.getMonitorName(ctx, incident)
+
+	severity, ok := pagerDutySeverities[incident.Severity]
+	if !ok {
+		severity = "error"
+	}
+
+	payload := map[string]interface{}{
+		"routing_key":  *team.PagerDutyRoutingKey,
+		"event_action": "trigger",
+		"dedup_key":    incident.DedupKey(),
+		"payload": map[string]interface{}{
+			"summary":  fmt.Sprintf("%s: %s", monitorName, incident.Title),
+			"source":   "opsway",
+			"severity": severity,
+			"custom_details": map[string]interface{}{
+				"monitor":          monitorName,
+				"failed_locations": incident.FailedLocations,
+			},
+		},
+		"links": []map[string]interface{}{
+			{
+				"href": fmt.Sprintf("%s/dashboard/incidents", w.config.ApplicationURL),
+				"text": "View in Opsway",
+			},
+		},
+	}
+
+	d.Recipient = webhookRecipient(pagerDutyEventsURL)
+	w.deliver(ctx, d, func(ctx context.Context) (int, error) {
+		return postJSON(ctx, pagerDutyEventsURL, payload)
+	})
+}
+
+func (w *worker) sendOpsgenieAlert(ctx context.Context, incident *entities.Incident, d delivery.Delivery) {
+	// Maintenance windows are not incidents and have no alias
+	if d.IncidentID == nil {
+		w.logger.Debug("opsgenie alerts are only sent for incidents")
+		return
+	}
+
+	team, err := w.teamService.GetByID(ctx, incident.TeamID)
+	if err != nil {
+		w.logger.WithError(err).Error("failed to get team for opsgenie alert")
+		return
+	}
+
+	if team.OpsgenieAPIKey == nil || *team.OpsgenieAPIKey == "" {
+		w.logger.Debug("opsgenie api key not configured for team")
+		return
+	}
+
+	monitorName := w.getMonitorName(ctx, incident)
+
+	priority, ok := opsgeniePriorities[incident.Severity]
+	if !ok {
+		priority = "P2"
+	}
+
+	description := ""
+	if incident.Description != nil {
+		description = *incident.Description
+	}
+
+	payload := map[string]interface{}{
+		"message":     truncate(fmt.Sprintf("%s: %s", monitorName, incident.Title), opsgenieMaxMessageLength),
+		"alias":       incident.DedupKey(),
+		"description": description,
+		"priority":    priority,
+		"source":      "opsway",
+		"tags":        []string{"opsway"},
+		"details": map[string]string{
+			"monitor":   monitorName,
+			"dashboard": fmt.Sprintf("%s/dashboard/incidents", w.config.ApplicationURL),
+		},
+	}
+
+	endpoint := getOpsgenieAPIURL(team) + "/v2/alerts"
+
+	d.Recipient = webhookRecipient(endpoint)
+	w.deliver(ctx, d, func(ctx context.Context) (int, error) {
+		return postJSONWithHeaders(ctx, endpoint, opsgenieHeaders(team), payload)
+	})
+}
+
+// syncIncidentManagement acknowledges or resolves the alerts opened for the
+// incident in external incident management tools.
+func (w *worker) syncIncidentManagement(ctx context.Context, eventType events.EventType, incident *entities.Incident) {
+	attempts, err := w.deliverySvc.GetByIncidentID(ctx, incident.ID)
+	if err != nil {
+		w.logger.WithError(err).WithField("incident_id", incident.ID).Error("failed to get notification attempts")
+		return
+	}
+
+	var channels []string
+	for _, a := range attempts {
+		if a.Status == entities.NotificationAttemptStatusSent && !slices.Contains(channels, a.Channel) {
+			channels = append(channels, a.Channel)
+		}
+	}
+
+	if !slices.Contains(channels, channelPagerDuty) && !slices.Contains(channels, channelOpsgenie) {
+		return
+	}
+
+	team, err := w.teamService.GetByID(ctx, incident.TeamID)
+	if err != nil {
+		w.logger.WithError(err).Error("failed to get team for incident management sync")
+		return
+	}
+
+	resolved := eventType == events.EventTypeIncidentResolved
+
+	d := delivery.Delivery{
+		TeamID:     incident.TeamID,
+		IncidentID: &incident.ID,
+		RetryContext: map[string]string{
+			retryKind:  retryKindSync,
+			retryEvent: string(eventType),
+		},
+	}
+
+	if slices.Contains(channels, channelPagerDuty) && team.PagerDutyRoutingKey != nil && *team.PagerDutyRoutingKey != "" {
+		action := "acknowledge"
+		if resolved {
+			action = "resolve"
+		}
+
+		payload := map[string]interface{}{
+			"routing_key":  *team.PagerDutyRoutingKey,
+			"event_action": action,
+			"dedup_key":    incident.DedupKey(),
+		}
+
+		d.Channel = channelPagerDuty
+		d.Recipient = webhookRecipient(pagerDutyEventsURL)
+		w.deliver(ctx, d, func(ctx context.Context) (int, error) {
+			return postJSON(ctx, pagerDutyEventsURL, payload)
+		})
+	}
+
+	if slices.Contains(channels, channelOpsgenie) && team.OpsgenieAPIKey != nil && *team.OpsgenieAPIKey != "" {
+		action := "acknowledge"
+		if resolved {
+			action = "close"
+		}
+
+		endpoint := fmt.Sprintf(
+			"%s/v2/alerts/%s/%s?identifierType=alias",
+			getOpsgenieAPIURL(team),
+			url.PathEscape(incident.DedupKey()),
+			action,
+		)
+
+		payload := map[string]interface{}{
+			"source": "opsway",
+			"note":   fmt.Sprintf("Incident %sd in Opsway", action),
+		}
+
+		d.Channel = channelOpsgenie
+		d.Recipient = webhookRecipient(endpoint)
+		w.deliver(ctx, d, func(ctx context.Context) (int, error) {
+			return postJSONWithHeaders(ctx, endpoint, opsgenieHeaders(team), payload)
+		})
+	}
+}
+
+func getOpsgenieAPIURL(team *entities.Team) string {
+	if team.OpsgenieEURegion {
+		return opsgenieEUAPIURL
+	}
+
+	return opsgenieAPIURL
+}
+
+func opsgenieHeaders(team *entities.Team) map[string]string {
+	return map[string]string{
+		"Authorization": "GenieKey " + *team.OpsgenieAPIKey,
+	}
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+
+	return string(runes[:max-3]) + "..."
+}
@@ -0,0 +1,221 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/opsway-io/backend/internal/entities"
+	"github.com/opsway-io/backend/internal/event/events"
+	"github.com/opsway-io/backend/internal/incident"
+	"github.com/opsway-io/backend/internal/maintenance"
+	"github.com/opsway-io/backend/internal/notification/delivery"
+	"gorm.io/gorm"
+)
+
+// Channels alerts are sent through, which all resend their failed attempts
+var alertChannels = []string{
+	"email",
+	"slack",
+	"discord",
+	"msteams",
+	"mattermost",
+	"google_chat",
+	"telegram",
+	"sms",
+	"voice",
+	"datadog",
+	"new_relic",
+	channelPagerDuty,
+	channelOpsgenie,
+}
+
+// Keys of the retry context stored with every attempt, which tells the
+// resender how the notification was sent in the first place
+const (
+	retryKind       = "kind"
+	retryTier       = "tier"
+	retryAction     = "action"
+	retryAssigneeID = "assignee_id"
+	retryAssignedBy = "assigned_by"
+	retryEvent      = "event"
+)
+
+const (
+	retryKindAlert       = "alert"
+	retryKindMaintenance = "maintenance"
+	retryKindAssignment  = "assignment"
+	retryKindSync        = "sync"
+)
+
+type resendKey struct{}
+
+// resendCapture makes deliver keep the send func of the notification being
+// resent instead of delivering anything.
+type resendCapture struct {
+	channel   string
+	recipient string
+	send      delivery.SendFunc
+}
+
+// resend rebuilds the notification of a failed attempt by running the path
+// that sent it again, so the retry picks up the current team settings.
+func (w *worker) resend(ctx context.Context, a *entities.NotificationAttempt) (delivery.SendFunc, error) {
+	capture := &resendCapture{channel: a.Channel, recipient: a.Recipient}
+	ctx = context.WithValue(ctx, resendKey{}, capture)
+
+	var err error
+	switch a.RetryContext[retryKind] {
+	case retryKindAlert:
+		err = w.resendAlert(ctx, a)
+	case retryKindMaintenance:
+		err = w.resendMaintenance(ctx, a)
+	case retryKindAssignment:
+		err = w.resendAssignment(ctx, a)
+	case retryKindSync:
+		err = w.resendSync(ctx, a)
+	default:
+		err = fmt.Errorf("%w: unknown notification kind %q", delivery.ErrPermanent, a.RetryContext[retryKind])
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if capture.send == nil {
+		return nil, fmt.Errorf("%w: %s is no longer notified through %s", delivery.ErrPermanent, a.Recipient, a.Channel)
+	}
+
+	return capture.send, nil
+}
+
+func (w *worker) resendAlert(ctx context.Context, a *entities.NotificationAttempt) error {
+	inc, err := w.getAttemptIncident(ctx, a)
+	if err != nil {
+		return err
+	}
+
+	if inc.Acknowledged || inc.Resolved {
+		return fmt.Errorf("%w: incident %d is acknowledged or resolved", delivery.ErrPermanent, inc.ID)
+	}
+
+	rule, err := w.getAttemptRule(ctx, a)
+	if err != nil {
+		return err
+	}
+
+	tier, err := strconv.Atoi(a.RetryContext[retryTier])
+	if err != nil {
+		return fmt.Errorf("%w: invalid tier: %w", delivery.ErrPermanent, err)
+	}
+
+	w.sendAlert(ctx, a.Channel, inc, tier, newAlertDelivery(inc, rule, tier))
+
+	return nil
+}
+
+func (w *worker) resendMaintenance(ctx context.Context, a *entities.NotificationAttempt) error {
+	if a.MaintenanceID == nil {
+		return fmt.Errorf("%w: attempt has no maintenance", delivery.ErrPermanent)
+	}
+
+	m, err := w.maintenanceSvc.GetByID(ctx, *a.MaintenanceID)
+	if err != nil {
+		if errors.Is(err, maintenance.ErrNotFound) {
+			return fmt.Errorf("%w: %w", delivery.ErrPermanent, err)
+		}
+
+		return err
+	}
+
+	rule, err := w.getAttemptRule(ctx, a)
+	if err != nil {
+		return err
+	}
+
+	action := a.RetryContext[retryAction]
+	w.sendAlert(ctx, a.Channel, newMaintenanceIncident(m, action), 1, newMaintenanceDelivery(m, rule, action))
+
+	return nil
+}
+
+func (w *worker) resendAssignment(ctx context.Context, a *entities.NotificationAttempt) error {
+	inc, err := w.getAttemptIncident(ctx, a)
+	if err != nil {
+		return err
+	}
+
+	assigneeID, err := strconv.ParseUint(a.RetryContext[retryAssigneeID], 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid assignee: %w", delivery.ErrPermanent, err)
+	}
+
+	assignedBy, err := strconv.ParseUint(a.RetryContext[retryAssignedBy], 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid assigner: %w", delivery.ErrPermanent, err)
+	}
+
+	w.notifyAssignment(ctx, inc, uint(assigneeID), uint(assignedBy))
+
+	return nil
+}
+
+func (w *worker) resendSync(ctx context.Context, a *entities.NotificationAttempt) error {
+	inc, err := w.getAttemptIncident(ctx, a)
+	if err != nil {
+		return err
+	}
+
+	w.syncIncidentManagement(ctx, events.EventType(a.RetryContext[retryEvent]), inc)
+
+	return nil
+}
+
+func (w *worker) getAttemptIncident(ctx context.Context, a *entities.NotificationAttempt) (*entities.Incident, error) {
+	if a.IncidentID == nil {
+		return nil, fmt.Errorf("%w: attempt has no incident", delivery.ErrPermanent)
+	}
+
+	inc, err := w.incidentSvc.GetByID(ctx, *a.IncidentID)
+	if err != nil {
+		if errors.Is(err, incident.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %w", delivery.ErrPermanent, err)
+		}
+
+		return nil, err
+	}
+
+	return inc, nil
+}
+
+func (w *worker) getAttemptRule(ctx context.Context, a *entities.NotificationAttempt) (*entities.AlertRule, error) {
+	if a.AlertRuleID == nil {
+		return nil, fmt.Errorf("%w: attempt has no alert rule", delivery.ErrPermanent)
+	}
+
+	rule, err := w.alertService.GetByIDAndTeamID(ctx, a.TeamID, *a.AlertRuleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %w", delivery.ErrPermanent, err)
+		}
+
+		return nil, err
+	}
+
+	if !rule.Enabled {
+		return nil, fmt.Errorf("%w: alert rule %d is disabled", delivery.ErrPermanent, rule.ID)
+	}
+
+	channels, err := ruleChannels(rule)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", delivery.ErrPermanent, err)
+	}
+
+	if !slices.Contains(channels, a.Channel) {
+		return nil, fmt.Errorf("%w: alert rule %d no longer uses %s", delivery.ErrPermanent, rule.ID, a.Channel)
+	}
+
+	return rule, nil
+}
@@ -2,6 +2,8 @@ package alerting
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/opsway-io/backend/internal/entities"
 )
@@ -14,6 +16,8 @@ type Service interface {
 	Delete(ctx context.Context, rule *entities.AlertRule) error
 }
 
+var ErrInvalidRule = errors.New("invalid alert rule")
+
 type ServiceImpl struct {
 	repository Repository
 }
@@ -33,10 +37,18 @@ func (s *ServiceImpl) GetByIDAndTeamID(ctx context.Context, teamID uint, ruleID
 }
 
 func (s *ServiceImpl) Create(ctx context.Context, rule *entities.AlertRule) error {
+	if err := ValidateConditions(rule.Conditions); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRule, err)
+	}
+
 	return s.repository.Create(ctx, rule)
 }
 
 func (s *ServiceImpl) Update(ctx context.Context, rule *entities.AlertRule) error {
+	if err := ValidateConditions(rule.Conditions); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRule, err)
+	}
+
 	return s.repository.Update(ctx, rule)
 }
 
@@ -0,0 +1,43 @@
+package alerting
+
+import (
+	"context"
+
+	"github.com/opsway-io/backend/internal/entities"
+)
+
+// suppressIfUpstreamDown suppresses the incident if one of the monitors its
+// monitor depends on has an open incident, and reports whether it did. The
+// prober already checks this when opening the incident, this catches the
+// upstream monitor failing at the same time.
+func (w *worker) suppressIfUpstreamDown(ctx context.Context, incident *entities.Incident) bool {
+	if incident.MonitorID == nil {
+		return false
+	}
+
+	upstream, err := w.monitorSvc.GetUpstreamIDs(ctx, incident.TeamID, *incident.MonitorID)
+	if err != nil {
+		w.logger.WithError(err).Error("failed to get upstream monitors")
+		return false
+	}
+
+	if len(upstream) == 0 {
+		return false
+	}
+
+	open, err := w.incidentSvc.GetActiveByMonitorIDs(ctx, upstream)
+	if err != nil {
+		w.logger.WithError(err).Error("failed to get open incidents of upstream monitors")
+		return false
+	}
+
+	if len(open) == 0 {
+		return false
+	}
+
+	if err := w.incidentSvc.Suppress(ctx, incident, entities.IncidentSuppressedUpstreamDown); err != nil {
+		w.logger.WithError(err).WithField("incident_id", incident.ID).Error("failed to suppress incident")
+	}
+
+	return true
+}
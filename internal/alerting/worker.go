@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"time"
@@ -14,15 +17,28 @@ import (
 	"github.com/opsway-io/backend/internal/incident"
 	"github.com/opsway-io/backend/internal/event"
 	"github.com/opsway-io/backend/internal/event/events"
+	"github.com/opsway-io/backend/internal/maintenance"
 	"github.com/opsway-io/backend/internal/monitor"
+	"github.com/opsway-io/backend/internal/notification/delivery"
 	"github.com/opsway-io/backend/internal/notification/email"
+	"github.com/opsway-io/backend/internal/notification/phone"
 	"github.com/opsway-io/backend/internal/notification/email/templates"
 	"github.com/opsway-io/backend/internal/team"
 	"github.com/opsway-io/backend/internal/escalation"
 	"github.com/opsway-io/backend/internal/statuspage"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
+// How often pending escalation steps are checked
+const escalationPollInterval = 15 * time.Second
+
+// How long chat and incident management endpoints get to respond, so one
+// hung endpoint does not hold up every later alert
+const requestTimeout = 10 * time.Second
+
+var httpClient = &http.Client{Timeout: requestTimeout}
+
 type WorkerConfig struct {
 	ApplicationURL    string `mapstructure:"application_url" default:"http://localhost:5173"`
 	StatusPageBaseURL string `mapstructure:"status_page_base_url" default:"http://localhost:5174"`
@@ -40,8 +56,11 @@ type worker struct {
 	monitorSvc   monitor.Service
 	statusPageSvc statuspage.Service
 	emailSender  email.Sender
+	phoneSender  phone.Sender
 	escalationSvc escalation.Service
 	incidentSvc  incident.Service
+	deliverySvc  delivery.Service
+	maintenanceSvc maintenance.Service
 	logger       *logrus.Entry
 }
 
@@ -53,11 +72,14 @@ func NewWorker(
 	monitorSvc monitor.Service,
 	statusPageSvc statuspage.Service,
 	emailSender email.Sender,
+	phoneSender phone.Sender,
 	escalationSvc escalation.Service,
 	incidentSvc incident.Service,
+	deliverySvc delivery.Service,
+	maintenanceSvc maintenance.Service,
 	logger *logrus.Entry,
 ) Worker {
-	return &worker{
+	w := &worker{
 		config:       config,
 		eventService: eventService,
 		alertService: alertService,
@@ -65,10 +87,19 @@ func NewWorker(
 		monitorSvc:   monitorSvc,
 		statusPageSvc: statusPageSvc,
 		emailSender:  emailSender,
+		phoneSender:  phoneSender,
 		escalationSvc: escalationSvc,
 		incidentSvc:  incidentSvc,
+		deliverySvc:  deliverySvc,
+		maintenanceSvc: maintenanceSvc,
 		logger:       logger.WithField("component", "alerting_worker"),
 	}
+
+	for _, channel := range alertChannels {
+		deliverySvc.RegisterResender(channel, w.resend)
+	}
+
+	return w
 }
 
 func (w *worker) Start(ctx context.Context) error {
@@ -91,6 +122,44 @@ func (w *worker) Start(ctx context.Context) error {
 		}
 	}()
 
+	// Reopened incidents are paged like new ones
+	reopenedMessages, err := w.eventService.Subscribe(ctx, string(events.EventTypeIncidentReopened))
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to incident reopened stream: %w", err)
+	}
+
+	go func() {
+		for msg := range reopenedMessages {
+			w.processMessage(ctx, msg.Payload)
+			msg.Ack()
+		}
+	}()
+
+	// So are incidents that were suppressed while their monitor stays down
+	unsuppressedMessages, err := w.eventService.Subscribe(ctx, string(events.EventTypeIncidentUnsuppressed))
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to incident unsuppressed stream: %w", err)
+	}
+
+	go func() {
+		for msg := range unsuppressedMessages {
+			w.processMessage(ctx, msg.Payload)
+			msg.Ack()
+		}
+	}()
+
+	assignmentMessages, err := w.eventService.Subscribe(ctx, string(events.EventTypeIncidentAssigned))
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to incident assignment stream: %w", err)
+	}
+
+	go func() {
+		for msg := range assignmentMessages {
+			w.processAssignmentMessage(ctx, msg.Payload)
+			msg.Ack()
+		}
+	}()
+
 	go func() {
 		for msg := range maintenanceMessages {
 			w.processMaintenanceMessage(ctx, msg.Payload)
@@ -98,6 +167,22 @@ func (w *worker) Start(ctx context.Context) error {
 		}
 	}()
 
+	for _, eventType := range []events.EventType{events.EventTypeIncidentAcknowledged, events.EventTypeIncidentResolved} {
+		messages, err := w.eventService.Subscribe(ctx, string(eventType))
+		if err != nil {
+			return fmt.Errorf("failed to subscribe to %s stream: %w", eventType, err)
+		}
+
+		go func() {
+			for msg := range messages {
+				w.processResolutionMessage(ctx, eventType, msg.Payload)
+				msg.Ack()
+			}
+		}()
+	}
+
+	go w.runEscalations(ctx)
+
 	<-ctx.Done()
 	return nil
 }
@@ -110,7 +195,38 @@ func (w *worker) processMessage(ctx context.Context, payload []byte) {
 	}
 
 	incident := ev.Incident
-	if incident == nil {
+	if incident == nil || incident.ParentID != nil {
+		return
+	}
+
+	suppressed := incident.IsSuppressed() || w.suppressIfUpstreamDown(ctx, incident)
+
+	var mon *entities.Monitor
+	var err error
+	if incident.MonitorID != nil {
+		mon, err = w.monitorSvc.GetMonitorAndSettingsByTeamIDAndID(ctx, incident.TeamID, *incident.MonitorID)
+		if err != nil {
+			w.logger.WithError(err).Warn("failed to get monitor of incident, matching without monitor tags")
+		}
+	}
+
+	// Notifications go out once per group, for the parent incident. Suppressed
+	// incidents are grouped too, so those of a monitor whose upstream is down
+	// end up under the incident of the upstream.
+	if parent := w.groupIncident(ctx, incident, mon); parent != nil {
+		w.logger.WithFields(logrus.Fields{
+			"incident_id": incident.ID,
+			"parent_id":   parent.ID,
+		}).Info("grouped incident under open parent incident")
+		return
+	}
+
+	// Suppressed incidents are recorded without paging anyone
+	if suppressed {
+		w.logger.WithFields(logrus.Fields{
+			"incident_id": incident.ID,
+			"reason":      *incident.SuppressedReason,
+		}).Info("incident suppressed, not paging")
 		return
 	}
 
@@ -122,27 +238,21 @@ func (w *worker) processMessage(ctx context.Context, payload []byte) {
 
 	w.notifyStatusPageSubscribers(ctx, incident)
 
+	subject := NewIncidentSubject(incident, mon)
+	now := time.Now()
+	escalationScheduled := false
+
 	for _, rule := range rules {
-		if !rule.Enabled {
+		if !rule.Enabled || !RuleMatches(&rule, subject, now) {
 			continue
 		}
 
-		// MVP match: simple substring or check if condition is present in title
-		if strings.Contains(strings.ToLower(incident.Title), strings.ToLower(rule.Condition)) ||
-			rule.Condition == "monitor_down" || // Fallback matching for the UI example
-			rule.Condition == "*" { // Wildcard
-			w.triggerRule(ctx, incident, &rule, 1) // Base tier
-		}
-	}
+		w.triggerRule(ctx, incident, &rule, 1) // Base tier
 
-	// For escalation check
-	for _, rule := range rules {
-		if !rule.Enabled {
-			continue
-		}
-		if strings.Contains(strings.ToLower(incident.Title), strings.ToLower(rule.Condition)) || rule.Condition == "monitor_down" || rule.Condition == "*" {
-			w.scheduleEscalationCheck(ctx, incident, &rule)
-			break // Only schedule once per incident
+		// Only schedule once per incident
+		if !escalationScheduled {
+			w.scheduleEscalation(ctx, incident, &rule)
+			escalationScheduled = true
 		}
 	}
 }
@@ -167,12 +277,15 @@ func (w *worker) processMaintenanceMessage(ctx context.Context, payload []byte)
 
 	w.notifyMaintenanceStatusPageSubscribers(ctx, maintenance, ev.Action)
 
+	subject := NewMaintenanceSubject(maintenance)
+	now := time.Now()
+
 	for _, rule := range rules {
 		if !rule.Enabled {
 			continue
 		}
 
-		if strings.Contains(strings.ToLower(maintenance.Title), strings.ToLower(rule.Condition)) || rule.Condition == "*" {
+		if RuleMatches(&rule, subject, now) {
 			// Trigger rules for maintenance
 			// We can adapt triggerRule to accept maintenance, but for now we'll mock or create a mock incident just to send the alert, or add a new trigger method.
 			w.triggerMaintenanceRule(ctx, maintenance, &rule, ev.Action)
@@ -271,56 +384,88 @@ func (w *worker) notifyMaintenanceStatusPageSubscribers(ctx context.Context, mai
 	}
 }
 
-func (w *worker) scheduleEscalationCheck(ctx context.Context, incident *entities.Incident, rule *entities.AlertRule) {
-	// Simple goroutine delay for MVP. In production, use a durable queue (e.g. Watermill delayed messages)
-	policy, err := w.escalationSvc.GetPolicyByTeamID(ctx, incident.TeamID)
-	if err != nil || policy == nil {
-		return // No escalation policy
+func (w *worker) scheduleEscalation(ctx context.Context, incident *entities.Incident, rule *entities.AlertRule) {
+	if err := w.escalationSvc.ScheduleEscalation(ctx, incident, rule.ID); err != nil {
+		w.logger.WithError(err).WithField("incident_id", incident.ID).Error("failed to schedule escalation")
 	}
+}
 
-	rotations, err := w.escalationSvc.GetRotationsByPolicyID(ctx, policy.ID)
-	if err != nil {
-		w.logger.WithError(err).Error("failed to get rotations for escalation")
+func (w *worker) processResolutionMessage(ctx context.Context, eventType events.EventType, payload []byte) {
+	// Acknowledged and resolved events share the same payload
+	var ev events.IncidentResolvedEvent
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		w.logger.WithError(err).Error("failed to unmarshal event")
 		return
 	}
 
-	maxTier := 1
-	for _, r := range rotations {
-		if r.Tier > maxTier {
-			maxTier = r.Tier
-		}
+	if ev.Incident == nil {
+		return
 	}
 
-	if maxTier <= 1 {
-		return // No higher tiers to escalate to
+	if err := w.escalationSvc.CancelEscalation(ctx, ev.Incident.ID); err != nil {
+		w.logger.WithError(err).WithField("incident_id", ev.Incident.ID).Error("failed to cancel escalation")
 	}
 
-	go func() {
-		bgCtx := context.Background()
+	w.syncIncidentManagement(ctx, eventType, ev.Incident)
+}
 
-		for currentTier := 2; currentTier <= maxTier; currentTier++ {
-			w.logger.Infof("Scheduling escalation check for incident %d to Tier %d in %d minutes", incident.ID, currentTier, policy.EscalationTimeoutMinutes)
-			time.Sleep(time.Duration(policy.EscalationTimeoutMinutes) * time.Minute)
-			
-			inc, err := w.incidentSvc.GetByID(bgCtx, incident.ID)
-			if err != nil {
-				w.logger.WithError(err).Error("failed to get incident during escalation check")
-				return
+func (w *worker) runEscalations(ctx context.Context) {
+	ticker := time.NewTicker(escalationPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := w.escalationSvc.ProcessDueEscalations(ctx, w.escalate); err != nil {
+				w.logger.WithError(err).Error("failed to process escalations")
 			}
-			
-			if inc.Acknowledged || inc.Resolved {
-				w.logger.Infof("Incident %d acknowledged or resolved, stopping escalation", incident.ID)
-				return // Stop escalation loop
-			}
-			
-			w.logger.Infof("Escalating incident %d to Tier %d", incident.ID, currentTier)
-			w.triggerRule(bgCtx, inc, rule, currentTier)
 		}
-	}()
+	}
+}
+
+func (w *worker) escalate(ctx context.Context, step *entities.EscalationStep) error {
+	inc, err := w.incidentSvc.GetByID(ctx, step.IncidentID)
+	if err != nil {
+		if errors.Is(err, incident.ErrNotFound) {
+			return escalation.ErrStopEscalation
+		}
+
+		return err
+	}
+
+	if inc.Acknowledged || inc.Resolved {
+		w.logger.Infof("Incident %d acknowledged or resolved, stopping escalation", inc.ID)
+		return escalation.ErrStopEscalation
+	}
+
+	rule, err := w.alertService.GetByIDAndTeamID(ctx, inc.TeamID, step.AlertRuleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return escalation.ErrStopEscalation
+		}
+
+		return err
+	}
+
+	if !rule.Enabled {
+		return escalation.ErrStopEscalation
+	}
+
+	w.logger.Infof("Escalating incident %d to Tier %d", inc.ID, step.Tier)
+	w.triggerRule(ctx, inc, rule, step.Tier)
+
+	return nil
 }
 
 func (w *worker) triggerMaintenanceRule(ctx context.Context, maintenance *entities.Maintenance, rule *entities.AlertRule, action string) {
-	// Create a mock incident to reuse the incident alerting channels
+	w.sendAlerts(ctx, newMaintenanceIncident(maintenance, action), rule, 1, newMaintenanceDelivery(maintenance, rule, action))
+}
+
+// newMaintenanceIncident creates a mock incident to reuse the incident
+// alerting channels for maintenance windows.
+func newMaintenanceIncident(maintenance *entities.Maintenance, action string) *entities.Incident {
 	mockTitle := fmt.Sprintf("Maintenance %s: %s", strings.ToUpper(action), maintenance.Title)
 	mockDesc := maintenance.Description
 	
@@ -339,37 +484,167 @@ func (w *worker) triggerMaintenanceRule(ctx context.Context, maintenance *entiti
 		mockIncident.MonitorID = &maintenance.Monitors[0].ID
 	}
 
-	w.triggerRule(ctx, mockIncident, rule, 1)
+	return mockIncident
+}
+
+func newMaintenanceDelivery(maintenance *entities.Maintenance, rule *entities.AlertRule, action string) delivery.Delivery {
+	return delivery.Delivery{
+		TeamID:        maintenance.TeamID,
+		MaintenanceID: &maintenance.ID,
+		AlertRuleID:   &rule.ID,
+		RetryContext: map[string]string{
+			retryKind:   retryKindMaintenance,
+			retryAction: action,
+		},
+	}
 }
 
 func (w *worker) triggerRule(ctx context.Context, incident *entities.Incident, rule *entities.AlertRule, tier int) {
+	w.sendAlerts(ctx, incident, rule, tier, newAlertDelivery(incident, rule, tier))
+}
+
+func newAlertDelivery(incident *entities.Incident, rule *entities.AlertRule, tier int) delivery.Delivery {
+	return delivery.Delivery{
+		TeamID:      incident.TeamID,
+		IncidentID:  &incident.ID,
+		AlertRuleID: &rule.ID,
+		RetryContext: map[string]string{
+			retryKind: retryKindAlert,
+			retryTier: strconv.Itoa(tier),
+		},
+	}
+}
+
+func ruleChannels(rule *entities.AlertRule) ([]string, error) {
 	var channels []string
 	if err := json.Unmarshal([]byte(rule.Channels), &channels); err != nil {
+		return nil, err
+	}
+
+	return channels, nil
+}
+
+func (w *worker) sendAlerts(ctx context.Context, incident *entities.Incident, rule *entities.AlertRule, tier int, d delivery.Delivery) {
+	channels, err := ruleChannels(rule)
+	if err != nil {
 		w.logger.WithError(err).Error("failed to parse channels array")
 		return
 	}
 
 	for _, channel := range channels {
-		switch channel {
-		case "email":
-			w.sendEmailAlert(ctx, incident, tier)
-		case "discord":
-			w.sendDiscordAlert(ctx, incident)
-		case "telegram":
-			w.sendTelegramAlert(ctx, incident)
-		case "sms":
-			w.sendSmsAlert(ctx, incident, tier)
-		case "voice":
-			w.sendVoiceAlert(ctx, incident, tier)
-		case "datadog":
-			w.sendDatadogAlert(ctx, incident)
-		case "new_relic":
-			w.sendNewRelicAlert(ctx, incident)
-		}
-	}
-}
-
-func (w *worker) sendEmailAlert(ctx context.Context, incident *entities.Incident, tier int) {
+		w.sendAlert(ctx, channel, incident, tier, d)
+	}
+}
+
+// sendAlert sends the alert through a single channel.
+func (w *worker) sendAlert(ctx context.Context, channel string, incident *entities.Incident, tier int, d delivery.Delivery) {
+	d.Channel = channel
+
+	switch channel {
+	case "email":
+		w.sendEmailAlert(ctx, incident, tier, d)
+	case "slack":
+		w.sendSlackAlert(ctx, incident, d)
+	case "discord":
+		w.sendDiscordAlert(ctx, incident, d)
+	case "msteams":
+		w.sendMSTeamsAlert(ctx, incident, d)
+	case "mattermost":
+		w.sendMattermostAlert(ctx, incident, d)
+	case "google_chat":
+		w.sendGoogleChatAlert(ctx, incident, d)
+	case "telegram":
+		w.sendTelegramAlert(ctx, incident, d)
+	case "sms":
+		w.sendSmsAlert(ctx, incident, tier, d)
+	case "voice":
+		w.sendVoiceAlert(ctx, incident, tier, d)
+	case "datadog":
+		w.sendDatadogAlert(ctx, incident, d)
+	case "new_relic":
+		w.sendNewRelicAlert(ctx, incident, d)
+	case "pagerduty":
+		w.sendPagerDutyAlert(ctx, incident, d)
+	case "opsgenie":
+		w.sendOpsgenieAlert(ctx, incident, d)
+	}
+}
+
+// deliver sends a notification through the delivery service, which records
+// every attempt and retries failures. When a failed attempt is being resent
+// it only picks up the send func of that attempt.
+func (w *worker) deliver(ctx context.Context, d delivery.Delivery, send delivery.SendFunc) {
+	if capture, ok := ctx.Value(resendKey{}).(*resendCapture); ok {
+		if d.Channel == capture.channel && d.Recipient == capture.recipient {
+			capture.send = send
+		}
+
+		return
+	}
+
+	if err := w.deliverySvc.Deliver(ctx, d, send); errors.Is(err, delivery.ErrRetryScheduled) {
+		w.logger.WithError(err).WithField("channel", d.Channel).Warn("failed to deliver alert, retrying later")
+	} else if err != nil {
+		w.logger.WithError(err).WithField("channel", d.Channel).Error("failed to deliver alert")
+	} else {
+		w.logger.WithField("channel", d.Channel).Info("alert delivered successfully")
+	}
+}
+
+// postJSON posts the payload to the endpoint and returns the response status code.
+func postJSON(ctx context.Context, endpoint string, payload interface{}) (int, error) {
+	return postJSONWithHeaders(ctx, endpoint, nil, payload)
+}
+
+func postJSONWithHeaders(ctx context.Context, endpoint string, headers map[string]string, payload interface{}) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return resp.StatusCode, nil
+}
+
+func (w *worker) getMonitorName(ctx context.Context, incident *entities.Incident) string {
+	if incident.MonitorID != nil {
+		mon, err := w.monitorSvc.GetMonitorAndSettingsByTeamIDAndID(ctx, incident.TeamID, *incident.MonitorID)
+		if err == nil && mon != nil {
+			return mon.Name
+		}
+	} else if incident.HeartbeatID != nil {
+		return "Heartbeat Monitor"
+	}
+
+	return "Unknown Monitor / Heartbeat"
+}
+
+// webhookRecipient identifies a webhook by its host, as the full URL usually
+// contains a secret token.
+func webhookRecipient(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "webhook"
+	}
+
+	return u.Host
+}
+
+func (w *worker) sendEmailAlert(ctx context.Context, incident *entities.Incident, tier int, d delivery.Delivery) {
 	offset := 0
 	limit := 100
 	query := ""
@@ -421,14 +696,14 @@ func (w *worker) sendEmailAlert(ctx context.Context, incident *entities.Incident
 			DashboardURL:  dashboardURL,
 		}
 
-		err = w.emailSender.Send(ctx, "", u.Email, tpl)
-		if err != nil {
-			w.logger.WithError(err).Error("failed to send incident alert email")
-		}
+		d.Recipient = u.Email
+		w.deliver(ctx, d, func(ctx context.Context) (int, error) {
+			return 0, w.emailSender.Send(ctx, "", u.Email, tpl)
+		})
 	}
 }
 
-func (w *worker) sendSlackAlert(ctx context.Context, incident *entities.Incident) {
+func (w *worker) sendSlackAlert(ctx context.Context, incident *entities.Incident, d delivery.Delivery) {
 	team, err := w.teamService.GetByID(ctx, incident.TeamID)
 	if err != nil {
 		w.logger.WithError(err).Error("failed to get team for slack alert")
@@ -492,7 +767,7 @@ func (w *worker) sendSlackAlert(ctx context.Context, incident *entities.Incident
 							"text": "Acknowledge",
 						},
 						"style": "primary",
-						"value": fmt.Sprintf("ack_%d", incident.ID),
+						"value": fmt.Sprintf("ack_%d_%d", incident.TeamID, incident.ID),
 						"action_id": "acknowledge_incident",
 					},
 					{
@@ -502,7 +777,7 @@ func (w *worker) sendSlackAlert(ctx context.Context, incident *entities.Incident
 							"text": "Resolve",
 						},
 						"style": "danger",
-						"value": fmt.Sprintf("resolve_%d", incident.ID),
+						"value": fmt.Sprintf("resolve_%d_%d", incident.TeamID, incident.ID),
 						"action_id": "resolve_incident",
 					},
 				},
@@ -510,30 +785,13 @@ func (w *worker) sendSlackAlert(ctx context.Context, incident *entities.Incident
 		},
 	}
 
-	body, _ := json.Marshal(payload)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *team.SlackWebhookURL, bytes.NewReader(body))
-	if err != nil {
-		w.logger.WithError(err).Error("failed to create slack webhook request")
-		return
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		w.logger.WithError(err).Error("failed to send slack webhook")
-		return
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode >= 400 {
-		w.logger.Errorf("slack webhook returned error status: %d", resp.StatusCode)
-	} else {
-		w.logger.Info("slack webhook sent successfully")
-	}
+	d.Recipient = webhookRecipient(*team.SlackWebhookURL)
+	w.deliver(ctx, d, func(ctx context.Context) (int, error) {
+		return postJSON(ctx, *team.SlackWebhookURL, payload)
+	})
 }
 
-func (w *worker) sendDiscordAlert(ctx context.Context, incident *entities.Incident) {
+func (w *worker) sendDiscordAlert(ctx context.Context, incident *entities.Incident, d delivery.Delivery) {
 	team, err := w.teamService.GetByID(ctx, incident.TeamID)
 	if err != nil {
 		w.logger.WithError(err).Error("failed to get team for discord alert")
@@ -567,30 +825,97 @@ func (w *worker) sendDiscordAlert(ctx context.Context, incident *entities.Incide
 		},
 	}
 
-	body, _ := json.Marshal(payload)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *team.DiscordWebhookURL, bytes.NewReader(body))
+	d.Recipient = webhookRecipient(*team.DiscordWebhookURL)
+	w.deliver(ctx, d, func(ctx context.Context) (int, error) {
+		return postJSON(ctx, *team.DiscordWebhookURL, payload)
+	})
+}
+
+func (w *worker) sendMSTeamsAlert(ctx context.Context, incident *entities.Incident, d delivery.Delivery) {
+	team, err := w.teamService.GetByID(ctx, incident.TeamID)
 	if err != nil {
-		w.logger.WithError(err).Error("failed to create discord webhook request")
+		w.logger.WithError(err).Error("failed to get team for microsoft teams alert")
 		return
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	if team.MSTeamsWebhookURL == nil || *team.MSTeamsWebhookURL == "" {
+		w.logger.Debug("microsoft teams webhook not configured for team")
+		return
+	}
+
+	monitorName := w.getMonitorName(ctx, incident)
+	dashboardURL := fmt.Sprintf("%s/dashboard/incidents", w.config.ApplicationURL)
+
+	payload := MSTeamsAlertPayload(AlertCard{
+		IncidentID:   incident.ID,
+		Monitor:      monitorName,
+		Issue:        incident.Title,
+		DashboardURL: dashboardURL,
+	})
+
+	d.Recipient = webhookRecipient(*team.MSTeamsWebhookURL)
+	w.deliver(ctx, d, func(ctx context.Context) (int, error) {
+		return postJSON(ctx, *team.MSTeamsWebhookURL, payload)
+	})
+}
+
+func (w *worker) sendMattermostAlert(ctx context.Context, incident *entities.Incident, d delivery.Delivery) {
+	team, err := w.teamService.GetByID(ctx, incident.TeamID)
 	if err != nil {
-		w.logger.WithError(err).Error("failed to send discord webhook")
+		w.logger.WithError(err).Error("failed to get team for mattermost alert")
 		return
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode >= 400 {
-		w.logger.Errorf("discord webhook returned error status: %d", resp.StatusCode)
-	} else {
-		w.logger.Info("discord webhook sent successfully")
+	if team.MattermostWebhookURL == nil || *team.MattermostWebhookURL == "" {
+		w.logger.Debug("mattermost webhook not configured for team")
+		return
 	}
+
+	monitorName := w.getMonitorName(ctx, incident)
+	dashboardURL := fmt.Sprintf("%s/dashboard/incidents", w.config.ApplicationURL)
+
+	payload := MattermostAlertPayload(AlertCard{
+		IncidentID:   incident.ID,
+		Monitor:      monitorName,
+		Issue:        incident.Title,
+		DashboardURL: dashboardURL,
+	})
+
+	d.Recipient = webhookRecipient(*team.MattermostWebhookURL)
+	w.deliver(ctx, d, func(ctx context.Context) (int, error) {
+		return postJSON(ctx, *team.MattermostWebhookURL, payload)
+	})
 }
 
-func (w *worker) sendTelegramAlert(ctx context.Context, incident *entities.Incident) {
+func (w *worker) sendGoogleChatAlert(ctx context.Context, incident *entities.Incident, d delivery.Delivery) {
+	team, err := w.teamService.GetByID(ctx, incident.TeamID)
+	if err != nil {
+		w.logger.WithError(err).Error("failed to get team for google chat alert")
+		return
+	}
+
+	if team.GoogleChatWebhookURL == nil || *team.GoogleChatWebhookURL == "" {
+		w.logger.Debug("google chat webhook not configured for team")
+		return
+	}
+
+	monitorName := w.getMonitorName(ctx, incident)
+	dashboardURL := fmt.Sprintf("%s/dashboard/incidents", w.config.ApplicationURL)
+
+	payload := GoogleChatAlertPayload(AlertCard{
+		IncidentID:   incident.ID,
+		Monitor:      monitorName,
+		Issue:        incident.Title,
+		DashboardURL: dashboardURL,
+	})
+
+	d.Recipient = webhookRecipient(*team.GoogleChatWebhookURL)
+	w.deliver(ctx, d, func(ctx context.Context) (int, error) {
+		return postJSON(ctx, *team.GoogleChatWebhookURL, payload)
+	})
+}
+
+func (w *worker) sendTelegramAlert(ctx context.Context, incident *entities.Incident, d delivery.Delivery) {
 	team, err := w.teamService.GetByID(ctx, incident.TeamID)
 	if err != nil {
 		w.logger.WithError(err).Error("failed to get team for telegram alert")
@@ -625,31 +950,14 @@ func (w *worker) sendTelegramAlert(ctx context.Context, incident *entities.Incid
 		"parse_mode": "Markdown",
 	}
 
-	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", botToken)
-	body, _ := json.Marshal(payload)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
-	if err != nil {
-		w.logger.WithError(err).Error("failed to create telegram request")
-		return
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		w.logger.WithError(err).Error("failed to send telegram alert")
-		return
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode >= 400 {
-		w.logger.Errorf("telegram alert returned error status: %d", resp.StatusCode)
-	} else {
-		w.logger.Info("telegram alert sent successfully")
-	}
+	telegramURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", botToken)
+	d.Recipient = *team.TelegramChatID
+	w.deliver(ctx, d, func(ctx context.Context) (int, error) {
+		return postJSON(ctx, telegramURL, payload)
+	})
 }
 
-func (w *worker) sendSmsAlert(ctx context.Context, incident *entities.Incident, tier int) {
+func (w *worker) sendSmsAlert(ctx context.Context, incident *entities.Incident, tier int, d delivery.Delivery) {
 	offset := 0
 	limit := 100
 	query := ""
@@ -674,13 +982,14 @@ func (w *worker) sendSmsAlert(ctx context.Context, incident *entities.Incident,
 			continue
 		}
 
-		w.logger.WithField("phone", *u.PhoneNumber).Info("mock sending SMS alert")
-		// In production, we would use the Twilio Go SDK or HTTP request here
-		// twilioClient.SendMessage("mock_twilio_from", *u.PhoneNumber, "Incident Alert: "+incident.Title)
+		d.Recipient = *u.PhoneNumber
+		w.deliver(ctx, d, func(ctx context.Context) (int, error) {
+			return w.phoneSender.SendSMS(ctx, *u.PhoneNumber, "Opsway incident alert: "+incident.Title)
+		})
 	}
 }
 
-func (w *worker) sendVoiceAlert(ctx context.Context, incident *entities.Incident, tier int) {
+func (w *worker) sendVoiceAlert(ctx context.Context, incident *entities.Incident, tier int, d delivery.Delivery) {
 	offset := 0
 	limit := 100
 	query := ""
@@ -705,13 +1014,20 @@ func (w *worker) sendVoiceAlert(ctx context.Context, incident *entities.Incident
 			continue
 		}
 
-		w.logger.WithField("phone", *u.PhoneNumber).Info("mock sending Voice alert")
-		// In production, we would use the Twilio Go SDK or HTTP request here
-		// twilioClient.MakeCall("mock_twilio_from", *u.PhoneNumber, "<twiml>url</twiml>")
+		// Only real incidents can be acknowledged by keypress
+		var ack *phone.Acknowledgement
+		if d.IncidentID != nil {
+			ack = &phone.Acknowledgement{IncidentID: *d.IncidentID, UserID: u.ID}
+		}
+
+		d.Recipient = *u.PhoneNumber
+		w.deliver(ctx, d, func(ctx context.Context) (int, error) {
+			return w.phoneSender.Call(ctx, *u.PhoneNumber, "Opsway incident alert. "+incident.Title+".", ack)
+		})
 	}
 }
 
-func (w *worker) sendDatadogAlert(ctx context.Context, incident *entities.Incident) {
+func (w *worker) sendDatadogAlert(ctx context.Context, incident *entities.Incident, d delivery.Delivery) {
 	team, err := w.teamService.GetByID(ctx, incident.TeamID)
 	if err != nil {
 		w.logger.WithError(err).Error("failed to get team for datadog alert")
@@ -742,30 +1058,13 @@ func (w *worker) sendDatadogAlert(ctx context.Context, incident *entities.Incide
 		"tags": []string{"source:opsway", fmt.Sprintf("monitor:%s", monitorName)},
 	}
 
-	body, _ := json.Marshal(payload)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *team.DatadogWebhookURL, bytes.NewReader(body))
-	if err != nil {
-		w.logger.WithError(err).Error("failed to create datadog request")
-		return
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		w.logger.WithError(err).Error("failed to send datadog alert")
-		return
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode >= 400 {
-		w.logger.Errorf("datadog alert returned error status: %d", resp.StatusCode)
-	} else {
-		w.logger.Info("datadog alert sent successfully")
-	}
+	d.Recipient = webhookRecipient(*team.DatadogWebhookURL)
+	w.deliver(ctx, d, func(ctx context.Context) (int, error) {
+		return postJSON(ctx, *team.DatadogWebhookURL, payload)
+	})
 }
 
-func (w *worker) sendNewRelicAlert(ctx context.Context, incident *entities.Incident) {
+func (w *worker) sendNewRelicAlert(ctx context.Context, incident *entities.Incident, d delivery.Delivery) {
 	team, err := w.teamService.GetByID(ctx, incident.TeamID)
 	if err != nil {
 		w.logger.WithError(err).Error("failed to get team for new relic alert")
@@ -795,25 +1094,8 @@ func (w *worker) sendNewRelicAlert(ctx context.Context, incident *entities.Incid
 		"timestamp": time.Now().Unix(),
 	}
 
-	body, _ := json.Marshal(payload)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *team.NewRelicWebhookURL, bytes.NewReader(body))
-	if err != nil {
-		w.logger.WithError(err).Error("failed to create new relic request")
-		return
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		w.logger.WithError(err).Error("failed to send new relic alert")
-		return
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode >= 400 {
-		w.logger.Errorf("new relic alert returned error status: %d", resp.StatusCode)
-	} else {
-		w.logger.Info("new relic alert sent successfully")
-	}
+	d.Recipient = webhookRecipient(*team.NewRelicWebhookURL)
+	w.deliver(ctx, d, func(ctx context.Context) (int, error) {
+		return postJSON(ctx, *team.NewRelicWebhookURL, payload)
+	})
 }
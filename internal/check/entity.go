@@ -14,9 +14,16 @@ type Check struct {
 	Location   string    `gorm:"index;not null"`
 	MonitorID  uint64    `gorm:"index;not null"`
 	StatusCode uint64    `gorm:"index; not null"`
-	Timing     Timing    `gorm:"embedded;embeddedPrefix:timing_"`
-	TLS        *TLS      `gorm:"embedded;embeddedPrefix:tls_"`
-	CreatedAt  time.Time `gorm:"index"`
+	Success    bool      `gorm:"not null"`
+	ErrorKind  string    `gorm:"index"`
+	Error      string
+	Banner     string
+	Timing     Timing      `gorm:"embedded;embeddedPrefix:timing_"`
+	TLS        *TLS        `gorm:"embedded;embeddedPrefix:tls_"`
+	DNSRecords []DNSRecord `gorm:"type:String;serializer:json"`
+	Query      *Query      `gorm:"type:String;serializer:json"`
+	Ping       *Ping       `gorm:"embedded;embeddedPrefix:ping_"`
+	CreatedAt  time.Time   `gorm:"index"`
 }
 
 // TableName returns the table name for the Check model, with ClickHouse engine options
@@ -46,3 +53,26 @@ type TLS struct {
 	NotBefore time.Time
 	NotAfter  time.Time
 }
+
+type Ping struct {
+	Sent       uint64
+	Received   uint64
+	PacketLoss float64
+	MinRTT     time.Duration
+	AvgRTT     time.Duration
+	MaxRTT     time.Duration
+	Jitter     time.Duration
+}
+
+type DNSRecord struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	TTL   uint32 `json:"ttl"`
+	Value string `json:"value"`
+}
+
+// Result of the query of database monitors, with the first rows only
+type Query struct {
+	Columns []string   `json:"columns"`
+	Rows    [][]string `json:"rows"`
+}
@@ -3,6 +3,7 @@ package check
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/opsway-io/backend/internal/connectors/clickhouse"
@@ -11,6 +12,10 @@ import (
 
 var ErrNotFound = errors.New("probe result not found")
 
+// Whether a check succeeded, checks stored before the probes reported their
+// success only have the status code to go by
+const succeededCondition = "(success OR (status_code > 0 AND status_code < 400))"
+
 type Repository interface {
 	Create(ctx context.Context, maintenance *Check) error
 	GetByTeamIDAndMonitorIDAndCheckID(ctx context.Context, teamID uint, monitorID uint, checkID uuid.UUID) (*Check, error)
@@ -22,6 +27,7 @@ type Repository interface {
 	GetMonitorIDAndAssertions(ctx context.Context, monitorID uint, assertions []string) (*[]Check, error)
 	GetByTeamIDMonitorsUptime(ctx context.Context, teamID uint, start, end string) (*[]MonitorUptime, error)
 	GetByTeamIDMonitorsPerformance(ctx context.Context, teamID uint, start, end string) (*[]MonitorPerformance, error)
+	GetFailedByMonitorIDBetween(ctx context.Context, monitorID uint, start, end time.Time, limit int) (*[]Check, error)
 }
 
 type RepositoryImpl struct {
@@ -126,7 +132,7 @@ func (r *RepositoryImpl) GetMonitorStatsByMonitorID(ctx context.Context, monitor
 	err := r.db.WithContext(
 		ctx,
 	).Table("checks").Select(`
-		if(count() = 0, 0, (countIf(status_code < 400) / count()) * 100) as uptime_percentage,
+		if(count() = 0, 0, (countIf(`+succeededCondition+`) / count()) * 100) as uptime_percentage,
 		if(count() = 0, 0, avg(timing_total/1000000)) as average_response_time`).
 		Where("monitor_id = ?", monitorID).
 		Where("created_at BETWEEN DATE_SUB(NOW(), INTERVAL 1 DAY) AND NOW()").
@@ -157,7 +163,7 @@ func (r *RepositoryImpl) GetMonitorOverviewsByTeamID(ctx context.Context, teamID
 	).Table("checks").Select(`
 		monitor_id,
 		max(created_at) as latest, 
-		(countIf(status_code < 400) / count()) * 100 as uptime_percentage,
+		(countIf(`+succeededCondition+`) / count()) * 100 as uptime_percentage,
 		avg(timing_total/1000000) as average_response_time,
 		quantile(0.99)(timing_total)/1000000 as p99, 
 		quantile(0.95)(timing_total)/1000000 as p95`).
@@ -234,7 +240,7 @@ func (r *RepositoryImpl) GetByTeamIDMonitorsUptime(ctx context.Context, teamID u
 	).Table("checks").Select(`
 		monitor_id, 
 		url,
-		count(status_code <= 400) / count(status_code) * 100 as uptime_percentage, 
+		countIf(`+succeededCondition+`) / count() * 100 as uptime_percentage, 
 		avg(timing_total/1000000) as average_response_time, 
 		toMonth(created_at) as date`).
 		Where("team_id = ?", teamID).
@@ -277,3 +283,27 @@ func (r *RepositoryImpl) GetByTeamIDMonitorsPerformance(ctx context.Context, tea
 
 	return &performance, nil
 }
+
+// GetFailedByMonitorIDBetween returns the checks that failed in the window,
+// oldest first.
+func (r *RepositoryImpl) GetFailedByMonitorIDBetween(ctx context.Context, monitorID uint, start, end time.Time, limit int) (*[]Check, error) {
+	var checks []Check
+	err := r.db.WithContext(
+		ctx,
+	).Where(
+		"monitor_id = ? AND created_at BETWEEN ? AND ?", monitorID, start, end,
+	).Where(
+		"NOT " + succeededCondition,
+	).Order(
+		"created_at asc",
+	).Limit(
+		limit,
+	).Find(
+		&checks,
+	).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &checks, nil
+}
@@ -2,6 +2,7 @@ package check
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"gorm.io/gorm"
@@ -20,6 +21,7 @@ type Service interface {
 	GetMonitorIDAndAssertions(ctx context.Context, monitorID uint, assertions []string) (*[]Check, error)
 	GetByTeamIDMonitorsUptime(ctx context.Context, teamID uint, start, end string) (*[]MonitorUptime, error)
 	GetByTeamIDMonitorsPerformance(ctx context.Context, teamID uint, start, end string) (*[]MonitorPerformance, error)
+	GetFailedByMonitorIDBetween(ctx context.Context, monitorID uint, start, end time.Time, limit int) (*[]Check, error)
 }
 
 type ServiceImpl struct {
@@ -82,3 +84,6 @@ func (s *ServiceImpl) GetByTeamIDMonitorsUptime(ctx context.Context, teamID uint
 func (s *ServiceImpl) GetByTeamIDMonitorsPerformance(ctx context.Context, teamID uint, start, end string) (*[]MonitorPerformance, error) {
 	return s.repository.GetByTeamIDMonitorsPerformance(ctx, teamID, start, end)
 }
+func (s *ServiceImpl) GetFailedByMonitorIDBetween(ctx context.Context, monitorID uint, start, end time.Time, limit int) (*[]Check, error) {
+	return s.repository.GetFailedByMonitorIDBetween(ctx, monitorID, start, end, limit)
+}
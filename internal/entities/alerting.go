@@ -5,12 +5,44 @@ import (
 )
 
 type AlertRule struct {
-	ID        uint      `json:"id"`
-	TeamID    uint      `gorm:"index;not null" json:"teamId"`
-	Name      string    `json:"name"`
-	Condition string    `json:"condition"`
+	ID     uint   `json:"id"`
+	TeamID uint   `gorm:"index;not null" json:"teamId"`
+	Name   string `json:"name"`
+
+	// Free text condition of rules created before structured conditions,
+	// only evaluated when Conditions is not set
+	Condition  string               `json:"condition,omitempty"`
+	Conditions *AlertRuleConditions `gorm:"serializer:json" json:"conditions"`
+
 	Channels  string    `gorm:"type:jsonb;default:'[]'" json:"channels"`
 	Enabled   bool      `gorm:"default:true" json:"enabled"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
+
+// AlertRuleConditions must all match for a rule to trigger. Empty conditions
+// match anything, and a list matches if any of its values match.
+type AlertRuleConditions struct {
+	MonitorIDs  []uint               `json:"monitorIds,omitempty"`
+	MonitorTags []string             `json:"monitorTags,omitempty"`
+	Sources     []IncidentSource     `json:"sources,omitempty"`
+	Severities  []IncidentSeverity   `json:"severities,omitempty"`
+	Locations   []string             `json:"locations,omitempty"`
+	TimeWindow  *AlertRuleTimeWindow `json:"timeWindow,omitempty"`
+}
+
+type AlertRuleTimeWindow struct {
+	// Days of the week the window applies to, empty means every day
+	Days []time.Weekday `json:"days,omitempty"`
+
+	// Time of day formatted as HH:MM, the window wraps around midnight if End
+	// is before Start
+	Start string `json:"start"`
+	End   string `json:"end"`
+
+	// IANA time zone name, defaults to UTC
+	Timezone string `json:"timezone,omitempty"`
+
+	// Match when outside of the window instead of inside it
+	Outside bool `json:"outside,omitempty"`
+}
@@ -12,6 +12,12 @@ type EscalationPolicy struct {
 	// How many minutes to wait before escalating from Tier 1 to Tier 2
 	EscalationTimeoutMinutes int `gorm:"not null;default:5"`
 
+	// Start over from Tier 1 after the last tier until the incident is acknowledged
+	RepeatUntilAcknowledged bool `gorm:"not null;default:false"`
+
+	// Maximum number of times to start over, 0 means no limit
+	MaxRepeats int `gorm:"not null;default:0"`
+
 	CreatedAt time.Time `gorm:"index"`
 	UpdatedAt time.Time `gorm:"index"`
 }
@@ -35,3 +41,38 @@ type OnCallRotation struct {
 func (OnCallRotation) TableName() string {
 	return "on_call_rotations"
 }
+
+type EscalationStepStatus string
+
+const (
+	EscalationStepStatusPending   EscalationStepStatus = "PENDING"
+	EscalationStepStatusCompleted EscalationStepStatus = "COMPLETED"
+	EscalationStepStatusCanceled  EscalationStepStatus = "CANCELED"
+)
+
+type EscalationStep struct {
+	ID          uint
+	TeamID      uint `gorm:"index;not null"`
+	IncidentID  uint `gorm:"index;not null"`
+	AlertRuleID uint `gorm:"not null"`
+
+	// Tier to notify once the step is due
+	Tier int `gorm:"not null"`
+
+	// Number of times the policy has started over from Tier 1
+	Repeat int `gorm:"not null;default:0"`
+
+	Status EscalationStepStatus `gorm:"index;not null;default:PENDING"`
+	DueAt  time.Time            `gorm:"index;not null"`
+
+	// Set while an alerter is processing the step, once expired the step can
+	// be claimed by another alerter
+	LockedUntil *time.Time
+
+	CreatedAt time.Time `gorm:"index"`
+	UpdatedAt time.Time `gorm:"index"`
+}
+
+func (EscalationStep) TableName() string {
+	return "escalation_steps"
+}
@@ -1,24 +1,190 @@
 package entities
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// Prefix of the keys that identify incidents in external incident management
+// tools such as PagerDuty and Opsgenie
+const incidentDedupKeyPrefix = "opsway-incident-"
+
+type IncidentSource string
+
+const (
+	IncidentSourceMonitor   IncidentSource = "MONITOR"
+	IncidentSourceHeartbeat IncidentSource = "HEARTBEAT"
+	IncidentSourceDatadog   IncidentSource = "DATADOG"
+	IncidentSourceNewRelic  IncidentSource = "NEW_RELIC"
+)
+
+var IncidentSources = []IncidentSource{
+	IncidentSourceMonitor,
+	IncidentSourceHeartbeat,
+	IncidentSourceDatadog,
+	IncidentSourceNewRelic,
+}
+
+// IncidentKind tells what an incident is about, independent of its title
+type IncidentKind string
+
+const (
+	IncidentKindAssertionFailure IncidentKind = "ASSERTION_FAILURE"
+	IncidentKindAnomaly          IncidentKind = "ANOMALY"
+	IncidentKindCertExpiry       IncidentKind = "CERT_EXPIRY"
+	IncidentKindFlapping         IncidentKind = "FLAPPING"
+	IncidentKindHeartbeat        IncidentKind = "HEARTBEAT"
+	IncidentKindExternal         IncidentKind = "EXTERNAL"
+)
+
+var IncidentKinds = []IncidentKind{
+	IncidentKindAssertionFailure,
+	IncidentKindAnomaly,
+	IncidentKindCertExpiry,
+	IncidentKindFlapping,
+	IncidentKindHeartbeat,
+	IncidentKindExternal,
+}
+
+type IncidentSeverity string
+
+const (
+	IncidentSeverityCritical IncidentSeverity = "CRITICAL"
+	IncidentSeverityMajor    IncidentSeverity = "MAJOR"
+	IncidentSeverityMinor    IncidentSeverity = "MINOR"
+)
+
+var IncidentSeverities = []IncidentSeverity{
+	IncidentSeverityCritical,
+	IncidentSeverityMajor,
+	IncidentSeverityMinor,
+}
+
+type IncidentStatus string
+
+const (
+	IncidentStatusInvestigating IncidentStatus = "INVESTIGATING"
+	IncidentStatusIdentified    IncidentStatus = "IDENTIFIED"
+	IncidentStatusMonitoring    IncidentStatus = "MONITORING"
+	IncidentStatusResolved      IncidentStatus = "RESOLVED"
+)
+
+var IncidentStatuses = []IncidentStatus{
+	IncidentStatusInvestigating,
+	IncidentStatusIdentified,
+	IncidentStatusMonitoring,
+	IncidentStatusResolved,
+}
+
+// Reason recorded on incidents that are not paged because a monitor they
+// depend on is down
+const IncidentSuppressedUpstreamDown = "upstream down"
+
+// Reason recorded on incidents of monitors that are flapping, which are
+// covered by a single flapping incident
+const IncidentSuppressedFlapping = "flapping"
+
+// IncidentGroupingStrategy decides which new incidents of a team are folded
+// into an open parent incident, so a shared failure only pages once
+type IncidentGroupingStrategy string
+
+const (
+	IncidentGroupingNone       IncidentGroupingStrategy = "NONE"
+	IncidentGroupingTimeWindow IncidentGroupingStrategy = "TIME_WINDOW"
+	IncidentGroupingDomain     IncidentGroupingStrategy = "DOMAIN"
+	IncidentGroupingTag        IncidentGroupingStrategy = "TAG"
+	IncidentGroupingDependency IncidentGroupingStrategy = "DEPENDENCY"
+)
+
+var IncidentGroupingStrategies = []IncidentGroupingStrategy{
+	IncidentGroupingNone,
+	IncidentGroupingTimeWindow,
+	IncidentGroupingDomain,
+	IncidentGroupingTag,
+	IncidentGroupingDependency,
+}
+
+func (s IncidentGroupingStrategy) IsValid() bool {
+	switch s {
+	case IncidentGroupingNone, IncidentGroupingTimeWindow, IncidentGroupingDomain, IncidentGroupingTag, IncidentGroupingDependency:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s IncidentStatus) IsValid() bool {
+	switch s {
+	case IncidentStatusInvestigating, IncidentStatusIdentified, IncidentStatusMonitoring, IncidentStatusResolved:
+		return true
+	default:
+		return false
+	}
+}
+
+// Rank orders severities from least to most severe, 0 for unknown severities
+func (s IncidentSeverity) Rank() int {
+	switch s {
+	case IncidentSeverityMinor:
+		return 1
+	case IncidentSeverityMajor:
+		return 2
+	case IncidentSeverityCritical:
+		return 3
+	default:
+		return 0
+	}
+}
+
+func (s IncidentSeverity) IsValid() bool {
+	switch s {
+	case IncidentSeverityCritical, IncidentSeverityMajor, IncidentSeverityMinor:
+		return true
+	default:
+		return false
+	}
+}
+
 type Incident struct {
 	ID                 uint
-	TeamID             uint `gorm:"index;not null"`
+	TeamID             uint `gorm:"index;not null;uniqueIndex:open_team_incident_fingerprint,priority:1,where:resolved = false"`
 	MonitorID          *uint `gorm:"index"`
-	MonitorAssertionID *uint `gorm:"uniqueIndex:unresolved_monitor_incident"`
-	HeartbeatID        *uint `gorm:"uniqueIndex:unresolved_heartbeat_incident"`
+	MonitorAssertionID *uint `gorm:"index"`
+	HeartbeatID        *uint `gorm:"index"`
+	Source             IncidentSource `gorm:"index;not null;default:MONITOR"`
+	Kind               IncidentKind `gorm:"index;not null;default:ASSERTION_FAILURE"`
+	// Only one incident per fingerprint can be open at a time within a team
+	Fingerprint        *string `gorm:"uniqueIndex:open_team_incident_fingerprint,priority:2,where:resolved = false"`
+	Severity           IncidentSeverity `gorm:"index;not null;default:MAJOR"`
+	Status             IncidentStatus `gorm:"index;not null;default:INVESTIGATING"`
 	Resolved           bool `gorm:"not null;default:false"`
+	ResolvedAt         *time.Time
 	Acknowledged       bool `gorm:"not null;default:false"`
 	AcknowledgedBy     *uint `gorm:"index"`
 	AcknowledgedAt     *time.Time
+	AssigneeID         *uint `gorm:"index"`
+	CommanderID        *uint `gorm:"index"`
+	ParentID           *uint `gorm:"index"`
+	// Set once the incident is merged into another, it is resolved then
+	MergedIntoID       *uint `gorm:"index"`
+	// Set if the incident was split off another incident
+	SplitFromID        *uint `gorm:"index"`
+	// Set if the incident is recorded without paging anyone
+	SuppressedReason   *string
 
 	Title               string `gorm:"index;not null"`
 	Description         *string
 	RootCauseAnalysis   *string
+	FailedLocations     []string `gorm:"serializer:json"`
 	Comments    []IncidentComment `gorm:"constraint:OnDelete:CASCADE"`
+	Updates     []IncidentUpdate  `gorm:"constraint:OnDelete:CASCADE"`
+	Responders  []IncidentResponder `gorm:"constraint:OnDelete:CASCADE"`
+	Postmortem  *Postmortem       `gorm:"constraint:OnDelete:CASCADE"`
+	Children    []Incident        `gorm:"foreignKey:ParentID;constraint:OnDelete:SET NULL"`
 
 	CreatedAt time.Time `gorm:"index"`
 	UpdatedAt time.Time `gorm:"index"`
@@ -28,6 +194,67 @@ func (Incident) TableName() string {
 	return "incidents"
 }
 
+// SyncStatus keeps the status and the resolved flag in agreement, as the
+// incident can be resolved through either of them.
+func (i *Incident) SyncStatus() {
+	if i.Status == IncidentStatusResolved {
+		i.Resolved = true
+	} else if i.Resolved {
+		i.Status = IncidentStatusResolved
+	}
+}
+
+// MonitorIncidentFingerprint identifies incidents of a kind for a monitor
+func MonitorIncidentFingerprint(kind IncidentKind, monitorID uint) string {
+	return fmt.Sprintf("%s:monitor:%d", kind, monitorID)
+}
+
+// AssertionIncidentFingerprint identifies failures of a monitor assertion
+func AssertionIncidentFingerprint(monitorAssertionID uint) string {
+	return fmt.Sprintf("%s:assertion:%d", IncidentKindAssertionFailure, monitorAssertionID)
+}
+
+func HeartbeatIncidentFingerprint(heartbeatID uint) string {
+	return fmt.Sprintf("%s:heartbeat:%d", IncidentKindHeartbeat, heartbeatID)
+}
+
+// ExternalIncidentFingerprint identifies alerts of an external source by a
+// key that is stable across notifications of the same alert, such as its title
+func ExternalIncidentFingerprint(source IncidentSource, key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return fmt.Sprintf("%s:%s:%s", IncidentKindExternal, strings.ToLower(string(source)), hex.EncodeToString(sum[:8]))
+}
+
+func (i *Incident) IsMerged() bool {
+	return i.MergedIntoID != nil
+}
+
+func (i *Incident) IsSuppressed() bool {
+	return i.SuppressedReason != nil
+}
+
+// DedupKey identifies the incident in external incident management tools
+func (i *Incident) DedupKey() string {
+	return fmt.Sprintf("%s%d", incidentDedupKeyPrefix, i.ID)
+}
+
+// IncidentIDFromDedupKey returns the ID of the incident the key was created
+// for, false is returned if the key was not created by opsway.
+func IncidentIDFromDedupKey(key string) (uint, bool) {
+	idStr, ok := strings.CutPrefix(key, incidentDedupKeyPrefix)
+	if !ok {
+		return 0, false
+	}
+
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+
+	return uint(id), true
+}
+
 type IncidentComment struct {
 	ID         uint
 	UserID     uint `gorm:"index;not null"`
@@ -42,3 +269,35 @@ type IncidentComment struct {
 func (IncidentComment) TableName() string {
 	return "incident_comments"
 }
+
+// IncidentUpdate is an entry in the status timeline of an incident. Public
+// updates are shown on the status pages of the affected monitors, unlike
+// comments which are internal to the team.
+type IncidentUpdate struct {
+	ID         uint
+	IncidentID uint  `gorm:"index;not null"`
+	UserID     *uint `gorm:"index"`
+
+	Status  IncidentStatus `gorm:"not null"`
+	Message string         `gorm:"type:text;not null"`
+	Public  bool           `gorm:"index;not null;default:false"`
+
+	CreatedAt time.Time `gorm:"index"`
+	UpdatedAt time.Time `gorm:"index"`
+}
+
+func (IncidentUpdate) TableName() string {
+	return "incident_updates"
+}
+
+// IncidentResponder is a team member working on an incident
+type IncidentResponder struct {
+	IncidentID uint `gorm:"primaryKey;autoIncrement:false"`
+	UserID     uint `gorm:"primaryKey;autoIncrement:false;index"`
+
+	CreatedAt time.Time
+}
+
+func (IncidentResponder) TableName() string {
+	return "incident_responders"
+}
@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+func Test_IncidentIDFromDedupKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		key    string
+		wantID uint
+		wantOK bool
+	}{
+		{
+			name:   "Key of incident",
+			key:    (&Incident{ID: 42}).DedupKey(),
+			wantID: 42,
+			wantOK: true,
+		},
+		{
+			name:   "Foreign key",
+			key:    "b5a9c1e0-other-tool",
+			wantOK: false,
+		},
+		{
+			name:   "Missing ID",
+			key:    "opsway-incident-",
+			wantOK: false,
+		},
+		{
+			name:   "Invalid ID",
+			key:    "opsway-incident-abc",
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := IncidentIDFromDedupKey(tt.key)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantID, id)
+		})
+	}
+}
+
+func TestIncident_SyncStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		incident     Incident
+		wantStatus   IncidentStatus
+		wantResolved bool
+	}{
+		{
+			name:         "Open incident",
+			incident:     Incident{Status: IncidentStatusIdentified},
+			wantStatus:   IncidentStatusIdentified,
+			wantResolved: false,
+		},
+		{
+			name:         "Resolved through status",
+			incident:     Incident{Status: IncidentStatusResolved},
+			wantStatus:   IncidentStatusResolved,
+			wantResolved: true,
+		},
+		{
+			name:         "Resolved through flag",
+			incident:     Incident{Status: IncidentStatusMonitoring, Resolved: true},
+			wantStatus:   IncidentStatusResolved,
+			wantResolved: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.incident.SyncStatus()
+			assert.Equal(t, tt.wantStatus, tt.incident.Status)
+			assert.Equal(t, tt.wantResolved, tt.incident.Resolved)
+		})
+	}
+}
+
+func Test_ExternalIncidentFingerprint(t *testing.T) {
+	t.Parallel()
+
+	a := ExternalIncidentFingerprint(IncidentSourceDatadog, "CPU high")
+
+	assert.Equal(t, a, ExternalIncidentFingerprint(IncidentSourceDatadog, "CPU high"))
+	assert.NotEqual(t, a, ExternalIncidentFingerprint(IncidentSourceDatadog, "Disk full"))
+	assert.NotEqual(t, a, ExternalIncidentFingerprint(IncidentSourceNewRelic, "CPU high"))
+}
@@ -2,6 +2,8 @@ package entities
 
 import (
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type MonitorState int
@@ -16,12 +18,20 @@ type Monitor struct {
 	ID     uint `json:"id"`
 	TeamID uint `gorm:"index;not null" json:"teamId"`
 
-	State MonitorState `gorm:"not null;default:0" json:"state"`
-	Name  string       `gorm:"index;not null" json:"name"`
+	State MonitorState   `gorm:"not null;default:0" json:"state"`
+	Name  string         `gorm:"index;not null" json:"name"`
+	Tags  pq.StringArray `gorm:"type:text[]" json:"tags"`
+
+	// Set while the monitor oscillates between passing and failing
+	Flapping      bool       `gorm:"not null;default:false" json:"flapping"`
+	FlappingSince *time.Time `json:"flappingSince"`
 
 	Settings   MonitorSettings    `gorm:"not null;constraint:OnDelete:CASCADE" json:"settings"`
 	Assertions []MonitorAssertion `gorm:"constraint:OnDelete:CASCADE" json:"assertions"`
 	Incidents  []Incident         `gorm:"constraint:OnDelete:CASCADE" json:"incidents"`
+	// Monitors this monitor depends on, failures are not paged while one of
+	// them has an open incident
+	Dependencies []MonitorDependency `gorm:"constraint:OnDelete:CASCADE" json:"dependencies"`
 
 	CreatedAt time.Time `gorm:"index" json:"createdAt"`
 	UpdatedAt time.Time `gorm:"index" json:"updatedAt"`
@@ -70,6 +80,18 @@ func GetMonitorStateEnumFromString(state string) MonitorState {
 	}
 }
 
+type MonitorDependency struct {
+	MonitorID   uint    `gorm:"primaryKey" json:"monitorId"`
+	DependsOnID uint    `gorm:"primaryKey;index" json:"dependsOnId"`
+	DependsOn   Monitor `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (MonitorDependency) TableName() string {
+	return "monitor_dependencies"
+}
+
 type MonitorSettings struct {
 	ID        uint
 	MonitorID uint `gorm:"uniqueIndex;not null"`
@@ -77,15 +99,27 @@ type MonitorSettings struct {
 	Method    string        `gorm:"not null"`
 	URL       string        `gorm:"not null"`
 	Frequency time.Duration `gorm:"not null;serializer:timeDurationSeconds"`
-
-	Headers []MonitorSettingsHeader `gorm:"serializer:json"`
-	Body    MonitorSettingsBody     `gorm:"embedded;embeddedPrefix:body_"`
-	TLS     MonitorSettingsTLS      `gorm:"embedded;embeddedPrefix:tls_"`
+	Timeout   time.Duration `gorm:"not null;default:0;serializer:timeDurationSeconds"`
+
+	Headers   []MonitorSettingsHeader `gorm:"serializer:json"`
+	Body      MonitorSettingsBody     `gorm:"embedded;embeddedPrefix:body_"`
+	TLS       MonitorSettingsTLS      `gorm:"embedded;embeddedPrefix:tls_"`
+	DNS       MonitorSettingsDNS      `gorm:"embedded;embeddedPrefix:dns_"`
+	ICMP      MonitorSettingsICMP     `gorm:"embedded;embeddedPrefix:icmp_"`
+	TCP       MonitorSettingsTCP      `gorm:"embedded;embeddedPrefix:tcp_"`
+	Database  MonitorSettingsDatabase `gorm:"embedded;embeddedPrefix:database_"`
 	Locations []string                `gorm:"serializer:json"`
 
+	IncidentPolicy MonitorSettingsIncidentPolicy `gorm:"embedded;embeddedPrefix:incident_policy_"`
+
 	UpdatedAt time.Time `gorm:"index"`
 }
 
+const (
+	DefaultMonitorTimeout        = 5 * time.Second
+	DefaultBrowserMonitorTimeout = 15 * time.Second
+)
+
 type MonitorSettingsHeader struct {
 	Key   string `gorm:"not null"`
 	Value string `gorm:"not null"`
@@ -117,6 +151,21 @@ func (m *MonitorSettingsBody) GetContentString() *string {
 	return &body
 }
 
+// GetContentType returns the Content-Type matching the body type, or an
+// empty string if the body type has no associated content type.
+func (m *MonitorSettingsBody) GetContentType() string {
+	switch m.Type {
+	case "JSON", "GRAPHQL":
+		return "application/json"
+	case "XML":
+		return "application/xml"
+	case "RAW":
+		return "text/plain"
+	default:
+		return ""
+	}
+}
+
 type MonitorSettingsTLS struct {
 	Enabled                 bool  `gorm:"not null;default:false"`
 	VerifyHostname          *bool `gorm:"default:null"`
@@ -124,6 +173,203 @@ type MonitorSettingsTLS struct {
 	ExpirationThresholdDays *uint `gorm:"default:null"`
 }
 
+// Settings of DNS monitors, the URL of which is the host to look up
+type MonitorSettingsDNS struct {
+	// Type of the records to look up, A by default
+	RecordType string
+	// Address of the nameserver, or the URL of the endpoint for DNS over
+	// HTTPS. The default nameserver of the prober is asked when empty.
+	Nameserver string
+	// UDP, TCP, DOT or DOH, UDP by default
+	Protocol string
+}
+
+// Settings of ICMP monitors, the URL of which is the host to ping
+type MonitorSettingsICMP struct {
+	// Number of echo requests sent per check, the default of the prober is
+	// used when 0
+	PacketCount uint `gorm:"not null;default:0"`
+}
+
+// Settings of TCP monitors, the URL of which is the host and port to connect
+// to. The TLS settings tell whether to do a TLS handshake once connected.
+type MonitorSettingsTCP struct {
+	// Payload written once connected, nothing is sent when empty
+	Send string
+	// Regular expression the response must match
+	Expect string
+	// Number of bytes of the response to read, 0 reads until the expectation
+	// is met or, without one, the first data received
+	ReadBytes uint `gorm:"not null;default:0"`
+	// Do a TLS handshake once connected
+	TLS bool `gorm:"not null;default:false"`
+}
+
+// Settings of Postgres, MySQL and Redis monitors. Their URL has the password
+// redacted, the probes connect with the connection string.
+type MonitorSettingsDatabase struct {
+	// Encrypted URL including the credentials, empty for monitors created
+	// before connection strings were encrypted
+	ConnectionString string
+	// Query run once connected, such as SELECT count(*) FROM jobs, or command
+	// for Redis, such as INFO replication. The server is pinged when empty.
+	Query string
+}
+
+const (
+	DefaultFailureThreshold  = 3
+	DefaultRecoveryThreshold = 1
+	DefaultFlapThreshold     = 50
+)
+
+type MonitorSettingsIncidentPolicy struct {
+	// Number of failed checks before an incident is opened
+	FailureThreshold uint `gorm:"not null;default:3"`
+
+	// Number of consecutive passed checks before open incidents are resolved
+	RecoveryThreshold uint `gorm:"not null;default:1"`
+
+	// If set, an incident is opened when FailureThreshold of the last
+	// WindowSize checks failed, instead of requiring consecutive failures
+	WindowSize uint `gorm:"not null;default:0"`
+
+	// Number of locations that must be failing at the same time before an
+	// incident is opened, 0 means any single location
+	LocationQuorum uint `gorm:"not null;default:0"`
+
+	// Number of recent checks in which state changes are counted to detect
+	// flapping, 0 disables flap detection
+	FlapWindow uint `gorm:"not null;default:0"`
+
+	// Percentage of state changes within the flap window at which the
+	// monitor is flapping. It stabilizes again below half of it.
+	FlapThreshold uint `gorm:"not null;default:0"`
+}
+
+func (p *MonitorSettingsIncidentPolicy) GetFailureThreshold() uint {
+	if p.FailureThreshold == 0 {
+		return DefaultFailureThreshold
+	}
+
+	return p.FailureThreshold
+}
+
+func (p *MonitorSettingsIncidentPolicy) GetRecoveryThreshold() uint {
+	if p.RecoveryThreshold == 0 {
+		return DefaultRecoveryThreshold
+	}
+
+	return p.RecoveryThreshold
+}
+
+func (p *MonitorSettingsIncidentPolicy) GetFlapThreshold() uint {
+	if p.FlapThreshold == 0 {
+		return DefaultFlapThreshold
+	}
+
+	return p.FlapThreshold
+}
+
+// GetHistorySize returns the number of recent check results needed to
+// evaluate the policy.
+func (p *MonitorSettingsIncidentPolicy) GetHistorySize() uint {
+	return max(p.WindowSize, p.FlapWindow)
+}
+
+// StateChangePercent returns how many of the consecutive checks within the
+// flap window changed state, as a percentage of the possible changes.
+func (p *MonitorSettingsIncidentPolicy) StateChangePercent(recentResults []bool) uint {
+	if uint(len(recentResults)) > p.FlapWindow {
+		recentResults = recentResults[:p.FlapWindow]
+	}
+
+	if len(recentResults) < 2 {
+		return 0
+	}
+
+	var changes int
+	for i := 1; i < len(recentResults); i++ {
+		if recentResults[i] != recentResults[i-1] {
+			changes++
+		}
+	}
+
+	return uint(changes * 100 / (len(recentResults) - 1))
+}
+
+// IsFlapping reports whether the monitor is flapping given the most recent
+// check results, newest first, and whether it was flapping before. A monitor
+// is only considered once the flap window is full.
+func (p *MonitorSettingsIncidentPolicy) IsFlapping(recentResults []bool, wasFlapping bool) bool {
+	if p.FlapWindow == 0 {
+		return false
+	}
+
+	if uint(len(recentResults)) < p.FlapWindow {
+		return wasFlapping
+	}
+
+	percent := p.StateChangePercent(recentResults)
+
+	if wasFlapping {
+		return percent >= p.GetFlapThreshold()/2
+	}
+
+	return percent >= p.GetFlapThreshold()
+}
+
+// IsFailing reports whether an incident should be opened given the number of
+// consecutive failures and the most recent check results, newest first.
+func (p *MonitorSettingsIncidentPolicy) IsFailing(consecutiveFailures uint, recentResults []bool) bool {
+	if p.WindowSize == 0 {
+		return consecutiveFailures >= p.GetFailureThreshold()
+	}
+
+	var failures uint
+	for i, passed := range recentResults {
+		if uint(i) >= p.WindowSize {
+			break
+		}
+
+		if !passed {
+			failures++
+		}
+	}
+
+	return failures >= p.GetFailureThreshold()
+}
+
+// IsRecovered reports whether open incidents should be resolved given the
+// number of consecutive passed checks.
+func (p *MonitorSettingsIncidentPolicy) IsRecovered(consecutiveSuccesses uint) bool {
+	return consecutiveSuccesses >= p.GetRecoveryThreshold()
+}
+
+// IsQuorumReached reports whether enough locations are failing to open an
+// incident. The quorum is capped at the number of locations the monitor runs in.
+func (p *MonitorSettingsIncidentPolicy) IsQuorumReached(failingLocations int, totalLocations int) bool {
+	quorum := int(p.LocationQuorum)
+	if quorum == 0 {
+		quorum = 1
+	}
+
+	if totalLocations > 0 && quorum > totalLocations {
+		quorum = totalLocations
+	}
+
+	return failingLocations >= quorum
+}
+
+// GetLocations returns the locations the monitor runs in, defaulting to the
+// global location.
+func (s *MonitorSettings) GetLocations() []string {
+	if len(s.Locations) == 0 {
+		return []string{"global"}
+	}
+
+	return s.Locations
+}
+
 func (MonitorSettings) TableName() string {
 	return "monitor_settings"
 }
@@ -136,6 +382,28 @@ func (ms *MonitorSettings) SetFrequencySeconds(seconds uint64) {
 	ms.Frequency = time.Duration(seconds) * time.Second
 }
 
+func (ms *MonitorSettings) GetTimeoutSeconds() uint64 {
+	return uint64(ms.Timeout / time.Second)
+}
+
+func (ms *MonitorSettings) SetTimeoutSeconds(seconds uint64) {
+	ms.Timeout = time.Duration(seconds) * time.Second
+}
+
+// GetTimeout returns the probe timeout, falling back to the default
+// for the monitor method if no timeout has been set.
+func (ms *MonitorSettings) GetTimeout() time.Duration {
+	if ms.Timeout > 0 {
+		return ms.Timeout
+	}
+
+	if ms.Method == "BROWSER" {
+		return DefaultBrowserMonitorTimeout
+	}
+
+	return DefaultMonitorTimeout
+}
+
 type MonitorAssertion struct {
 	ID        uint
 	MonitorID uint `gorm:"index;not null"`
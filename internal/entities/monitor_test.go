@@ -0,0 +1,278 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+func Test_MonitorSettingsIncidentPolicy_IsFailing(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		consecutiveFailures uint
+		recentResults       []bool
+	}
+	tests := []struct {
+		name   string
+		policy MonitorSettingsIncidentPolicy
+		args   args
+		want   bool
+	}{
+		{
+			name:   "Default threshold not reached",
+			policy: MonitorSettingsIncidentPolicy{},
+			args: args{
+				consecutiveFailures: 2,
+			},
+			want: false,
+		},
+		{
+			name:   "Default threshold reached",
+			policy: MonitorSettingsIncidentPolicy{},
+			args: args{
+				consecutiveFailures: 3,
+			},
+			want: true,
+		},
+		{
+			name: "Custom threshold reached",
+			policy: MonitorSettingsIncidentPolicy{
+				FailureThreshold: 1,
+			},
+			args: args{
+				consecutiveFailures: 1,
+			},
+			want: true,
+		},
+		{
+			name: "Window threshold reached with interleaved passes",
+			policy: MonitorSettingsIncidentPolicy{
+				FailureThreshold: 3,
+				WindowSize:       5,
+			},
+			args: args{
+				consecutiveFailures: 1,
+				recentResults:       []bool{false, true, false, true, false},
+			},
+			want: true,
+		},
+		{
+			name: "Window threshold not reached",
+			policy: MonitorSettingsIncidentPolicy{
+				FailureThreshold: 3,
+				WindowSize:       5,
+			},
+			args: args{
+				consecutiveFailures: 1,
+				recentResults:       []bool{false, true, true, true, false},
+			},
+			want: false,
+		},
+		{
+			name: "Window ignores results outside of window",
+			policy: MonitorSettingsIncidentPolicy{
+				FailureThreshold: 2,
+				WindowSize:       2,
+			},
+			args: args{
+				consecutiveFailures: 1,
+				recentResults:       []bool{false, true, false, false},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.IsFailing(tt.args.consecutiveFailures, tt.args.recentResults)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_MonitorSettingsIncidentPolicy_IsRecovered(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                 string
+		policy               MonitorSettingsIncidentPolicy
+		consecutiveSuccesses uint
+		want                 bool
+	}{
+		{
+			name:                 "Default recovers on first pass",
+			policy:               MonitorSettingsIncidentPolicy{},
+			consecutiveSuccesses: 1,
+			want:                 true,
+		},
+		{
+			name: "Custom threshold not reached",
+			policy: MonitorSettingsIncidentPolicy{
+				RecoveryThreshold: 3,
+			},
+			consecutiveSuccesses: 2,
+			want:                 false,
+		},
+		{
+			name: "Custom threshold reached",
+			policy: MonitorSettingsIncidentPolicy{
+				RecoveryThreshold: 3,
+			},
+			consecutiveSuccesses: 3,
+			want:                 true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.IsRecovered(tt.consecutiveSuccesses)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_MonitorSettingsIncidentPolicy_IsQuorumReached(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		policy           MonitorSettingsIncidentPolicy
+		failingLocations int
+		totalLocations   int
+		want             bool
+	}{
+		{
+			name:             "Default opens on a single location",
+			policy:           MonitorSettingsIncidentPolicy{},
+			failingLocations: 1,
+			totalLocations:   3,
+			want:             true,
+		},
+		{
+			name: "Quorum not reached",
+			policy: MonitorSettingsIncidentPolicy{
+				LocationQuorum: 2,
+			},
+			failingLocations: 1,
+			totalLocations:   3,
+			want:             false,
+		},
+		{
+			name: "Quorum reached",
+			policy: MonitorSettingsIncidentPolicy{
+				LocationQuorum: 2,
+			},
+			failingLocations: 2,
+			totalLocations:   3,
+			want:             true,
+		},
+		{
+			name: "Quorum capped at number of locations",
+			policy: MonitorSettingsIncidentPolicy{
+				LocationQuorum: 3,
+			},
+			failingLocations: 1,
+			totalLocations:   1,
+			want:             true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.IsQuorumReached(tt.failingLocations, tt.totalLocations)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_MonitorSettingsIncidentPolicy_IsFlapping(t *testing.T) {
+	t.Parallel()
+
+	// 4 state changes in 5 checks is 100%, newest first
+	oscillating := []bool{true, false, true, false, true}
+	// 2 state changes in 5 checks is 50%
+	unsettled := []bool{false, false, true, true, false}
+	// 1 state change in 5 checks is 25%
+	recovered := []bool{true, true, true, false, false}
+
+	tests := []struct {
+		name          string
+		policy        MonitorSettingsIncidentPolicy
+		recentResults []bool
+		wasFlapping   bool
+		want          bool
+	}{
+		{
+			name:          "Disabled by default",
+			policy:        MonitorSettingsIncidentPolicy{},
+			recentResults: oscillating,
+			want:          false,
+		},
+		{
+			name: "Oscillating monitor starts flapping",
+			policy: MonitorSettingsIncidentPolicy{
+				FlapWindow: 5,
+			},
+			recentResults: oscillating,
+			want:          true,
+		},
+		{
+			name: "Default threshold reached",
+			policy: MonitorSettingsIncidentPolicy{
+				FlapWindow: 5,
+			},
+			recentResults: unsettled,
+			want:          true,
+		},
+		{
+			name: "Custom threshold not reached",
+			policy: MonitorSettingsIncidentPolicy{
+				FlapWindow:    5,
+				FlapThreshold: 75,
+			},
+			recentResults: unsettled,
+			want:          false,
+		},
+		{
+			name: "Not evaluated before the window is full",
+			policy: MonitorSettingsIncidentPolicy{
+				FlapWindow: 10,
+			},
+			recentResults: oscillating,
+			wasFlapping:   false,
+			want:          false,
+		},
+		{
+			name: "Keeps flapping above half the threshold",
+			policy: MonitorSettingsIncidentPolicy{
+				FlapWindow:    5,
+				FlapThreshold: 75,
+			},
+			recentResults: unsettled,
+			wasFlapping:   true,
+			want:          true,
+		},
+		{
+			name: "Stabilizes below half the threshold",
+			policy: MonitorSettingsIncidentPolicy{
+				FlapWindow:    5,
+				FlapThreshold: 75,
+			},
+			recentResults: recovered,
+			wasFlapping:   true,
+			want:          false,
+		},
+		{
+			name: "Only the flap window is considered",
+			policy: MonitorSettingsIncidentPolicy{
+				FlapWindow: 3,
+			},
+			recentResults: recovered,
+			want:          false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.IsFlapping(tt.recentResults, tt.wasFlapping)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"time"
+)
+
+type NotificationAttemptStatus string
+
+const (
+	NotificationAttemptStatusSent   NotificationAttemptStatus = "SENT"
+	NotificationAttemptStatusFailed NotificationAttemptStatus = "FAILED"
+)
+
+type NotificationAttempt struct {
+	ID            uint
+	TeamID        uint  `gorm:"index;not null"`
+	IncidentID    *uint `gorm:"index"`
+	MaintenanceID *uint `gorm:"index"`
+	AlertRuleID   *uint `gorm:"index"`
+
+	Channel   string `gorm:"index;not null"`
+	Recipient string `gorm:"not null"`
+
+	// 1 for the first attempt, incremented for every retry
+	Attempt      int                       `gorm:"not null;default:1"`
+	Status       NotificationAttemptStatus `gorm:"index;not null"`
+	ResponseCode *int
+	Error        *string
+
+	// When the failed attempt is retried, unset once it was
+	NextAttemptAt *time.Time `gorm:"index"`
+	// What the channel needs to send the notification again, such as the
+	// escalation tier that was paged
+	RetryContext map[string]string `gorm:"serializer:json"`
+
+	CreatedAt time.Time `gorm:"index"`
+}
+
+func (NotificationAttempt) TableName() string {
+	return "notification_attempts"
+}
@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type PostmortemTimelineEntryKind string
+
+const (
+	PostmortemTimelineEntryKindOpened       PostmortemTimelineEntryKind = "OPENED"
+	PostmortemTimelineEntryKindFailedCheck  PostmortemTimelineEntryKind = "FAILED_CHECK"
+	PostmortemTimelineEntryKindAcknowledged PostmortemTimelineEntryKind = "ACKNOWLEDGED"
+	PostmortemTimelineEntryKindUpdate       PostmortemTimelineEntryKind = "UPDATE"
+	PostmortemTimelineEntryKindComment      PostmortemTimelineEntryKind = "COMMENT"
+	PostmortemTimelineEntryKindResolved     PostmortemTimelineEntryKind = "RESOLVED"
+	PostmortemTimelineEntryKindNote         PostmortemTimelineEntryKind = "NOTE"
+)
+
+type PostmortemTimelineEntry struct {
+	At          time.Time                   `json:"at"`
+	Kind        PostmortemTimelineEntryKind `json:"kind"`
+	Description string                      `json:"description"`
+	// Left out when the postmortem is published
+	Internal bool `json:"internal,omitempty"`
+}
+
+// IsInternal reports whether the entry is only shown to the team, comments
+// always are.
+func (e PostmortemTimelineEntry) IsInternal() bool {
+	return e.Internal || e.Kind == PostmortemTimelineEntryKindComment
+}
+
+// Postmortem documents a resolved incident, it can be published on the
+// status pages of the affected monitor.
+type Postmortem struct {
+	ID         uint `gorm:"primaryKey"`
+	TeamID     uint `gorm:"index;not null"`
+	IncidentID uint `gorm:"uniqueIndex;not null"`
+
+	Title               string `gorm:"not null"`
+	Summary             *string
+	RootCause           *string
+	ContributingFactors pq.StringArray `gorm:"type:text[]"`
+	ImpactStartedAt     time.Time      `gorm:"not null"`
+	ImpactEndedAt       *time.Time
+	// Prefilled from the incident when the postmortem is created, editable afterwards
+	Timeline    []PostmortemTimelineEntry `gorm:"serializer:json"`
+	ActionItems []PostmortemActionItem    `gorm:"constraint:OnDelete:CASCADE"`
+
+	Published   bool `gorm:"index;not null;default:false"`
+	PublishedAt *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Postmortem) TableName() string {
+	return "postmortems"
+}
+
+type PostmortemActionItem struct {
+	ID           uint   `gorm:"primaryKey"`
+	PostmortemID uint   `gorm:"index;not null"`
+	Description  string `gorm:"not null"`
+	OwnerUserID  *uint  `gorm:"index"`
+	DueAt        *time.Time
+	Completed    bool `gorm:"not null;default:false"`
+
+	Owner *User `gorm:"foreignKey:OwnerUserID;constraint:OnDelete:SET NULL"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (PostmortemActionItem) TableName() string {
+	return "postmortem_action_items"
+}
@@ -11,11 +11,12 @@ import (
 type ReportType string
 
 const (
-	ReportTypeUptime      ReportType = "UPTIME"
-	ReportTypePerformance ReportType = "PERFORMANCE"
-	ReportTypeIncident    ReportType = "INCIDENT"
-	ReportTypeAll         ReportType = "ALL"
-	ReportTypeCustom      ReportType = "CUSTOM"
+	ReportTypeUptime            ReportType = "UPTIME"
+	ReportTypePerformance       ReportType = "PERFORMANCE"
+	ReportTypeIncident          ReportType = "INCIDENT"
+	ReportTypeIncidentAnalytics ReportType = "INCIDENT_ANALYTICS"
+	ReportTypeAll               ReportType = "ALL"
+	ReportTypeCustom            ReportType = "CUSTOM"
 )
 
 type ReportStatus string
@@ -46,12 +47,52 @@ type MonitorIncident struct {
 	Count     int  `json:"count"`
 }
 
+// IncidentAnalytics summarizes the incidents of a team, or of one of its
+// monitors, opened in a time range. Merged incidents are left out as they
+// are duplicates.
+type IncidentAnalytics struct {
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	MonitorID    *uint     `json:"monitorId"`
+	Count        int       `json:"count"`
+	Acknowledged int       `json:"acknowledged"`
+	Resolved     int       `json:"resolved"`
+	// Mean time to acknowledge and to resolve in seconds, nil without any
+	// acknowledged or resolved incidents
+	MTTA *float64 `json:"mtta"`
+	MTTR *float64 `json:"mttr"`
+
+	ByKind     []IncidentCount `json:"byKind"`
+	BySeverity []IncidentCount `json:"bySeverity"`
+	// Hours of the day in UTC with incidents, busiest first
+	BusiestHours []IncidentHourCount `json:"busiestHours"`
+	// Monitors with the most incidents, noisiest first
+	NoisyMonitors []NoisyMonitor `json:"noisyMonitors"`
+}
+
+type IncidentCount struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
+
+type IncidentHourCount struct {
+	Hour  int `json:"hour"`
+	Count int `json:"count"`
+}
+
+type NoisyMonitor struct {
+	MonitorID uint     `json:"monitorId"`
+	Count     int      `json:"count"`
+	MTTR      *float64 `json:"mttr"`
+}
+
 type ReportData struct {
-	Uptime      *[]check.MonitorUptime       `json:"uptime"`
-	Performance *[]check.MonitorPerformance  `json:"performance"`
-	Incident    *[]MonitorIncident           `json:"incident"`
-	All         *string                      `json:"all"`
-	Custom      *string                      `json:"custom"`
+	Uptime            *[]check.MonitorUptime      `json:"uptime"`
+	Performance       *[]check.MonitorPerformance `json:"performance"`
+	Incident          *[]MonitorIncident          `json:"incident"`
+	IncidentAnalytics *IncidentAnalytics          `json:"incidentAnalytics"`
+	All               *string                     `json:"all"`
+	Custom            *string                     `json:"custom"`
 }
 
 func ReportFrom(source any) (ReportType, error) {
@@ -67,6 +108,8 @@ func ReportFrom(source any) (ReportType, error) {
 		return ReportTypePerformance, nil
 	case "INCIDENT":
 		return ReportTypeIncident, nil
+	case "INCIDENT_ANALYTICS":
+		return ReportTypeIncidentAnalytics, nil
 	case "ALL":
 		return ReportTypeAll, nil
 	case "CUSTOM":
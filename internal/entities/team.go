@@ -1,6 +1,9 @@
 package entities
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"errors"
 	"regexp"
 	"time"
@@ -43,10 +46,25 @@ type Team struct {
 	PaymentPlan      PaymentPlan `gorm:"default:FREE;not null"`
 	StripeCustomerID *string     `gorm:"index"`
 	SlackWebhookURL    *string     `gorm:"type:text"`
+	// ID of the Slack workspace the alerts are posted to, only clicks on their
+	// buttons from it can act on the incidents of the team
+	SlackWorkspaceID *string `gorm:"type:text"`
 	DiscordWebhookURL  *string     `gorm:"type:text"`
 	TelegramChatID     *string     `gorm:"type:text"`
 	DatadogWebhookURL  *string     `gorm:"type:text"`
 	NewRelicWebhookURL *string     `gorm:"type:text"`
+	MSTeamsWebhookURL    *string `gorm:"type:text"`
+	MattermostWebhookURL *string `gorm:"type:text"`
+	GoogleChatWebhookURL *string `gorm:"type:text"`
+	PagerDutyRoutingKey    *string `gorm:"type:text"`
+	PagerDutyWebhookSecret *string `gorm:"type:text"`
+	OpsgenieAPIKey         *string `gorm:"type:text"`
+	OpsgenieWebhookToken   *string `gorm:"type:text"`
+	OpsgenieEURegion       bool    `gorm:"not null;default:false"`
+	IngestionTokenHash     *string `gorm:"type:text"`
+	IncidentGrouping       IncidentGroupingStrategy `gorm:"not null;default:NONE"`
+	// Seconds after the parent incident opened in which new incidents are grouped
+	IncidentGroupingWindow int `gorm:"not null;default:300"`
 	HasAvatar          bool
 
 	Users       []User        `gorm:"many2many:team_users;constraint:OnDelete:CASCADE;"`
@@ -103,6 +121,32 @@ func (t *Team) GetStatusPageLimit() int {
 	}
 }
 
+// SetIngestionToken stores the hash of the token inbound alert integrations,
+// such as Datadog and New Relic, must present.
+func (t *Team) SetIngestionToken(token string) {
+	hash := hashIngestionToken(token)
+	t.IngestionTokenHash = &hash
+}
+
+func (t *Team) HasIngestionToken() bool {
+	return t.IngestionTokenHash != nil && *t.IngestionTokenHash != ""
+}
+
+func (t *Team) VerifyIngestionToken(token string) bool {
+	if !t.HasIngestionToken() || token == "" {
+		return false
+	}
+
+	hash := hashIngestionToken(token)
+
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(*t.IngestionTokenHash)) == 1
+}
+
+func hashIngestionToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
 func (t *Team) BeforeCreate(tx *gorm.DB) (err error) {
 	if ok := checkTeamNameFormat(t.Name); !ok {
 		return ErrIllegalTeamNameFormat
@@ -168,6 +212,9 @@ type TeamUser struct {
 
 	Role TeamRole `gorm:"index"`
 
+	// ID of the user in the Slack workspace of the team
+	SlackUserID *string `gorm:"index"`
+
 	UpdatedAt time.Time
 	CreatedAt time.Time
 }
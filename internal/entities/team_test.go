@@ -115,3 +115,48 @@ func Test_checkTeamNameFormatInvalid(t *testing.T) {
 		})
 	}
 }
+
+func TestTeam_VerifyIngestionToken(t *testing.T) {
+	t.Parallel()
+
+	team := Team{}
+	team.SetIngestionToken("secret-token")
+
+	tests := []struct {
+		name  string
+		team  Team
+		token string
+		want  bool
+	}{
+		{
+			name:  "Matching token",
+			team:  team,
+			token: "secret-token",
+			want:  true,
+		},
+		{
+			name:  "Wrong token",
+			team:  team,
+			token: "other-token",
+			want:  false,
+		},
+		{
+			name:  "Empty token",
+			team:  team,
+			token: "",
+			want:  false,
+		},
+		{
+			name:  "No token configured",
+			team:  Team{},
+			token: "secret-token",
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.team.VerifyIngestionToken(tt.token)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// WebhookEndpoint is a team defined URL that receives signed JSON payloads
+// for incident and maintenance events.
+type WebhookEndpoint struct {
+	ID     uint   `gorm:"primaryKey"`
+	TeamID uint   `gorm:"index;not null"`
+	Name   string `gorm:"not null"`
+	URL    string `gorm:"not null"`
+	Secret string `gorm:"not null"`
+	// Events the endpoint is subscribed to, all events if empty
+	Events pq.StringArray `gorm:"type:text[]"`
+	// Go template rendered with the payload, the payload is sent as JSON if nil
+	BodyTemplate *string
+	Enabled      bool `gorm:"not null;default:true"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (WebhookEndpoint) TableName() string {
+	return "webhook_endpoints"
+}
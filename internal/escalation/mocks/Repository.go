@@ -8,6 +8,8 @@ import (
 	entities "github.com/opsway-io/backend/internal/entities"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -15,6 +17,54 @@ type Repository struct {
 	mock.Mock
 }
 
+// CancelStepsByIncidentID provides a mock function with given fields: ctx, incidentID
+func (_m *Repository) CancelStepsByIncidentID(ctx context.Context, incidentID uint) error {
+	ret := _m.Called(ctx, incidentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelStepsByIncidentID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, incidentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClaimDueSteps provides a mock function with given fields: ctx, now, lease, limit
+func (_m *Repository) ClaimDueSteps(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entities.EscalationStep, error) {
+	ret := _m.Called(ctx, now, lease, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDueSteps")
+	}
+
+	var r0 []entities.EscalationStep
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) ([]entities.EscalationStep, error)); ok {
+		return rf(ctx, now, lease, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) []entities.EscalationStep); ok {
+		r0 = rf(ctx, now, lease, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.EscalationStep)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Duration, int) error); ok {
+		r1 = rf(ctx, now, lease, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreatePolicy provides a mock function with given fields: ctx, policy
 func (_m *Repository) CreatePolicy(ctx context.Context, policy *entities.EscalationPolicy) error {
 	ret := _m.Called(ctx, policy)
@@ -33,6 +83,24 @@ func (_m *Repository) CreatePolicy(ctx context.Context, policy *entities.Escalat
 	return r0
 }
 
+// CreateStep provides a mock function with given fields: ctx, step
+func (_m *Repository) CreateStep(ctx context.Context, step *entities.EscalationStep) error {
+	ret := _m.Called(ctx, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStep")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.EscalationStep) error); ok {
+		r0 = rf(ctx, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetPolicyByTeamID provides a mock function with given fields: ctx, teamID
 func (_m *Repository) GetPolicyByTeamID(ctx context.Context, teamID uint) (*entities.EscalationPolicy, error) {
 	ret := _m.Called(ctx, teamID)
@@ -159,6 +227,24 @@ func (_m *Repository) UpdatePolicy(ctx context.Context, policy *entities.Escalat
 	return r0
 }
 
+// UpdateStepStatus provides a mock function with given fields: ctx, stepID, status
+func (_m *Repository) UpdateStepStatus(ctx context.Context, stepID uint, status entities.EscalationStepStatus) error {
+	ret := _m.Called(ctx, stepID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStepStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, entities.EscalationStepStatus) error); ok {
+		r0 = rf(ctx, stepID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepository(t interface {
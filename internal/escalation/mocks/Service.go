@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	entities "github.com/opsway-io/backend/internal/entities"
+	escalation "github.com/opsway-io/backend/internal/escalation"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -15,6 +16,24 @@ type Service struct {
 	mock.Mock
 }
 
+// CancelEscalation provides a mock function with given fields: ctx, incidentID
+func (_m *Service) CancelEscalation(ctx context.Context, incidentID uint) error {
+	ret := _m.Called(ctx, incidentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelEscalation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, incidentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreatePolicy provides a mock function with given fields: ctx, policy
 func (_m *Service) CreatePolicy(ctx context.Context, policy *entities.EscalationPolicy) error {
 	ret := _m.Called(ctx, policy)
@@ -153,6 +172,52 @@ func (_m *Service) GetRotationsByPolicyIDAndTier(ctx context.Context, policyID u
 	return r0, r1
 }
 
+// ProcessDueEscalations provides a mock function with given fields: ctx, handler
+func (_m *Service) ProcessDueEscalations(ctx context.Context, handler escalation.StepHandler) (int, error) {
+	ret := _m.Called(ctx, handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessDueEscalations")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, escalation.StepHandler) (int, error)); ok {
+		return rf(ctx, handler)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, escalation.StepHandler) int); ok {
+		r0 = rf(ctx, handler)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, escalation.StepHandler) error); ok {
+		r1 = rf(ctx, handler)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleEscalation provides a mock function with given fields: ctx, incident, alertRuleID
+func (_m *Service) ScheduleEscalation(ctx context.Context, incident *entities.Incident, alertRuleID uint) error {
+	ret := _m.Called(ctx, incident, alertRuleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleEscalation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Incident, uint) error); ok {
+		r0 = rf(ctx, incident, alertRuleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetRotations provides a mock function with given fields: ctx, policyID, rotations
 func (_m *Service) SetRotations(ctx context.Context, policyID uint, rotations []entities.OnCallRotation) error {
 	ret := _m.Called(ctx, policyID, rotations)
@@ -2,9 +2,11 @@ package escalation
 
 import (
 	"context"
+	"time"
 
 	"github.com/opsway-io/backend/internal/entities"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
@@ -15,6 +17,11 @@ type Repository interface {
 	GetRotationsByPolicyID(ctx context.Context, policyID uint) ([]entities.OnCallRotation, error)
 	GetRotationsByPolicyIDAndTier(ctx context.Context, policyID uint, tier int) ([]entities.OnCallRotation, error)
 	SetRotations(ctx context.Context, policyID uint, rotations []entities.OnCallRotation) error
+
+	CreateStep(ctx context.Context, step *entities.EscalationStep) error
+	ClaimDueSteps(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entities.EscalationStep, error)
+	UpdateStepStatus(ctx context.Context, stepID uint, status entities.EscalationStepStatus) error
+	CancelStepsByIncidentID(ctx context.Context, incidentID uint) error
 }
 
 type RepositoryImpl struct {
@@ -64,3 +71,50 @@ func (r *RepositoryImpl) SetRotations(ctx context.Context, policyID uint, rotati
 		return nil
 	})
 }
+
+func (r *RepositoryImpl) CreateStep(ctx context.Context, step *entities.EscalationStep) error {
+	return r.db.WithContext(ctx).Create(step).Error
+}
+
+// ClaimDueSteps locks pending steps that are due for the duration of the lease.
+// Rows locked by another alerter are skipped, so every step is only claimed once.
+func (r *RepositoryImpl) ClaimDueSteps(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entities.EscalationStep, error) {
+	var steps []entities.EscalationStep
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(
+			clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"},
+		).Where(
+			"status = ? AND due_at <= ? AND (locked_until IS NULL OR locked_until <= ?)",
+			entities.EscalationStepStatusPending, now, now,
+		).Order("due_at asc").Limit(limit).Find(&steps).Error; err != nil {
+			return err
+		}
+
+		if len(steps) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(steps))
+		for i := range steps {
+			ids[i] = steps[i].ID
+		}
+
+		return tx.Model(&entities.EscalationStep{}).Where("id IN ?", ids).Update("locked_until", now.Add(lease)).Error
+	})
+
+	return steps, err
+}
+
+func (r *RepositoryImpl) UpdateStepStatus(ctx context.Context, stepID uint, status entities.EscalationStepStatus) error {
+	return r.db.WithContext(ctx).Model(&entities.EscalationStep{}).Where("id = ?", stepID).Updates(map[string]any{
+		"status":       status,
+		"locked_until": nil,
+	}).Error
+}
+
+func (r *RepositoryImpl) CancelStepsByIncidentID(ctx context.Context, incidentID uint) error {
+	return r.db.WithContext(ctx).Model(&entities.EscalationStep{}).Where(
+		"incident_id = ? AND status = ?", incidentID, entities.EscalationStepStatusPending,
+	).Update("status", entities.EscalationStepStatusCanceled).Error
+}
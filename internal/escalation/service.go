@@ -3,6 +3,7 @@ package escalation
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/opsway-io/backend/internal/entities"
 	"gorm.io/gorm"
@@ -19,14 +20,41 @@ type Service interface {
 	
 	// Helper to get tier 1 and tier 2 users quickly for a team
 	GetOnCallUsersByTeamID(ctx context.Context, teamID uint, tier int) ([]uint, error)
+
+	ScheduleEscalation(ctx context.Context, incident *entities.Incident, alertRuleID uint) error
+	CancelEscalation(ctx context.Context, incidentID uint) error
+	ProcessDueEscalations(ctx context.Context, handler StepHandler) (int, error)
 }
 
+// StepHandler notifies the tier of a due escalation step. Returning
+// ErrStopEscalation cancels the escalation, any other error retries the step
+// once its lease expires.
+type StepHandler func(ctx context.Context, step *entities.EscalationStep) error
+
+var ErrStopEscalation = errors.New("escalation stopped")
+
+const (
+	// How long a claimed step is locked before another alerter may retry it
+	stepLease = 5 * time.Minute
+
+	// Maximum number of steps claimed per call to ProcessDueEscalations
+	stepBatchSize = 50
+)
+
 type ServiceImpl struct {
 	repo Repository
+	now  func() time.Time
 }
 
 func NewService(repo Repository) Service {
-	return &ServiceImpl{repo: repo}
+	return NewServiceWithClock(repo, time.Now)
+}
+
+func NewServiceWithClock(repo Repository, now func() time.Time) Service {
+	return &ServiceImpl{
+		repo: repo,
+		now:  now,
+	}
 }
 
 func (s *ServiceImpl) GetPolicyByTeamID(ctx context.Context, teamID uint) (*entities.EscalationPolicy, error) {
@@ -74,3 +102,130 @@ func (s *ServiceImpl) GetOnCallUsersByTeamID(ctx context.Context, teamID uint, t
 	
 	return userIDs, nil
 }
+
+// ScheduleEscalation stores the first escalation step for an incident that was
+// just sent to Tier 1. Nothing is scheduled if the team has no policy or the
+// policy has nothing to escalate to.
+func (s *ServiceImpl) ScheduleEscalation(ctx context.Context, incident *entities.Incident, alertRuleID uint) error {
+	policy, maxTier, err := s.getPolicyAndMaxTier(ctx, incident.TeamID)
+	if err != nil || policy == nil {
+		return err
+	}
+
+	step := nextStep(policy, maxTier, &entities.EscalationStep{
+		TeamID:      incident.TeamID,
+		IncidentID:  incident.ID,
+		AlertRuleID: alertRuleID,
+		Tier:        1,
+	}, s.now())
+	if step == nil {
+		return nil
+	}
+
+	return s.repo.CreateStep(ctx, step)
+}
+
+func (s *ServiceImpl) CancelEscalation(ctx context.Context, incidentID uint) error {
+	return s.repo.CancelStepsByIncidentID(ctx, incidentID)
+}
+
+// ProcessDueEscalations claims due steps, passes them to the handler and
+// schedules the step that follows. It returns the number of steps processed.
+func (s *ServiceImpl) ProcessDueEscalations(ctx context.Context, handler StepHandler) (int, error) {
+	steps, err := s.repo.ClaimDueSteps(ctx, s.now(), stepLease, stepBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+
+	for i := range steps {
+		step := &steps[i]
+
+		if err := handler(ctx, step); err != nil {
+			if errors.Is(err, ErrStopEscalation) {
+				if err := s.repo.UpdateStepStatus(ctx, step.ID, entities.EscalationStepStatusCanceled); err != nil {
+					return processed, err
+				}
+
+				processed++
+			}
+
+			continue
+		}
+
+		if err := s.repo.UpdateStepStatus(ctx, step.ID, entities.EscalationStepStatusCompleted); err != nil {
+			return processed, err
+		}
+
+		processed++
+
+		policy, maxTier, err := s.getPolicyAndMaxTier(ctx, step.TeamID)
+		if err != nil {
+			return processed, err
+		}
+
+		if policy == nil {
+			continue
+		}
+
+		if next := nextStep(policy, maxTier, step, s.now()); next != nil {
+			if err := s.repo.CreateStep(ctx, next); err != nil {
+				return processed, err
+			}
+		}
+	}
+
+	return processed, nil
+}
+
+func (s *ServiceImpl) getPolicyAndMaxTier(ctx context.Context, teamID uint) (*entities.EscalationPolicy, int, error) {
+	policy, err := s.repo.GetPolicyByTeamID(ctx, teamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, nil
+		}
+
+		return nil, 0, err
+	}
+
+	rotations, err := s.repo.GetRotationsByPolicyID(ctx, policy.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	maxTier := 1
+	for _, r := range rotations {
+		if r.Tier > maxTier {
+			maxTier = r.Tier
+		}
+	}
+
+	return policy, maxTier, nil
+}
+
+// nextStep returns the step following the given one, or nil if the escalation
+// is finished.
+func nextStep(policy *entities.EscalationPolicy, maxTier int, step *entities.EscalationStep, now time.Time) *entities.EscalationStep {
+	tier := step.Tier + 1
+	repeat := step.Repeat
+
+	if tier > maxTier {
+		if !policy.RepeatUntilAcknowledged || (policy.MaxRepeats > 0 && repeat >= policy.MaxRepeats) {
+			return nil
+		}
+
+		tier = 1
+		repeat++
+	}
+
+	return &entities.EscalationStep{
+		TeamID:      step.TeamID,
+		IncidentID:  step.IncidentID,
+		AlertRuleID: step.AlertRuleID,
+		Tier:        tier,
+		Repeat:      repeat,
+		Status:      entities.EscalationStepStatusPending,
+		DueAt:       now.Add(time.Duration(policy.EscalationTimeoutMinutes) * time.Minute),
+	}
+}
@@ -2,12 +2,15 @@ package escalation_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/opsway-io/backend/internal/entities"
 	"github.com/opsway-io/backend/internal/escalation"
 	"github.com/opsway-io/backend/internal/escalation/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestService_GetOnCallUsersByTeamID(t *testing.T) {
@@ -41,3 +44,146 @@ func TestService_GetOnCallUsersByTeamID(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 }
+
+func TestService_ScheduleEscalation(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	policy := &entities.EscalationPolicy{
+		ID:                       10,
+		TeamID:                   1,
+		EscalationTimeoutMinutes: 5,
+	}
+
+	t.Run("schedules the second tier", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		svc := escalation.NewServiceWithClock(repo, clock)
+
+		repo.On("GetPolicyByTeamID", ctx, uint(1)).Return(policy, nil)
+		repo.On("GetRotationsByPolicyID", ctx, uint(10)).Return([]entities.OnCallRotation{{Tier: 1}, {Tier: 2}}, nil)
+		repo.On("CreateStep", ctx, &entities.EscalationStep{
+			TeamID:      1,
+			IncidentID:  100,
+			AlertRuleID: 20,
+			Tier:        2,
+			Status:      entities.EscalationStepStatusPending,
+			DueAt:       now.Add(5 * time.Minute),
+		}).Return(nil)
+
+		err := svc.ScheduleEscalation(ctx, &entities.Incident{ID: 100, TeamID: 1}, 20)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("does nothing without higher tiers", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		svc := escalation.NewServiceWithClock(repo, clock)
+
+		repo.On("GetPolicyByTeamID", ctx, uint(1)).Return(policy, nil)
+		repo.On("GetRotationsByPolicyID", ctx, uint(10)).Return([]entities.OnCallRotation{{Tier: 1}}, nil)
+
+		err := svc.ScheduleEscalation(ctx, &entities.Incident{ID: 100, TeamID: 1}, 20)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestService_ProcessDueEscalations(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	policy := &entities.EscalationPolicy{
+		ID:                       10,
+		TeamID:                   1,
+		EscalationTimeoutMinutes: 5,
+		RepeatUntilAcknowledged:  true,
+		MaxRepeats:               2,
+	}
+	rotations := []entities.OnCallRotation{{Tier: 1}, {Tier: 2}}
+
+	t.Run("notifies the tier and schedules the next one", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		svc := escalation.NewServiceWithClock(repo, clock)
+
+		step := entities.EscalationStep{ID: 1, TeamID: 1, IncidentID: 100, AlertRuleID: 20, Tier: 2}
+
+		repo.On("ClaimDueSteps", ctx, now, mock.Anything, mock.Anything).Return([]entities.EscalationStep{step}, nil)
+		repo.On("UpdateStepStatus", ctx, uint(1), entities.EscalationStepStatusCompleted).Return(nil)
+		repo.On("GetPolicyByTeamID", ctx, uint(1)).Return(policy, nil)
+		repo.On("GetRotationsByPolicyID", ctx, uint(10)).Return(rotations, nil)
+		repo.On("CreateStep", ctx, &entities.EscalationStep{
+			TeamID:      1,
+			IncidentID:  100,
+			AlertRuleID: 20,
+			Tier:        1,
+			Repeat:      1,
+			Status:      entities.EscalationStepStatusPending,
+			DueAt:       now.Add(5 * time.Minute),
+		}).Return(nil)
+
+		var notified []int
+		processed, err := svc.ProcessDueEscalations(ctx, func(ctx context.Context, s *entities.EscalationStep) error {
+			notified = append(notified, s.Tier)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, processed)
+		assert.Equal(t, []int{2}, notified)
+	})
+
+	t.Run("stops after the maximum number of repeats", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		svc := escalation.NewServiceWithClock(repo, clock)
+
+		step := entities.EscalationStep{ID: 1, TeamID: 1, IncidentID: 100, AlertRuleID: 20, Tier: 2, Repeat: 2}
+
+		repo.On("ClaimDueSteps", ctx, now, mock.Anything, mock.Anything).Return([]entities.EscalationStep{step}, nil)
+		repo.On("UpdateStepStatus", ctx, uint(1), entities.EscalationStepStatusCompleted).Return(nil)
+		repo.On("GetPolicyByTeamID", ctx, uint(1)).Return(policy, nil)
+		repo.On("GetRotationsByPolicyID", ctx, uint(10)).Return(rotations, nil)
+
+		processed, err := svc.ProcessDueEscalations(ctx, func(ctx context.Context, s *entities.EscalationStep) error {
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, processed)
+		repo.AssertNotCalled(t, "CreateStep", mock.Anything, mock.Anything)
+	})
+
+	t.Run("cancels when the incident was acknowledged", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		svc := escalation.NewServiceWithClock(repo, clock)
+
+		step := entities.EscalationStep{ID: 1, TeamID: 1, IncidentID: 100, AlertRuleID: 20, Tier: 2}
+
+		repo.On("ClaimDueSteps", ctx, now, mock.Anything, mock.Anything).Return([]entities.EscalationStep{step}, nil)
+		repo.On("UpdateStepStatus", ctx, uint(1), entities.EscalationStepStatusCanceled).Return(nil)
+
+		processed, err := svc.ProcessDueEscalations(ctx, func(ctx context.Context, s *entities.EscalationStep) error {
+			return escalation.ErrStopEscalation
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, processed)
+	})
+
+	t.Run("leaves failed steps for a retry", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		svc := escalation.NewServiceWithClock(repo, clock)
+
+		step := entities.EscalationStep{ID: 1, TeamID: 1, IncidentID: 100, AlertRuleID: 20, Tier: 2}
+
+		repo.On("ClaimDueSteps", ctx, now, mock.Anything, mock.Anything).Return([]entities.EscalationStep{step}, nil)
+
+		processed, err := svc.ProcessDueEscalations(ctx, func(ctx context.Context, s *entities.EscalationStep) error {
+			return errors.New("smtp unavailable")
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, processed)
+	})
+}
@@ -0,0 +1,17 @@
+package events
+
+import (
+	"github.com/opsway-io/backend/internal/entities"
+)
+
+const (
+	EventTypeIncidentAcknowledged EventType = "incident:acknowledged"
+)
+
+type IncidentAcknowledgedEvent struct {
+	Incident *entities.Incident `json:"incident"`
+}
+
+func (e IncidentAcknowledgedEvent) Name() string {
+	return string(EventTypeIncidentAcknowledged)
+}
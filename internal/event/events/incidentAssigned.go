@@ -0,0 +1,19 @@
+package events
+
+import (
+	"github.com/opsway-io/backend/internal/entities"
+)
+
+const (
+	EventTypeIncidentAssigned EventType = "incident:assigned"
+)
+
+type IncidentAssignedEvent struct {
+	Incident   *entities.Incident `json:"incident"`
+	AssigneeID uint               `json:"assigneeId"`
+	AssignedBy uint               `json:"assignedBy"`
+}
+
+func (e IncidentAssignedEvent) Name() string {
+	return string(EventTypeIncidentAssigned)
+}
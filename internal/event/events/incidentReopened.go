@@ -0,0 +1,17 @@
+package events
+
+import (
+	"github.com/opsway-io/backend/internal/entities"
+)
+
+const (
+	EventTypeIncidentReopened EventType = "incident:reopened"
+)
+
+type IncidentReopenedEvent struct {
+	Incident *entities.Incident `json:"incident"`
+}
+
+func (e IncidentReopenedEvent) Name() string {
+	return string(EventTypeIncidentReopened)
+}
@@ -0,0 +1,19 @@
+package events
+
+import (
+	"github.com/opsway-io/backend/internal/entities"
+)
+
+const (
+	EventTypeIncidentUnsuppressed EventType = "incident:unsuppressed"
+)
+
+// IncidentUnsuppressedEvent is published when the reason an open incident was
+// not paged for no longer applies
+type IncidentUnsuppressedEvent struct {
+	Incident *entities.Incident `json:"incident"`
+}
+
+func (e IncidentUnsuppressedEvent) Name() string {
+	return string(EventTypeIncidentUnsuppressed)
+}
@@ -68,15 +68,20 @@ func (w *worker) processExpiredHeartbeats(ctx context.Context) {
 
 		desc := "Heartbeat missed its check-in window"
 		hbId := hb.ID
+		fingerprint := entities.HeartbeatIncidentFingerprint(hb.ID)
 
-		incident := entities.Incident{
+		incident := &entities.Incident{
 			TeamID:      hb.TeamID,
 			HeartbeatID: &hbId,
 			Title:       "Heartbeat Down",
 			Description: &desc,
+			Source:      entities.IncidentSourceHeartbeat,
+			Kind:        entities.IncidentKindHeartbeat,
+			Fingerprint: &fingerprint,
+			Severity:    entities.IncidentSeverityMajor,
 		}
 
-		if err := w.incidentService.Create(ctx, &[]entities.Incident{incident}); err != nil {
+		if _, _, err := w.incidentService.Open(ctx, incident); err != nil {
 			l.WithError(err).Error("failed to create incident for expired heartbeat")
 		}
 	}
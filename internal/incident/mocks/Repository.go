@@ -9,6 +9,8 @@ import (
 	incident "github.com/opsway-io/backend/internal/incident"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -16,6 +18,24 @@ type Repository struct {
 	mock.Mock
 }
 
+// AddResponder provides a mock function with given fields: ctx, responder
+func (_m *Repository) AddResponder(ctx context.Context, responder *entities.IncidentResponder) error {
+	ret := _m.Called(ctx, responder)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddResponder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.IncidentResponder) error); ok {
+		r0 = rf(ctx, responder)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: ctx, incidents
 func (_m *Repository) Create(ctx context.Context, incidents *[]entities.Incident) error {
 	ret := _m.Called(ctx, incidents)
@@ -34,6 +54,24 @@ func (_m *Repository) Create(ctx context.Context, incidents *[]entities.Incident
 	return r0
 }
 
+// CreateUpdate provides a mock function with given fields: ctx, update
+func (_m *Repository) CreateUpdate(ctx context.Context, update *entities.IncidentUpdate) error {
+	ret := _m.Called(ctx, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.IncidentUpdate) error); ok {
+		r0 = rf(ctx, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: ctx, _a1
 func (_m *Repository) Delete(ctx context.Context, _a1 *entities.Incident) error {
 	ret := _m.Called(ctx, _a1)
@@ -52,6 +90,66 @@ func (_m *Repository) Delete(ctx context.Context, _a1 *entities.Incident) error
 	return r0
 }
 
+// GetActiveByMonitorIDs provides a mock function with given fields: ctx, monitorIDs
+func (_m *Repository) GetActiveByMonitorIDs(ctx context.Context, monitorIDs []uint) ([]entities.Incident, error) {
+	ret := _m.Called(ctx, monitorIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveByMonitorIDs")
+	}
+
+	var r0 []entities.Incident
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uint) ([]entities.Incident, error)); ok {
+		return rf(ctx, monitorIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uint) []entities.Incident); ok {
+		r0 = rf(ctx, monitorIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Incident)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uint) error); ok {
+		r1 = rf(ctx, monitorIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAnalyticsByTeamID provides a mock function with given fields: ctx, teamID, filter
+func (_m *Repository) GetAnalyticsByTeamID(ctx context.Context, teamID uint, filter incident.AnalyticsFilter) (*entities.IncidentAnalytics, error) {
+	ret := _m.Called(ctx, teamID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAnalyticsByTeamID")
+	}
+
+	var r0 *entities.IncidentAnalytics
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, incident.AnalyticsFilter) (*entities.IncidentAnalytics, error)); ok {
+		return rf(ctx, teamID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, incident.AnalyticsFilter) *entities.IncidentAnalytics); ok {
+		r0 = rf(ctx, teamID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.IncidentAnalytics)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, incident.AnalyticsFilter) error); ok {
+		r1 = rf(ctx, teamID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *Repository) GetByID(ctx context.Context, id uint) (*entities.Incident, error) {
 	ret := _m.Called(ctx, id)
@@ -142,9 +240,9 @@ func (_m *Repository) GetByTeamIDMonitorsIncidentStats(ctx context.Context, team
 	return r0, r1
 }
 
-// GetByTeamIDPaginated provides a mock function with given fields: ctx, teamID, offset, limit
-func (_m *Repository) GetByTeamIDPaginated(ctx context.Context, teamID uint, offset *int, limit *int) (*[]entities.Incident, error) {
-	ret := _m.Called(ctx, teamID, offset, limit)
+// GetByTeamIDPaginated provides a mock function with given fields: ctx, teamID, filter, offset, limit
+func (_m *Repository) GetByTeamIDPaginated(ctx context.Context, teamID uint, filter incident.Filter, offset *int, limit *int) (*[]entities.Incident, error) {
+	ret := _m.Called(ctx, teamID, filter, offset, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByTeamIDPaginated")
@@ -152,19 +250,289 @@ func (_m *Repository) GetByTeamIDPaginated(ctx context.Context, teamID uint, off
 
 	var r0 *[]entities.Incident
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint, *int, *int) (*[]entities.Incident, error)); ok {
-		return rf(ctx, teamID, offset, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, incident.Filter, *int, *int) (*[]entities.Incident, error)); ok {
+		return rf(ctx, teamID, filter, offset, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint, *int, *int) *[]entities.Incident); ok {
-		r0 = rf(ctx, teamID, offset, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, incident.Filter, *int, *int) *[]entities.Incident); ok {
+		r0 = rf(ctx, teamID, filter, offset, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*[]entities.Incident)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint, *int, *int) error); ok {
-		r1 = rf(ctx, teamID, offset, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, incident.Filter, *int, *int) error); ok {
+		r1 = rf(ctx, teamID, filter, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChildrenByIncidentID provides a mock function with given fields: ctx, incidentID
+func (_m *Repository) GetChildrenByIncidentID(ctx context.Context, incidentID uint) ([]entities.Incident, error) {
+	ret := _m.Called(ctx, incidentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChildrenByIncidentID")
+	}
+
+	var r0 []entities.Incident
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]entities.Incident, error)); ok {
+		return rf(ctx, incidentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entities.Incident); ok {
+		r0 = rf(ctx, incidentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Incident)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, incidentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommentsByIncidentID provides a mock function with given fields: ctx, incidentID
+func (_m *Repository) GetCommentsByIncidentID(ctx context.Context, incidentID uint) ([]entities.IncidentComment, error) {
+	ret := _m.Called(ctx, incidentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommentsByIncidentID")
+	}
+
+	var r0 []entities.IncidentComment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]entities.IncidentComment, error)); ok {
+		return rf(ctx, incidentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entities.IncidentComment); ok {
+		r0 = rf(ctx, incidentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.IncidentComment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, incidentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLastResolvedByFingerprint provides a mock function with given fields: ctx, teamID, fingerprint, since
+func (_m *Repository) GetLastResolvedByFingerprint(ctx context.Context, teamID uint, fingerprint string, since time.Time) (*entities.Incident, error) {
+	ret := _m.Called(ctx, teamID, fingerprint, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastResolvedByFingerprint")
+	}
+
+	var r0 *entities.Incident
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, time.Time) (*entities.Incident, error)); ok {
+		return rf(ctx, teamID, fingerprint, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, time.Time) *entities.Incident); ok {
+		r0 = rf(ctx, teamID, fingerprint, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Incident)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, time.Time) error); ok {
+		r1 = rf(ctx, teamID, fingerprint, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpenByFingerprint provides a mock function with given fields: ctx, teamID, fingerprint
+func (_m *Repository) GetOpenByFingerprint(ctx context.Context, teamID uint, fingerprint string) (*entities.Incident, error) {
+	ret := _m.Called(ctx, teamID, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenByFingerprint")
+	}
+
+	var r0 *entities.Incident
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) (*entities.Incident, error)); ok {
+		return rf(ctx, teamID, fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) *entities.Incident); ok {
+		r0 = rf(ctx, teamID, fingerprint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Incident)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, teamID, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpenParentsByTeamID provides a mock function with given fields: ctx, teamID, since
+func (_m *Repository) GetOpenParentsByTeamID(ctx context.Context, teamID uint, since time.Time) ([]entities.Incident, error) {
+	ret := _m.Called(ctx, teamID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenParentsByTeamID")
+	}
+
+	var r0 []entities.Incident
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, time.Time) ([]entities.Incident, error)); ok {
+		return rf(ctx, teamID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, time.Time) []entities.Incident); ok {
+		r0 = rf(ctx, teamID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Incident)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, time.Time) error); ok {
+		r1 = rf(ctx, teamID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpenWithoutFingerprint provides a mock function with given fields: ctx
+func (_m *Repository) GetOpenWithoutFingerprint(ctx context.Context) ([]entities.Incident, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenWithoutFingerprint")
+	}
+
+	var r0 []entities.Incident
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entities.Incident, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entities.Incident); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Incident)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPublicUpdatesByIncidentIDs provides a mock function with given fields: ctx, incidentIDs
+func (_m *Repository) GetPublicUpdatesByIncidentIDs(ctx context.Context, incidentIDs []uint) ([]entities.IncidentUpdate, error) {
+	ret := _m.Called(ctx, incidentIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPublicUpdatesByIncidentIDs")
+	}
+
+	var r0 []entities.IncidentUpdate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uint) ([]entities.IncidentUpdate, error)); ok {
+		return rf(ctx, incidentIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uint) []entities.IncidentUpdate); ok {
+		r0 = rf(ctx, incidentIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.IncidentUpdate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uint) error); ok {
+		r1 = rf(ctx, incidentIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRespondersByIncidentID provides a mock function with given fields: ctx, incidentID
+func (_m *Repository) GetRespondersByIncidentID(ctx context.Context, incidentID uint) ([]entities.IncidentResponder, error) {
+	ret := _m.Called(ctx, incidentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRespondersByIncidentID")
+	}
+
+	var r0 []entities.IncidentResponder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]entities.IncidentResponder, error)); ok {
+		return rf(ctx, incidentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entities.IncidentResponder); ok {
+		r0 = rf(ctx, incidentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.IncidentResponder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, incidentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUpdatesByIncidentID provides a mock function with given fields: ctx, incidentID
+func (_m *Repository) GetUpdatesByIncidentID(ctx context.Context, incidentID uint) ([]entities.IncidentUpdate, error) {
+	ret := _m.Called(ctx, incidentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUpdatesByIncidentID")
+	}
+
+	var r0 []entities.IncidentUpdate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]entities.IncidentUpdate, error)); ok {
+		return rf(ctx, incidentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entities.IncidentUpdate); ok {
+		r0 = rf(ctx, incidentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.IncidentUpdate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, incidentID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -172,6 +540,96 @@ func (_m *Repository) GetByTeamIDPaginated(ctx context.Context, teamID uint, off
 	return r0, r1
 }
 
+// Merge provides a mock function with given fields: ctx, sourceID, targetID, groupID
+func (_m *Repository) Merge(ctx context.Context, sourceID uint, targetID uint, groupID uint) error {
+	ret := _m.Called(ctx, sourceID, targetID, groupID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, uint) error); ok {
+		r0 = rf(ctx, sourceID, targetID, groupID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MoveComments provides a mock function with given fields: ctx, fromID, toID, commentIDs
+func (_m *Repository) MoveComments(ctx context.Context, fromID uint, toID uint, commentIDs []uint) error {
+	ret := _m.Called(ctx, fromID, toID, commentIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveComments")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, []uint) error); ok {
+		r0 = rf(ctx, fromID, toID, commentIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveResponder provides a mock function with given fields: ctx, incidentID, userID
+func (_m *Repository) RemoveResponder(ctx context.Context, incidentID uint, userID uint) error {
+	ret := _m.Called(ctx, incidentID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveResponder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, incidentID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reopen provides a mock function with given fields: ctx, incidentID
+func (_m *Repository) Reopen(ctx context.Context, incidentID uint) error {
+	ret := _m.Called(ctx, incidentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reopen")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, incidentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Transaction provides a mock function with given fields: ctx, fn
+func (_m *Repository) Transaction(ctx context.Context, fn func(incident.Repository) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(incident.Repository) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, _a1
 func (_m *Repository) Update(ctx context.Context, _a1 *entities.Incident) error {
 	ret := _m.Called(ctx, _a1)
@@ -190,17 +648,17 @@ func (_m *Repository) Update(ctx context.Context, _a1 *entities.Incident) error
 	return r0
 }
 
-// Upsert provides a mock function with given fields: ctx, incidents
-func (_m *Repository) Upsert(ctx context.Context, incidents *[]entities.Incident) error {
-	ret := _m.Called(ctx, incidents)
+// UpdateAssignee provides a mock function with given fields: ctx, incidentID, assigneeID
+func (_m *Repository) UpdateAssignee(ctx context.Context, incidentID uint, assigneeID *uint) error {
+	ret := _m.Called(ctx, incidentID, assigneeID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Upsert")
+		panic("no return value specified for UpdateAssignee")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *[]entities.Incident) error); ok {
-		r0 = rf(ctx, incidents)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *uint) error); ok {
+		r0 = rf(ctx, incidentID, assigneeID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -208,34 +666,94 @@ func (_m *Repository) Upsert(ctx context.Context, incidents *[]entities.Incident
 	return r0
 }
 
-// GetActiveByMonitorIDs provides a mock function with given fields: ctx, monitorIDs
-func (_m *Repository) GetActiveByMonitorIDs(ctx context.Context, monitorIDs []uint) ([]entities.Incident, error) {
-	ret := _m.Called(ctx, monitorIDs)
+// UpdateCommander provides a mock function with given fields: ctx, incidentID, commanderID
+func (_m *Repository) UpdateCommander(ctx context.Context, incidentID uint, commanderID *uint) error {
+	ret := _m.Called(ctx, incidentID, commanderID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetActiveByMonitorIDs")
+		panic("no return value specified for UpdateCommander")
 	}
 
-	var r0 []entities.Incident
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []uint) ([]entities.Incident, error)); ok {
-		return rf(ctx, monitorIDs)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *uint) error); ok {
+		r0 = rf(ctx, incidentID, commanderID)
+	} else {
+		r0 = ret.Error(0)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []uint) []entities.Incident); ok {
-		r0 = rf(ctx, monitorIDs)
+
+	return r0
+}
+
+// UpdateFingerprint provides a mock function with given fields: ctx, _a1
+func (_m *Repository) UpdateFingerprint(ctx context.Context, _a1 *entities.Incident) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFingerprint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Incident) error); ok {
+		r0 = rf(ctx, _a1)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entities.Incident)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []uint) error); ok {
-		r1 = rf(ctx, monitorIDs)
+	return r0
+}
+
+// UpdateParent provides a mock function with given fields: ctx, incidentID, parentID
+func (_m *Repository) UpdateParent(ctx context.Context, incidentID uint, parentID *uint) error {
+	ret := _m.Called(ctx, incidentID, parentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateParent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *uint) error); ok {
+		r0 = rf(ctx, incidentID, parentID)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
+}
+
+// UpdateSuppressedReason provides a mock function with given fields: ctx, incidentID, reason
+func (_m *Repository) UpdateSuppressedReason(ctx context.Context, incidentID uint, reason *string) error {
+	ret := _m.Called(ctx, incidentID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSuppressedReason")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *string) error); ok {
+		r0 = rf(ctx, incidentID, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Upsert provides a mock function with given fields: ctx, incidents
+func (_m *Repository) Upsert(ctx context.Context, incidents *[]entities.Incident) error {
+	ret := _m.Called(ctx, incidents)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *[]entities.Incident) error); ok {
+		r0 = rf(ctx, incidents)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
 // NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -9,6 +9,8 @@ import (
 	incident "github.com/opsway-io/backend/internal/incident"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -16,6 +18,60 @@ type Service struct {
 	mock.Mock
 }
 
+// AddResponder provides a mock function with given fields: ctx, incidentID, userID
+func (_m *Service) AddResponder(ctx context.Context, incidentID uint, userID uint) error {
+	ret := _m.Called(ctx, incidentID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddResponder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, incidentID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddUpdate provides a mock function with given fields: ctx, _a1, update
+func (_m *Service) AddUpdate(ctx context.Context, _a1 *entities.Incident, update *entities.IncidentUpdate) error {
+	ret := _m.Called(ctx, _a1, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Incident, *entities.IncidentUpdate) error); ok {
+		r0 = rf(ctx, _a1, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Assign provides a mock function with given fields: ctx, _a1, assigneeID, assignedBy
+func (_m *Service) Assign(ctx context.Context, _a1 *entities.Incident, assigneeID *uint, assignedBy uint) error {
+	ret := _m.Called(ctx, _a1, assigneeID, assignedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Assign")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Incident, *uint, uint) error); ok {
+		r0 = rf(ctx, _a1, assigneeID, assignedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: ctx, incidents
 func (_m *Service) Create(ctx context.Context, incidents *[]entities.Incident) error {
 	ret := _m.Called(ctx, incidents)
@@ -52,6 +108,66 @@ func (_m *Service) Delete(ctx context.Context, _a1 *entities.Incident) error {
 	return r0
 }
 
+// GetActiveByMonitorIDs provides a mock function with given fields: ctx, monitorIDs
+func (_m *Service) GetActiveByMonitorIDs(ctx context.Context, monitorIDs []uint) ([]entities.Incident, error) {
+	ret := _m.Called(ctx, monitorIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveByMonitorIDs")
+	}
+
+	var r0 []entities.Incident
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uint) ([]entities.Incident, error)); ok {
+		return rf(ctx, monitorIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uint) []entities.Incident); ok {
+		r0 = rf(ctx, monitorIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Incident)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uint) error); ok {
+		r1 = rf(ctx, monitorIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAnalytics provides a mock function with given fields: ctx, teamID, filter
+func (_m *Service) GetAnalytics(ctx context.Context, teamID uint, filter incident.AnalyticsFilter) (*entities.IncidentAnalytics, error) {
+	ret := _m.Called(ctx, teamID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAnalytics")
+	}
+
+	var r0 *entities.IncidentAnalytics
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, incident.AnalyticsFilter) (*entities.IncidentAnalytics, error)); ok {
+		return rf(ctx, teamID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, incident.AnalyticsFilter) *entities.IncidentAnalytics); ok {
+		r0 = rf(ctx, teamID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.IncidentAnalytics)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, incident.AnalyticsFilter) error); ok {
+		r1 = rf(ctx, teamID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *Service) GetByID(ctx context.Context, id uint) (*entities.Incident, error) {
 	ret := _m.Called(ctx, id)
@@ -142,9 +258,9 @@ func (_m *Service) GetByTeamIDMonitorsIncidentStats(ctx context.Context, teamID
 	return r0, r1
 }
 
-// GetByTeamIDPaginated provides a mock function with given fields: ctx, teamID, offset, limit
-func (_m *Service) GetByTeamIDPaginated(ctx context.Context, teamID uint, offset *int, limit *int) (*[]entities.Incident, error) {
-	ret := _m.Called(ctx, teamID, offset, limit)
+// GetByTeamIDPaginated provides a mock function with given fields: ctx, teamID, filter, offset, limit
+func (_m *Service) GetByTeamIDPaginated(ctx context.Context, teamID uint, filter incident.Filter, offset *int, limit *int) (*[]entities.Incident, error) {
+	ret := _m.Called(ctx, teamID, filter, offset, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByTeamIDPaginated")
@@ -152,19 +268,49 @@ func (_m *Service) GetByTeamIDPaginated(ctx context.Context, teamID uint, offset
 
 	var r0 *[]entities.Incident
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint, *int, *int) (*[]entities.Incident, error)); ok {
-		return rf(ctx, teamID, offset, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, incident.Filter, *int, *int) (*[]entities.Incident, error)); ok {
+		return rf(ctx, teamID, filter, offset, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint, *int, *int) *[]entities.Incident); ok {
-		r0 = rf(ctx, teamID, offset, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, incident.Filter, *int, *int) *[]entities.Incident); ok {
+		r0 = rf(ctx, teamID, filter, offset, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*[]entities.Incident)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint, *int, *int) error); ok {
-		r1 = rf(ctx, teamID, offset, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, incident.Filter, *int, *int) error); ok {
+		r1 = rf(ctx, teamID, filter, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChildren provides a mock function with given fields: ctx, incidentID
+func (_m *Service) GetChildren(ctx context.Context, incidentID uint) ([]entities.Incident, error) {
+	ret := _m.Called(ctx, incidentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChildren")
+	}
+
+	var r0 []entities.Incident
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]entities.Incident, error)); ok {
+		return rf(ctx, incidentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entities.Incident); ok {
+		r0 = rf(ctx, incidentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Incident)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, incidentID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -172,6 +318,365 @@ func (_m *Service) GetByTeamIDPaginated(ctx context.Context, teamID uint, offset
 	return r0, r1
 }
 
+// GetComments provides a mock function with given fields: ctx, incidentID
+func (_m *Service) GetComments(ctx context.Context, incidentID uint) ([]entities.IncidentComment, error) {
+	ret := _m.Called(ctx, incidentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetComments")
+	}
+
+	var r0 []entities.IncidentComment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]entities.IncidentComment, error)); ok {
+		return rf(ctx, incidentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entities.IncidentComment); ok {
+		r0 = rf(ctx, incidentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.IncidentComment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, incidentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpenParents provides a mock function with given fields: ctx, teamID, since
+func (_m *Service) GetOpenParents(ctx context.Context, teamID uint, since time.Time) ([]entities.Incident, error) {
+	ret := _m.Called(ctx, teamID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenParents")
+	}
+
+	var r0 []entities.Incident
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, time.Time) ([]entities.Incident, error)); ok {
+		return rf(ctx, teamID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, time.Time) []entities.Incident); ok {
+		r0 = rf(ctx, teamID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Incident)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, time.Time) error); ok {
+		r1 = rf(ctx, teamID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPublicUpdatesByIncidentIDs provides a mock function with given fields: ctx, incidentIDs
+func (_m *Service) GetPublicUpdatesByIncidentIDs(ctx context.Context, incidentIDs []uint) ([]entities.IncidentUpdate, error) {
+	ret := _m.Called(ctx, incidentIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPublicUpdatesByIncidentIDs")
+	}
+
+	var r0 []entities.IncidentUpdate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uint) ([]entities.IncidentUpdate, error)); ok {
+		return rf(ctx, incidentIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uint) []entities.IncidentUpdate); ok {
+		r0 = rf(ctx, incidentIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.IncidentUpdate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uint) error); ok {
+		r1 = rf(ctx, incidentIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetResponders provides a mock function with given fields: ctx, incidentID
+func (_m *Service) GetResponders(ctx context.Context, incidentID uint) ([]entities.IncidentResponder, error) {
+	ret := _m.Called(ctx, incidentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetResponders")
+	}
+
+	var r0 []entities.IncidentResponder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]entities.IncidentResponder, error)); ok {
+		return rf(ctx, incidentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entities.IncidentResponder); ok {
+		r0 = rf(ctx, incidentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.IncidentResponder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, incidentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUpdates provides a mock function with given fields: ctx, incidentID
+func (_m *Service) GetUpdates(ctx context.Context, incidentID uint) ([]entities.IncidentUpdate, error) {
+	ret := _m.Called(ctx, incidentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUpdates")
+	}
+
+	var r0 []entities.IncidentUpdate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]entities.IncidentUpdate, error)); ok {
+		return rf(ctx, incidentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entities.IncidentUpdate); ok {
+		r0 = rf(ctx, incidentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.IncidentUpdate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, incidentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Merge provides a mock function with given fields: ctx, source, target, userID
+func (_m *Service) Merge(ctx context.Context, source *entities.Incident, target *entities.Incident, userID uint) error {
+	ret := _m.Called(ctx, source, target, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Incident, *entities.Incident, uint) error); ok {
+		r0 = rf(ctx, source, target, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MigrateLegacyFingerprints provides a mock function with given fields: ctx
+func (_m *Service) MigrateLegacyFingerprints(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MigrateLegacyFingerprints")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Open provides a mock function with given fields: ctx, _a1
+func (_m *Service) Open(ctx context.Context, _a1 *entities.Incident) (*entities.Incident, bool, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 *entities.Incident
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Incident) (*entities.Incident, bool, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Incident) *entities.Incident); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Incident)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entities.Incident) bool); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *entities.Incident) error); ok {
+		r2 = rf(ctx, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RemoveResponder provides a mock function with given fields: ctx, incidentID, userID
+func (_m *Service) RemoveResponder(ctx context.Context, incidentID uint, userID uint) error {
+	ret := _m.Called(ctx, incidentID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveResponder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, incidentID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reopen provides a mock function with given fields: ctx, _a1, userID, message
+func (_m *Service) Reopen(ctx context.Context, _a1 *entities.Incident, userID *uint, message string) error {
+	ret := _m.Called(ctx, _a1, userID, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reopen")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Incident, *uint, string) error); ok {
+		r0 = rf(ctx, _a1, userID, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCommander provides a mock function with given fields: ctx, _a1, commanderID
+func (_m *Service) SetCommander(ctx context.Context, _a1 *entities.Incident, commanderID *uint) error {
+	ret := _m.Called(ctx, _a1, commanderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCommander")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Incident, *uint) error); ok {
+		r0 = rf(ctx, _a1, commanderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetParent provides a mock function with given fields: ctx, _a1, parent
+func (_m *Service) SetParent(ctx context.Context, _a1 *entities.Incident, parent *entities.Incident) error {
+	ret := _m.Called(ctx, _a1, parent)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetParent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Incident, *entities.Incident) error); ok {
+		r0 = rf(ctx, _a1, parent)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Split provides a mock function with given fields: ctx, _a1, split, commentIDs, userID
+func (_m *Service) Split(ctx context.Context, _a1 *entities.Incident, split *entities.Incident, commentIDs []uint, userID uint) error {
+	ret := _m.Called(ctx, _a1, split, commentIDs, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Split")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Incident, *entities.Incident, []uint, uint) error); ok {
+		r0 = rf(ctx, _a1, split, commentIDs, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Suppress provides a mock function with given fields: ctx, _a1, reason
+func (_m *Service) Suppress(ctx context.Context, _a1 *entities.Incident, reason string) error {
+	ret := _m.Called(ctx, _a1, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Suppress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Incident, string) error); ok {
+		r0 = rf(ctx, _a1, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unsuppress provides a mock function with given fields: ctx, _a1
+func (_m *Service) Unsuppress(ctx context.Context, _a1 *entities.Incident) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unsuppress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Incident) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, _a1
 func (_m *Service) Update(ctx context.Context, _a1 *entities.Incident) error {
 	ret := _m.Called(ctx, _a1)
@@ -3,6 +3,7 @@ package incident
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/opsway-io/backend/internal/connectors/postgres"
 	"github.com/opsway-io/backend/internal/entities"
@@ -14,14 +15,36 @@ var ErrNotFound = errors.New("incident not found")
 
 type Repository interface {
 	GetByID(ctx context.Context, id uint) (*entities.Incident, error)
-	GetByTeamIDPaginated(ctx context.Context, teamID uint, offset, limit *int) (*[]entities.Incident, error)
+	GetByTeamIDPaginated(ctx context.Context, teamID uint, filter Filter, offset, limit *int) (*[]entities.Incident, error)
 	GetByMonitorIDWithAssertionPaginated(ctx context.Context, monitorID uint, offset, limit *int) (*[]IncidentAndAssertion, error)
 	GetActiveByMonitorIDs(ctx context.Context, monitorIDs []uint) ([]entities.Incident, error)
+	GetOpenByFingerprint(ctx context.Context, teamID uint, fingerprint string) (*entities.Incident, error)
+	GetLastResolvedByFingerprint(ctx context.Context, teamID uint, fingerprint string, since time.Time) (*entities.Incident, error)
+	GetOpenWithoutFingerprint(ctx context.Context) ([]entities.Incident, error)
+	UpdateFingerprint(ctx context.Context, incident *entities.Incident) error
 	Upsert(ctx context.Context, incidents *[]entities.Incident) error
 	Create(ctx context.Context, incidents *[]entities.Incident) error
 	Update(ctx context.Context, incident *entities.Incident) error
 	Delete(ctx context.Context, incident *entities.Incident) error
 	GetByTeamIDMonitorsIncidentStats(ctx context.Context, teamID uint, start, end string) (*[]entities.MonitorIncident, error)
+	GetAnalyticsByTeamID(ctx context.Context, teamID uint, filter AnalyticsFilter) (*entities.IncidentAnalytics, error)
+	GetCommentsByIncidentID(ctx context.Context, incidentID uint) ([]entities.IncidentComment, error)
+	UpdateAssignee(ctx context.Context, incidentID uint, assigneeID *uint) error
+	UpdateCommander(ctx context.Context, incidentID uint, commanderID *uint) error
+	UpdateParent(ctx context.Context, incidentID uint, parentID *uint) error
+	UpdateSuppressedReason(ctx context.Context, incidentID uint, reason *string) error
+	Reopen(ctx context.Context, incidentID uint) error
+	Merge(ctx context.Context, sourceID, targetID, groupID uint) error
+	MoveComments(ctx context.Context, fromID, toID uint, commentIDs []uint) error
+	GetOpenParentsByTeamID(ctx context.Context, teamID uint, since time.Time) ([]entities.Incident, error)
+	GetChildrenByIncidentID(ctx context.Context, incidentID uint) ([]entities.Incident, error)
+	GetRespondersByIncidentID(ctx context.Context, incidentID uint) ([]entities.IncidentResponder, error)
+	AddResponder(ctx context.Context, responder *entities.IncidentResponder) error
+	RemoveResponder(ctx context.Context, incidentID, userID uint) error
+	CreateUpdate(ctx context.Context, update *entities.IncidentUpdate) error
+	GetUpdatesByIncidentID(ctx context.Context, incidentID uint) ([]entities.IncidentUpdate, error)
+	GetPublicUpdatesByIncidentIDs(ctx context.Context, incidentIDs []uint) ([]entities.IncidentUpdate, error)
+	Transaction(ctx context.Context, fn func(repository Repository) error) error
 }
 
 type RepositoryImpl struct {
@@ -32,6 +55,14 @@ func NewRepository(db *gorm.DB) Repository {
 	return &RepositoryImpl{db: db}
 }
 
+// Transaction runs fn with a repository whose changes are committed together,
+// or rolled back if fn returns an error
+func (r *RepositoryImpl) Transaction(ctx context.Context, fn func(repository Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&RepositoryImpl{db: tx})
+	})
+}
+
 func (r *RepositoryImpl) GetByID(ctx context.Context, id uint) (*entities.Incident, error) {
 	var incident entities.Incident
 	if err := r.db.WithContext(
@@ -49,13 +80,51 @@ func (r *RepositoryImpl) GetByID(ctx context.Context, id uint) (*entities.Incide
 	return &incident, nil
 }
 
-func (r *RepositoryImpl) GetByTeamIDPaginated(ctx context.Context, teamID uint, offset, limit *int) (*[]entities.Incident, error) {
+// Filter narrows down the incidents of a team, the zero value matches all
+type Filter struct {
+	// Incidents assigned to the user
+	AssigneeID *uint
+	// Incidents the user is assigned to, commanding or responding to
+	InvolvedUserID *uint
+	Unassigned     bool
+	// Only incidents that are not grouped under a parent, with their children
+	TopLevel bool
+}
+
+func (f Filter) scope(db *gorm.DB) *gorm.DB {
+	if f.AssigneeID != nil {
+		db = db.Where("assignee_id = ?", *f.AssigneeID)
+	}
+
+	if f.InvolvedUserID != nil {
+		db = db.Where(
+			"assignee_id = ? OR commander_id = ? OR EXISTS (SELECT 1 FROM incident_responders ir WHERE ir.incident_id = incidents.id AND ir.user_id = ?)",
+			*f.InvolvedUserID, *f.InvolvedUserID, *f.InvolvedUserID,
+		)
+	}
+
+	if f.Unassigned {
+		db = db.Where("assignee_id IS NULL")
+	}
+
+	if f.TopLevel {
+		db = db.Where("parent_id IS NULL").Preload("Children", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at asc")
+		})
+	}
+
+	return db
+}
+
+func (r *RepositoryImpl) GetByTeamIDPaginated(ctx context.Context, teamID uint, filter Filter, offset, limit *int) (*[]entities.Incident, error) {
 	var incidents []entities.Incident
 	if err := r.db.WithContext(
 		ctx,
 	).Where(entities.Incident{
 		TeamID: teamID,
-	}).Order(
+	}).Scopes(
+		filter.scope,
+	).Order(
 		"created_at desc",
 	).Scopes(
 		postgres.Paginated(offset, limit),
@@ -111,11 +180,72 @@ func (r *RepositoryImpl) GetActiveByMonitorIDs(ctx context.Context, monitorIDs [
 	return incidents, nil
 }
 
+func (r *RepositoryImpl) GetOpenByFingerprint(ctx context.Context, teamID uint, fingerprint string) (*entities.Incident, error) {
+	var incident entities.Incident
+	if err := r.db.WithContext(
+		ctx,
+	).Where(
+		"team_id = ? AND fingerprint = ? AND resolved = ?", teamID, fingerprint, false,
+	).First(&incident).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return &incident, nil
+}
+
+// GetLastResolvedByFingerprint returns the incident of the team with the
+// fingerprint that was most recently resolved since the given time, merged
+// incidents excluded.
+func (r *RepositoryImpl) GetLastResolvedByFingerprint(ctx context.Context, teamID uint, fingerprint string, since time.Time) (*entities.Incident, error) {
+	var incident entities.Incident
+	if err := r.db.WithContext(
+		ctx,
+	).Where(
+		"team_id = ? AND fingerprint = ? AND resolved = ? AND merged_into_id IS NULL AND resolved_at >= ?", teamID, fingerprint, true, since,
+	).Order(
+		"resolved_at desc",
+	).First(&incident).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return &incident, nil
+}
+
+func (r *RepositoryImpl) GetOpenWithoutFingerprint(ctx context.Context) ([]entities.Incident, error) {
+	var incidents []entities.Incident
+	if err := r.db.WithContext(
+		ctx,
+	).Where(
+		"fingerprint IS NULL AND resolved = ?", false,
+	).Find(&incidents).Error; err != nil {
+		return nil, err
+	}
+
+	return incidents, nil
+}
+
+// UpdateFingerprint stores the source, kind and fingerprint of the incident
+func (r *RepositoryImpl) UpdateFingerprint(ctx context.Context, incident *entities.Incident) error {
+	return r.db.WithContext(ctx).Model(&entities.Incident{ID: incident.ID}).Updates(map[string]any{
+		"source":      incident.Source,
+		"kind":        incident.Kind,
+		"fingerprint": incident.Fingerprint,
+	}).Error
+}
+
 func (r *RepositoryImpl) Upsert(ctx context.Context, incidents *[]entities.Incident) error {
 	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "monitor_assertion_id"}},
+		Columns:   []clause.Column{{Name: "team_id"}, {Name: "fingerprint"}},
 		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "resolved = false"}}},
-		DoUpdates: clause.AssignmentColumns([]string{"updated_at"}),
+		DoUpdates: clause.AssignmentColumns([]string{"failed_locations", "updated_at"}),
 	}).Create(incidents).Error
 }
 
@@ -166,3 +296,349 @@ func (r *RepositoryImpl) GetByTeamIDMonitorsIncidentStats(ctx context.Context, t
 
 	return &incidents, nil
 }
+
+// AnalyticsFilter selects the incidents opened in [Start, End), optionally of a
+// single monitor
+type AnalyticsFilter struct {
+	Start     time.Time
+	End       time.Time
+	MonitorID *uint
+	// Number of noisy monitors to return
+	NoisyMonitors int
+}
+
+func (f AnalyticsFilter) scope(db *gorm.DB) *gorm.DB {
+	db = db.Where("created_at >= ? AND created_at < ? AND merged_into_id IS NULL", f.Start, f.End)
+
+	if f.MonitorID != nil {
+		db = db.Where("monitor_id = ?", *f.MonitorID)
+	}
+
+	return db
+}
+
+func (r *RepositoryImpl) GetAnalyticsByTeamID(ctx context.Context, teamID uint, filter AnalyticsFilter) (*entities.IncidentAnalytics, error) {
+	incidents := func() *gorm.DB {
+		return r.db.WithContext(ctx).Model(&entities.Incident{}).Where("team_id = ?", teamID).Scopes(filter.scope)
+	}
+
+	analytics := entities.IncidentAnalytics{
+		Start:         filter.Start,
+		End:           filter.End,
+		MonitorID:     filter.MonitorID,
+		ByKind:        []entities.IncidentCount{},
+		BySeverity:    []entities.IncidentCount{},
+		BusiestHours:  []entities.IncidentHourCount{},
+		NoisyMonitors: []entities.NoisyMonitor{},
+	}
+
+	var summary struct {
+		Count        int
+		Acknowledged int
+		Resolved     int
+		MTTA         *float64
+		MTTR         *float64
+	}
+	if err := incidents().Select(`
+		count(*) as count,
+		count(acknowledged_at) as acknowledged,
+		count(resolved_at) as resolved,
+		avg(extract(epoch from acknowledged_at - created_at)) as mtta,
+		avg(extract(epoch from resolved_at - created_at)) as mttr`,
+	).Scan(&summary).Error; err != nil {
+		return nil, err
+	}
+
+	analytics.Count = summary.Count
+	analytics.Acknowledged = summary.Acknowledged
+	analytics.Resolved = summary.Resolved
+	analytics.MTTA = summary.MTTA
+	analytics.MTTR = summary.MTTR
+
+	if err := incidents().Select(
+		"kind as key, count(*) as count",
+	).Group("kind").Order("count desc, key asc").Scan(&analytics.ByKind).Error; err != nil {
+		return nil, err
+	}
+
+	if err := incidents().Select(
+		"severity as key, count(*) as count",
+	).Group("severity").Order("count desc, key asc").Scan(&analytics.BySeverity).Error; err != nil {
+		return nil, err
+	}
+
+	if err := incidents().Select(
+		"extract(hour from created_at at time zone 'UTC')::int as hour, count(*) as count",
+	).Group("hour").Order("count desc, hour asc").Scan(&analytics.BusiestHours).Error; err != nil {
+		return nil, err
+	}
+
+	if err := incidents().Select(
+		"monitor_id, count(*) as count, avg(extract(epoch from resolved_at - created_at)) as mttr",
+	).Where("monitor_id IS NOT NULL").Group("monitor_id").Order(
+		"count desc, monitor_id asc",
+	).Limit(filter.NoisyMonitors).Scan(&analytics.NoisyMonitors).Error; err != nil {
+		return nil, err
+	}
+
+	return &analytics, nil
+}
+
+func (r *RepositoryImpl) GetCommentsByIncidentID(ctx context.Context, incidentID uint) ([]entities.IncidentComment, error) {
+	var comments []entities.IncidentComment
+	if err := r.db.WithContext(
+		ctx,
+	).Where(entities.IncidentComment{
+		IncidentID: incidentID,
+	}).Order(
+		"created_at asc",
+	).Find(&comments).Error; err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (r *RepositoryImpl) CreateUpdate(ctx context.Context, update *entities.IncidentUpdate) error {
+	return r.db.WithContext(ctx).Create(update).Error
+}
+
+func (r *RepositoryImpl) GetUpdatesByIncidentID(ctx context.Context, incidentID uint) ([]entities.IncidentUpdate, error) {
+	var updates []entities.IncidentUpdate
+	if err := r.db.WithContext(
+		ctx,
+	).Where(entities.IncidentUpdate{
+		IncidentID: incidentID,
+	}).Order(
+		"created_at desc",
+	).Find(&updates).Error; err != nil {
+		return nil, err
+	}
+
+	return updates, nil
+}
+
+func (r *RepositoryImpl) GetPublicUpdatesByIncidentIDs(ctx context.Context, incidentIDs []uint) ([]entities.IncidentUpdate, error) {
+	if len(incidentIDs) == 0 {
+		return []entities.IncidentUpdate{}, nil
+	}
+
+	var updates []entities.IncidentUpdate
+	if err := r.db.WithContext(
+		ctx,
+	).Where("incident_id IN ? AND public = ?", incidentIDs, true).
+		Order("created_at desc").
+		Find(&updates).Error; err != nil {
+		return nil, err
+	}
+
+	return updates, nil
+}
+
+func (r *RepositoryImpl) UpdateAssignee(ctx context.Context, incidentID uint, assigneeID *uint) error {
+	result := r.db.WithContext(ctx).Model(&entities.Incident{ID: incidentID}).Update("assignee_id", assigneeID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *RepositoryImpl) UpdateCommander(ctx context.Context, incidentID uint, commanderID *uint) error {
+	result := r.db.WithContext(ctx).Model(&entities.Incident{ID: incidentID}).Update("commander_id", commanderID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *RepositoryImpl) UpdateParent(ctx context.Context, incidentID uint, parentID *uint) error {
+	result := r.db.WithContext(ctx).Model(&entities.Incident{ID: incidentID}).Update("parent_id", parentID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *RepositoryImpl) UpdateSuppressedReason(ctx context.Context, incidentID uint, reason *string) error {
+	result := r.db.WithContext(ctx).Model(&entities.Incident{ID: incidentID}).Update("suppressed_reason", reason)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Reopen marks the incident as unresolved and unacknowledged again
+func (r *RepositoryImpl) Reopen(ctx context.Context, incidentID uint) error {
+	result := r.db.WithContext(ctx).Model(&entities.Incident{ID: incidentID}).Updates(map[string]any{
+		"resolved":        false,
+		"resolved_at":     nil,
+		"status":          entities.IncidentStatusInvestigating,
+		"acknowledged":    false,
+		"acknowledged_by": nil,
+		"acknowledged_at": nil,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Merge moves the comments, responders and notifications of the source
+// incident to the target incident, and marks the source as merged into the
+// target. Children of the source are grouped under the group incident, which
+// is no longer grouped itself if it was a child of the source. Updates stay on
+// the timeline of the source.
+func (r *RepositoryImpl) Merge(ctx context.Context, sourceID, targetID, groupID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entities.IncidentComment{}).Where(
+			"incident_id = ?", sourceID,
+		).Update("incident_id", targetID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(
+			"INSERT INTO incident_responders (incident_id, user_id, created_at) SELECT ?, user_id, created_at FROM incident_responders WHERE incident_id = ? ON CONFLICT DO NOTHING",
+			targetID, sourceID,
+		).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("incident_id = ?", sourceID).Delete(&entities.IncidentResponder{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&entities.NotificationAttempt{}).Where(
+			"incident_id = ?", sourceID,
+		).Update("incident_id", targetID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&entities.Incident{}).Where(
+			"parent_id = ? AND id <> ?", sourceID, groupID,
+		).Update("parent_id", groupID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&entities.Incident{}).Where(
+			"id = ? AND parent_id = ?", groupID, sourceID,
+		).Update("parent_id", nil).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&entities.Incident{ID: sourceID}).Update("merged_into_id", targetID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		return nil
+	})
+}
+
+// MoveComments moves the comments to another incident, all of them must belong
+// to the incident they are moved from.
+func (r *RepositoryImpl) MoveComments(ctx context.Context, fromID, toID uint, commentIDs []uint) error {
+	if len(commentIDs) == 0 {
+		return nil
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.IncidentComment{}).Where(
+			"id IN ? AND incident_id = ?", commentIDs, fromID,
+		).Update("incident_id", toID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(commentIDs)) {
+			return ErrNotFound
+		}
+
+		return nil
+	})
+}
+
+// GetOpenParentsByTeamID returns the unresolved incidents of the team opened
+// since the given time that are not grouped themselves, oldest first.
+// Suppressed incidents are left out, as no one was paged for them.
+func (r *RepositoryImpl) GetOpenParentsByTeamID(ctx context.Context, teamID uint, since time.Time) ([]entities.Incident, error) {
+	var incidents []entities.Incident
+	if err := r.db.WithContext(
+		ctx,
+	).Where(
+		"team_id = ? AND resolved = ? AND parent_id IS NULL AND suppressed_reason IS NULL AND created_at >= ?", teamID, false, since,
+	).Order(
+		"created_at asc",
+	).Find(&incidents).Error; err != nil {
+		return nil, err
+	}
+
+	return incidents, nil
+}
+
+func (r *RepositoryImpl) GetChildrenByIncidentID(ctx context.Context, incidentID uint) ([]entities.Incident, error) {
+	var incidents []entities.Incident
+	if err := r.db.WithContext(
+		ctx,
+	).Where(
+		"parent_id = ?", incidentID,
+	).Order(
+		"created_at asc",
+	).Find(&incidents).Error; err != nil {
+		return nil, err
+	}
+
+	return incidents, nil
+}
+
+func (r *RepositoryImpl) GetRespondersByIncidentID(ctx context.Context, incidentID uint) ([]entities.IncidentResponder, error) {
+	var responders []entities.IncidentResponder
+	if err := r.db.WithContext(
+		ctx,
+	).Where(entities.IncidentResponder{
+		IncidentID: incidentID,
+	}).Order(
+		"created_at asc",
+	).Find(&responders).Error; err != nil {
+		return nil, err
+	}
+
+	return responders, nil
+}
+
+func (r *RepositoryImpl) AddResponder(ctx context.Context, responder *entities.IncidentResponder) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(responder).Error
+}
+
+func (r *RepositoryImpl) RemoveResponder(ctx context.Context, incidentID, userID uint) error {
+	result := r.db.WithContext(ctx).Where("incident_id = ? AND user_id = ?", incidentID, userID).Delete(&entities.IncidentResponder{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -95,6 +95,15 @@ func TestRepositoryIntegration(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, fetchedUpdate.Resolved)
 
+	// Reopen
+	err = repo.Reopen(ctx, dbInc.ID)
+	assert.NoError(t, err)
+
+	fetchedUpdate, err = repo.GetByID(ctx, dbInc.ID)
+	assert.NoError(t, err)
+	assert.False(t, fetchedUpdate.Resolved)
+	assert.Nil(t, fetchedUpdate.ResolvedAt)
+
 	// Delete
 	err = repo.Delete(ctx, fetchedUpdate)
 	assert.NoError(t, err)
@@ -2,22 +2,63 @@ package incident
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/opsway-io/backend/internal/entities"
 	"github.com/opsway-io/backend/internal/event"
 	"github.com/opsway-io/backend/internal/event/events"
 )
 
+var (
+	ErrInvalidStatus       = errors.New("invalid incident status")
+	ErrIncidentResolved    = errors.New("incident is resolved")
+	ErrInvalidParent       = errors.New("invalid parent incident")
+	ErrInvalidMerge        = errors.New("invalid incident merge")
+	ErrIncidentMerged      = errors.New("incident is merged")
+	ErrIncidentNotResolved = errors.New("incident is not resolved")
+	ErrIncidentOpen        = errors.New("an incident with the same fingerprint is open")
+	ErrCommentNotFound     = errors.New("incident comment not found")
+	ErrInvalidTimeRange    = errors.New("invalid time range")
+)
+
+// Failures that recur within the window after their incident was resolved
+// reopen that incident instead of opening a new one
+const ReopenWindow = 15 * time.Minute
+
 type Service interface {
 	GetByID(ctx context.Context, id uint) (*entities.Incident, error)
-	GetByTeamIDPaginated(ctx context.Context, teamID uint, offset, limit *int) (*[]entities.Incident, error)
+	GetByTeamIDPaginated(ctx context.Context, teamID uint, filter Filter, offset, limit *int) (*[]entities.Incident, error)
 	GetByMonitorIDWithAssertionPaginated(ctx context.Context, monitorID uint, offset, limit *int) (*[]IncidentAndAssertion, error)
 	GetActiveByMonitorIDs(ctx context.Context, monitorIDs []uint) ([]entities.Incident, error)
 	Upsert(ctx context.Context, incidents *[]entities.Incident) error
 	Create(ctx context.Context, incidents *[]entities.Incident) error
+	Open(ctx context.Context, incident *entities.Incident) (*entities.Incident, bool, error)
 	Update(ctx context.Context, incident *entities.Incident) error
+	Reopen(ctx context.Context, incident *entities.Incident, userID *uint, message string) error
+	Merge(ctx context.Context, source, target *entities.Incident, userID uint) error
+	Split(ctx context.Context, incident, split *entities.Incident, commentIDs []uint, userID uint) error
 	Delete(ctx context.Context, incident *entities.Incident) error
 	GetByTeamIDMonitorsIncidentStats(ctx context.Context, teamID uint, start, end string) (*[]entities.MonitorIncident, error)
+	GetAnalytics(ctx context.Context, teamID uint, filter AnalyticsFilter) (*entities.IncidentAnalytics, error)
+	GetComments(ctx context.Context, incidentID uint) ([]entities.IncidentComment, error)
+	Assign(ctx context.Context, incident *entities.Incident, assigneeID *uint, assignedBy uint) error
+	SetCommander(ctx context.Context, incident *entities.Incident, commanderID *uint) error
+	SetParent(ctx context.Context, incident *entities.Incident, parent *entities.Incident) error
+	Suppress(ctx context.Context, incident *entities.Incident, reason string) error
+	Unsuppress(ctx context.Context, incident *entities.Incident) error
+	GetOpenParents(ctx context.Context, teamID uint, since time.Time) ([]entities.Incident, error)
+	GetChildren(ctx context.Context, incidentID uint) ([]entities.Incident, error)
+	GetResponders(ctx context.Context, incidentID uint) ([]entities.IncidentResponder, error)
+	AddResponder(ctx context.Context, incidentID, userID uint) error
+	RemoveResponder(ctx context.Context, incidentID, userID uint) error
+	AddUpdate(ctx context.Context, incident *entities.Incident, update *entities.IncidentUpdate) error
+	GetUpdates(ctx context.Context, incidentID uint) ([]entities.IncidentUpdate, error)
+	GetPublicUpdatesByIncidentIDs(ctx context.Context, incidentIDs []uint) ([]entities.IncidentUpdate, error)
+	MigrateLegacyFingerprints(ctx context.Context) (int, error)
 }
 
 type ServiceImpl struct {
@@ -36,8 +77,8 @@ func (s *ServiceImpl) GetByID(ctx context.Context, id uint) (*entities.Incident,
 	return s.repository.GetByID(ctx, id)
 }
 
-func (s *ServiceImpl) GetByTeamIDPaginated(ctx context.Context, teamID uint, offset, limit *int) (*[]entities.Incident, error) {
-	return s.repository.GetByTeamIDPaginated(ctx, teamID, offset, limit)
+func (s *ServiceImpl) GetByTeamIDPaginated(ctx context.Context, teamID uint, filter Filter, offset, limit *int) (*[]entities.Incident, error) {
+	return s.repository.GetByTeamIDPaginated(ctx, teamID, filter, offset, limit)
 }
 
 func (s *ServiceImpl) GetByMonitorIDWithAssertionPaginated(ctx context.Context, monitorID uint, offset, limit *int) (*[]IncidentAndAssertion, error) {
@@ -72,10 +113,349 @@ func (s *ServiceImpl) Create(ctx context.Context, incidents *[]entities.Incident
 	return err
 }
 
-func (s *ServiceImpl) Update(ctx context.Context, incident *entities.Incident) error {
+// Open opens the incident, unless an incident with the same fingerprint is
+// already open. The open incident is then refreshed with the severity and
+// failed locations of the new one, and returned instead. An incident with the
+// same fingerprint resolved within the ReopenWindow is reopened. It reports
+// whether the incident was opened or reopened.
+func (s *ServiceImpl) Open(ctx context.Context, incident *entities.Incident) (*entities.Incident, bool, error) {
+	if incident.Fingerprint != nil {
+		open, err := s.repository.GetOpenByFingerprint(ctx, incident.TeamID, *incident.Fingerprint)
+		if err == nil {
+			return open, false, s.refresh(ctx, open, incident)
+		}
+
+		if !errors.Is(err, ErrNotFound) {
+			return nil, false, err
+		}
+
+		resolved, err := s.repository.GetLastResolvedByFingerprint(ctx, incident.TeamID, *incident.Fingerprint, time.Now().Add(-ReopenWindow))
+		if err == nil {
+			if err := s.Reopen(ctx, resolved, nil, "Reopened as the failure recurred shortly after resolution"); err != nil {
+				return nil, false, err
+			}
+
+			return resolved, true, s.refresh(ctx, resolved, incident)
+		}
+
+		if !errors.Is(err, ErrNotFound) {
+			return nil, false, err
+		}
+	}
+
+	incidents := []entities.Incident{*incident}
+	if err := s.Create(ctx, &incidents); err != nil {
+		if incident.Fingerprint == nil {
+			return nil, false, err
+		}
+
+		// Another incident with the same fingerprint was opened in the meantime
+		open, findErr := s.repository.GetOpenByFingerprint(ctx, incident.TeamID, *incident.Fingerprint)
+		if findErr != nil {
+			return nil, false, err
+		}
+
+		return open, false, s.refresh(ctx, open, incident)
+	}
+
+	return &incidents[0], true, nil
+}
+
+// refresh raises the incident to the severity of the latest occurrence if that
+// is more severe, and takes over its failed locations
+func (s *ServiceImpl) refresh(ctx context.Context, incident *entities.Incident, latest *entities.Incident) error {
+	changed := false
+
+	if latest.Severity.Rank() > incident.Severity.Rank() {
+		incident.Severity = latest.Severity
+		changed = true
+	}
+
+	if len(latest.FailedLocations) > 0 && !slices.Equal(latest.FailedLocations, incident.FailedLocations) {
+		incident.FailedLocations = latest.FailedLocations
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
 	return s.repository.Update(ctx, incident)
 }
 
+func (s *ServiceImpl) Update(ctx context.Context, incident *entities.Incident) error {
+	previous, err := s.update(ctx, s.repository, incident)
+	if err != nil {
+		return err
+	}
+
+	s.publishChanges(previous, incident)
+
+	return nil
+}
+
+// update stores the incident and returns it as it was before.
+func (s *ServiceImpl) update(ctx context.Context, repository Repository, incident *entities.Incident) (*entities.Incident, error) {
+	previous, err := repository.GetByID(ctx, incident.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	incident.SyncStatus()
+	if !previous.Resolved && incident.Resolved && incident.ResolvedAt == nil {
+		now := time.Now()
+		incident.ResolvedAt = &now
+	}
+
+	if err := repository.Update(ctx, incident); err != nil {
+		return nil, err
+	}
+
+	return previous, nil
+}
+
+// publishChanges publishes the acknowledgement or resolution of the incident
+// if it was not acknowledged or resolved before.
+func (s *ServiceImpl) publishChanges(previous, incident *entities.Incident) {
+	if !previous.Acknowledged && incident.Acknowledged {
+		_ = s.eventService.Publish(events.IncidentAcknowledgedEvent{
+			Incident: incident,
+		})
+	}
+
+	if !previous.Resolved && incident.Resolved {
+		_ = s.eventService.Publish(events.IncidentResolvedEvent{
+			Incident: incident,
+		})
+	}
+}
+
+// Reopen marks the resolved incident as unresolved and unacknowledged, so
+// responders are paged again. The message is added to its timeline.
+func (s *ServiceImpl) Reopen(ctx context.Context, incident *entities.Incident, userID *uint, message string) error {
+	if incident.IsMerged() {
+		return ErrIncidentMerged
+	}
+
+	if !incident.Resolved {
+		return ErrIncidentNotResolved
+	}
+
+	if incident.Fingerprint != nil {
+		_, err := s.repository.GetOpenByFingerprint(ctx, incident.TeamID, *incident.Fingerprint)
+		if err == nil {
+			return ErrIncidentOpen
+		}
+
+		if !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+
+	if err := s.repository.Reopen(ctx, incident.ID); err != nil {
+		return err
+	}
+
+	incident.Resolved = false
+	incident.ResolvedAt = nil
+	incident.Status = entities.IncidentStatusInvestigating
+	incident.Acknowledged = false
+	incident.AcknowledgedBy = nil
+	incident.AcknowledgedAt = nil
+
+	if err := s.repository.CreateUpdate(ctx, &entities.IncidentUpdate{
+		IncidentID: incident.ID,
+		UserID:     userID,
+		Status:     incident.Status,
+		Message:    message,
+	}); err != nil {
+		return err
+	}
+
+	_ = s.eventService.Publish(events.IncidentReopenedEvent{
+		Incident: incident,
+	})
+
+	return nil
+}
+
+// Merge merges the source incident into the target incident of the same team.
+// The comments, responders and notifications of the source are moved to the
+// target, which also takes over its acknowledgement and severity if the source
+// is acknowledged or more severe. The source is resolved, and the merge is
+// recorded on the timelines of both.
+func (s *ServiceImpl) Merge(ctx context.Context, source, target *entities.Incident, userID uint) error {
+	if source.ID == target.ID || source.TeamID != target.TeamID {
+		return ErrInvalidMerge
+	}
+
+	if source.IsMerged() || target.IsMerged() {
+		return ErrIncidentMerged
+	}
+
+	if target.Resolved {
+		return ErrIncidentResolved
+	}
+
+	// Children of the source join the group of the target
+	groupID := target.ID
+	if target.ParentID != nil && *target.ParentID != source.ID {
+		groupID = *target.ParentID
+	}
+
+	acknowledged := source.Acknowledged && !target.Acknowledged
+	resolved := !source.Resolved
+
+	// The merge is applied as a whole, so an incident is never left half merged
+	err := s.repository.Transaction(ctx, func(repository Repository) error {
+		if err := repository.Merge(ctx, source.ID, target.ID, groupID); err != nil {
+			return err
+		}
+
+		if groupID == target.ID {
+			target.ParentID = nil
+		}
+
+		takeOver := false
+		if acknowledged {
+			target.Acknowledged = true
+			target.AcknowledgedBy = source.AcknowledgedBy
+			target.AcknowledgedAt = source.AcknowledgedAt
+			takeOver = true
+		}
+
+		if source.Severity.Rank() > target.Severity.Rank() {
+			target.Severity = source.Severity
+			takeOver = true
+		}
+
+		if takeOver {
+			if err := repository.Update(ctx, target); err != nil {
+				return err
+			}
+		}
+
+		source.MergedIntoID = &target.ID
+		if resolved {
+			now := time.Now()
+			source.Status = entities.IncidentStatusResolved
+			source.ResolvedAt = &now
+			source.SyncStatus()
+
+			if err := repository.Update(ctx, source); err != nil {
+				return err
+			}
+		}
+
+		if err := repository.CreateUpdate(ctx, &entities.IncidentUpdate{
+			IncidentID: source.ID,
+			UserID:     &userID,
+			Status:     source.Status,
+			Message:    fmt.Sprintf("Merged into incident #%d (%s)", target.ID, target.Title),
+		}); err != nil {
+			return err
+		}
+
+		return repository.CreateUpdate(ctx, &entities.IncidentUpdate{
+			IncidentID: target.ID,
+			UserID:     &userID,
+			Status:     target.Status,
+			Message:    fmt.Sprintf("Incident #%d (%s) was merged into this incident", source.ID, source.Title),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	if acknowledged {
+		_ = s.eventService.Publish(events.IncidentAcknowledgedEvent{
+			Incident: target,
+		})
+	}
+
+	if resolved {
+		_ = s.eventService.Publish(events.IncidentResolvedEvent{
+			Incident: source,
+		})
+	}
+
+	return nil
+}
+
+// Split opens the split incident for a problem that turned out to be covered
+// by the incident, and moves the given comments of the incident to it. The
+// split is recorded on the timelines of both.
+func (s *ServiceImpl) Split(ctx context.Context, incident, split *entities.Incident, commentIDs []uint, userID uint) error {
+	if incident.IsMerged() {
+		return ErrIncidentMerged
+	}
+
+	slices.Sort(commentIDs)
+	commentIDs = slices.Compact(commentIDs)
+
+	if len(commentIDs) > 0 {
+		comments, err := s.repository.GetCommentsByIncidentID(ctx, incident.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range commentIDs {
+			if !slices.ContainsFunc(comments, func(c entities.IncidentComment) bool { return c.ID == id }) {
+				return ErrCommentNotFound
+			}
+		}
+	}
+
+	split.ID = 0
+	split.TeamID = incident.TeamID
+	split.SplitFromID = &incident.ID
+	split.Source = incident.Source
+	split.Kind = incident.Kind
+	split.Status = entities.IncidentStatusInvestigating
+	if split.Severity == "" {
+		split.Severity = incident.Severity
+	}
+
+	// The split is applied as a whole, so an incident is never left half split
+	err := s.repository.Transaction(ctx, func(repository Repository) error {
+		incidents := []entities.Incident{*split}
+		if err := repository.Create(ctx, &incidents); err != nil {
+			return err
+		}
+
+		*split = incidents[0]
+
+		if err := repository.MoveComments(ctx, incident.ID, split.ID, commentIDs); err != nil {
+			return err
+		}
+
+		if err := repository.CreateUpdate(ctx, &entities.IncidentUpdate{
+			IncidentID: incident.ID,
+			UserID:     &userID,
+			Status:     incident.Status,
+			Message:    fmt.Sprintf("Split off incident #%d (%s)", split.ID, split.Title),
+		}); err != nil {
+			return err
+		}
+
+		return repository.CreateUpdate(ctx, &entities.IncidentUpdate{
+			IncidentID: split.ID,
+			UserID:     &userID,
+			Status:     split.Status,
+			Message:    fmt.Sprintf("Split off incident #%d (%s)", incident.ID, incident.Title),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	_ = s.eventService.Publish(events.IncidentCreatedEvent{
+		Incident: split,
+	})
+
+	return nil
+}
+
 func (s *ServiceImpl) Delete(ctx context.Context, incident *entities.Incident) error {
 	return s.repository.Delete(ctx, incident)
 }
@@ -83,3 +463,268 @@ func (s *ServiceImpl) Delete(ctx context.Context, incident *entities.Incident) e
 func (s *ServiceImpl) GetByTeamIDMonitorsIncidentStats(ctx context.Context, teamID uint, start, end string) (*[]entities.MonitorIncident, error) {
 	return s.repository.GetByTeamIDMonitorsIncidentStats(ctx, teamID, start, end)
 }
+
+// Number of noisy monitors returned when the filter does not say
+const defaultNoisyMonitors = 10
+
+// GetAnalytics returns the mean times to acknowledge and resolve, and the
+// breakdowns of the incidents selected by the filter
+func (s *ServiceImpl) GetAnalytics(ctx context.Context, teamID uint, filter AnalyticsFilter) (*entities.IncidentAnalytics, error) {
+	if !filter.End.After(filter.Start) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	if filter.NoisyMonitors <= 0 {
+		filter.NoisyMonitors = defaultNoisyMonitors
+	}
+
+	return s.repository.GetAnalyticsByTeamID(ctx, teamID, filter)
+}
+
+func (s *ServiceImpl) GetComments(ctx context.Context, incidentID uint) ([]entities.IncidentComment, error) {
+	return s.repository.GetCommentsByIncidentID(ctx, incidentID)
+}
+
+// AddUpdate moves the incident to the status of the update and appends the
+// update to the timeline of the incident.
+func (s *ServiceImpl) AddUpdate(ctx context.Context, incident *entities.Incident, update *entities.IncidentUpdate) error {
+	if !update.Status.IsValid() {
+		return ErrInvalidStatus
+	}
+
+	if incident.Resolved {
+		return ErrIncidentResolved
+	}
+
+	incident.Status = update.Status
+	update.IncidentID = incident.ID
+
+	// The status only changes together with its entry on the timeline
+	var previous *entities.Incident
+	err := s.repository.Transaction(ctx, func(repository Repository) error {
+		var err error
+		if previous, err = s.update(ctx, repository, incident); err != nil {
+			return err
+		}
+
+		return repository.CreateUpdate(ctx, update)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publishChanges(previous, incident)
+
+	return nil
+}
+
+func (s *ServiceImpl) GetUpdates(ctx context.Context, incidentID uint) ([]entities.IncidentUpdate, error) {
+	return s.repository.GetUpdatesByIncidentID(ctx, incidentID)
+}
+
+func (s *ServiceImpl) GetPublicUpdatesByIncidentIDs(ctx context.Context, incidentIDs []uint) ([]entities.IncidentUpdate, error) {
+	return s.repository.GetPublicUpdatesByIncidentIDs(ctx, incidentIDs)
+}
+
+// Assign assigns the incident to a team member, or unassigns it when the
+// assignee is nil. The new assignee is notified unless they assigned
+// themselves.
+func (s *ServiceImpl) Assign(ctx context.Context, incident *entities.Incident, assigneeID *uint, assignedBy uint) error {
+	if err := s.repository.UpdateAssignee(ctx, incident.ID, assigneeID); err != nil {
+		return err
+	}
+
+	previous := incident.AssigneeID
+	incident.AssigneeID = assigneeID
+
+	if assigneeID == nil || *assigneeID == assignedBy {
+		return nil
+	}
+
+	if previous != nil && *previous == *assigneeID {
+		return nil
+	}
+
+	_ = s.eventService.Publish(events.IncidentAssignedEvent{
+		Incident:   incident,
+		AssigneeID: *assigneeID,
+		AssignedBy: assignedBy,
+	})
+
+	return nil
+}
+
+func (s *ServiceImpl) SetCommander(ctx context.Context, incident *entities.Incident, commanderID *uint) error {
+	if err := s.repository.UpdateCommander(ctx, incident.ID, commanderID); err != nil {
+		return err
+	}
+
+	incident.CommanderID = commanderID
+
+	return nil
+}
+
+// SetParent groups the incident under the parent incident, or ungroups it when
+// the parent is nil. Groups are a single level deep, so neither can the parent
+// be grouped nor can the incident have children of its own.
+func (s *ServiceImpl) SetParent(ctx context.Context, incident *entities.Incident, parent *entities.Incident) error {
+	var parentID *uint
+	if parent != nil {
+		if parent.ID == incident.ID || parent.TeamID != incident.TeamID || parent.ParentID != nil {
+			return ErrInvalidParent
+		}
+
+		children, err := s.repository.GetChildrenByIncidentID(ctx, incident.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(children) > 0 {
+			return ErrInvalidParent
+		}
+
+		parentID = &parent.ID
+	}
+
+	if err := s.repository.UpdateParent(ctx, incident.ID, parentID); err != nil {
+		return err
+	}
+
+	incident.ParentID = parentID
+
+	return nil
+}
+
+// Suppress records that no one is paged for the incident, and why
+func (s *ServiceImpl) Suppress(ctx context.Context, incident *entities.Incident, reason string) error {
+	if err := s.repository.UpdateSuppressedReason(ctx, incident.ID, &reason); err != nil {
+		return err
+	}
+
+	incident.SuppressedReason = &reason
+
+	return nil
+}
+
+// Unsuppress clears the suppression of the open incident, so that responders
+// are paged for it as if it was just opened
+func (s *ServiceImpl) Unsuppress(ctx context.Context, incident *entities.Incident) error {
+	if !incident.IsSuppressed() {
+		return nil
+	}
+
+	if err := s.repository.UpdateSuppressedReason(ctx, incident.ID, nil); err != nil {
+		return err
+	}
+
+	incident.SuppressedReason = nil
+
+	_ = s.eventService.Publish(events.IncidentUnsuppressedEvent{
+		Incident: incident,
+	})
+
+	return nil
+}
+
+func (s *ServiceImpl) GetOpenParents(ctx context.Context, teamID uint, since time.Time) ([]entities.Incident, error) {
+	return s.repository.GetOpenParentsByTeamID(ctx, teamID, since)
+}
+
+func (s *ServiceImpl) GetChildren(ctx context.Context, incidentID uint) ([]entities.Incident, error) {
+	return s.repository.GetChildrenByIncidentID(ctx, incidentID)
+}
+
+func (s *ServiceImpl) GetResponders(ctx context.Context, incidentID uint) ([]entities.IncidentResponder, error) {
+	return s.repository.GetRespondersByIncidentID(ctx, incidentID)
+}
+
+func (s *ServiceImpl) AddResponder(ctx context.Context, incidentID, userID uint) error {
+	return s.repository.AddResponder(ctx, &entities.IncidentResponder{
+		IncidentID: incidentID,
+		UserID:     userID,
+	})
+}
+
+func (s *ServiceImpl) RemoveResponder(ctx context.Context, incidentID, userID uint) error {
+	return s.repository.RemoveResponder(ctx, incidentID, userID)
+}
+
+// Titles and title prefixes of the incidents opened before incidents had a
+// kind, by which the legacy incidents without an assertion are told apart
+const (
+	legacyAnomalyTitle    = "Anomaly Detected"
+	legacyCertExpiryTitle = "SSL/TLS Cert Expiry"
+	legacyDatadogPrefix   = "Datadog Alert: "
+	legacyNewRelicPrefix  = "New Relic Alert: "
+)
+
+// MigrateLegacyFingerprints gives the open incidents of heartbeats, monitor
+// assertions, anomalies, expiring certificates and external alerts, which were
+// deduplicated by unique indexes or by their titles before, the kind and
+// fingerprint new incidents get, so they are refreshed and resolved like them.
+// When several legacy incidents share a fingerprint only the first gets it,
+// the others keep their kind. It returns the number of incidents migrated.
+func (s *ServiceImpl) MigrateLegacyFingerprints(ctx context.Context) (int, error) {
+	incidents, err := s.repository.GetOpenWithoutFingerprint(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	for i := range incidents {
+		incident := &incidents[i]
+
+		fingerprint, ok := classifyLegacyIncident(incident)
+		if !ok {
+			continue
+		}
+
+		_, err := s.repository.GetOpenByFingerprint(ctx, incident.TeamID, fingerprint)
+		if errors.Is(err, ErrNotFound) {
+			incident.Fingerprint = &fingerprint
+		} else if err != nil {
+			return migrated, err
+		}
+
+		if err := s.repository.UpdateFingerprint(ctx, incident); err != nil {
+			return migrated, err
+		}
+
+		migrated++
+	}
+
+	return migrated, nil
+}
+
+// classifyLegacyIncident sets the source and kind of an incident opened before
+// incidents had them, and returns its fingerprint. Incidents opened by hand are
+// not classified.
+func classifyLegacyIncident(incident *entities.Incident) (string, bool) {
+	switch {
+	case incident.HeartbeatID != nil:
+		incident.Source = entities.IncidentSourceHeartbeat
+		incident.Kind = entities.IncidentKindHeartbeat
+		return entities.HeartbeatIncidentFingerprint(*incident.HeartbeatID), true
+	case incident.MonitorAssertionID != nil:
+		incident.Kind = entities.IncidentKindAssertionFailure
+		return entities.AssertionIncidentFingerprint(*incident.MonitorAssertionID), true
+	case incident.MonitorID != nil && incident.Title == legacyAnomalyTitle:
+		incident.Source = entities.IncidentSourceMonitor
+		incident.Kind = entities.IncidentKindAnomaly
+		return entities.MonitorIncidentFingerprint(entities.IncidentKindAnomaly, *incident.MonitorID), true
+	case incident.MonitorID != nil && incident.Title == legacyCertExpiryTitle:
+		incident.Source = entities.IncidentSourceMonitor
+		incident.Kind = entities.IncidentKindCertExpiry
+		return entities.MonitorIncidentFingerprint(entities.IncidentKindCertExpiry, *incident.MonitorID), true
+	case incident.Source == entities.IncidentSourceDatadog || strings.HasPrefix(incident.Title, legacyDatadogPrefix):
+		incident.Source = entities.IncidentSourceDatadog
+		incident.Kind = entities.IncidentKindExternal
+		return entities.ExternalIncidentFingerprint(entities.IncidentSourceDatadog, strings.TrimPrefix(incident.Title, legacyDatadogPrefix)), true
+	case incident.Source == entities.IncidentSourceNewRelic || strings.HasPrefix(incident.Title, legacyNewRelicPrefix):
+		incident.Source = entities.IncidentSourceNewRelic
+		incident.Kind = entities.IncidentKindExternal
+		return entities.ExternalIncidentFingerprint(entities.IncidentSourceNewRelic, strings.TrimPrefix(incident.Title, legacyNewRelicPrefix)), true
+	default:
+		return "", false
+	}
+}
@@ -2,9 +2,12 @@ package incident_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/opsway-io/backend/internal/entities"
+	"github.com/opsway-io/backend/internal/event/events"
 	eventMocks "github.com/opsway-io/backend/internal/event/mocks"
 	"github.com/opsway-io/backend/internal/incident"
 	"github.com/opsway-io/backend/internal/incident/mocks"
@@ -70,3 +73,606 @@ func TestService_GetByID(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestService_Update(t *testing.T) {
+	mockRepo := mocks.NewRepository(t)
+	mockEventService := eventMocks.NewService(t)
+	svc := incident.NewService(mockRepo, mockEventService)
+
+	t.Run("publishes acknowledged and resolved events", func(t *testing.T) {
+		ctx := context.Background()
+		previous := &entities.Incident{ID: 1}
+		updated := &entities.Incident{ID: 1, Acknowledged: true, Resolved: true}
+
+		mockRepo.On("GetByID", ctx, uint(1)).Return(previous, nil).Once()
+		mockRepo.On("Update", ctx, updated).Return(nil).Once()
+		mockEventService.On("Publish", mock.AnythingOfType("events.IncidentAcknowledgedEvent")).Return(nil).Once()
+		mockEventService.On("Publish", mock.AnythingOfType("events.IncidentResolvedEvent")).Return(nil).Once()
+
+		err := svc.Update(ctx, updated)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.IncidentStatusResolved, updated.Status)
+		assert.NotNil(t, updated.ResolvedAt)
+		mockRepo.AssertExpectations(t)
+		mockEventService.AssertExpectations(t)
+	})
+
+	t.Run("does not publish without a state change", func(t *testing.T) {
+		ctx := context.Background()
+		previous := &entities.Incident{ID: 2, Acknowledged: true}
+		updated := &entities.Incident{ID: 2, Acknowledged: true, Title: "updated"}
+
+		mockRepo.On("GetByID", ctx, uint(2)).Return(previous, nil).Once()
+		mockRepo.On("Update", ctx, updated).Return(nil).Once()
+
+		err := svc.Update(ctx, updated)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestService_AddUpdate(t *testing.T) {
+	mockRepo := mocks.NewRepository(t)
+	mockEventService := eventMocks.NewService(t)
+	svc := incident.NewService(mockRepo, mockEventService)
+
+	transaction := func(ctx context.Context) {
+		mockRepo.On("Transaction", ctx, mock.Anything).Return(func(_ context.Context, fn func(incident.Repository) error) error {
+			return fn(mockRepo)
+		}).Once()
+	}
+
+	t.Run("moves the incident to the status of the update", func(t *testing.T) {
+		ctx := context.Background()
+		previous := &entities.Incident{ID: 1, Status: entities.IncidentStatusInvestigating}
+		in := &entities.Incident{ID: 1, Status: entities.IncidentStatusInvestigating}
+		update := &entities.IncidentUpdate{Status: entities.IncidentStatusIdentified, Message: "found it", Public: true}
+
+		transaction(ctx)
+		mockRepo.On("GetByID", ctx, uint(1)).Return(previous, nil).Once()
+		mockRepo.On("Update", ctx, in).Return(nil).Once()
+		mockRepo.On("CreateUpdate", ctx, update).Return(nil).Once()
+
+		err := svc.AddUpdate(ctx, in, update)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.IncidentStatusIdentified, in.Status)
+		assert.Equal(t, uint(1), update.IncidentID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("resolves the incident", func(t *testing.T) {
+		ctx := context.Background()
+		previous := &entities.Incident{ID: 2, Status: entities.IncidentStatusMonitoring}
+		in := &entities.Incident{ID: 2, Status: entities.IncidentStatusMonitoring}
+		update := &entities.IncidentUpdate{Status: entities.IncidentStatusResolved, Message: "fixed"}
+
+		transaction(ctx)
+		mockRepo.On("GetByID", ctx, uint(2)).Return(previous, nil).Once()
+		mockRepo.On("Update", ctx, in).Return(nil).Once()
+		mockRepo.On("CreateUpdate", ctx, update).Return(nil).Once()
+		mockEventService.On("Publish", mock.AnythingOfType("events.IncidentResolvedEvent")).Return(nil).Once()
+
+		err := svc.AddUpdate(ctx, in, update)
+
+		assert.NoError(t, err)
+		assert.True(t, in.Resolved)
+		mockRepo.AssertExpectations(t)
+		mockEventService.AssertExpectations(t)
+	})
+
+	t.Run("publishes nothing when the update is not recorded", func(t *testing.T) {
+		ctx := context.Background()
+		previous := &entities.Incident{ID: 5, Status: entities.IncidentStatusMonitoring}
+		in := &entities.Incident{ID: 5, Status: entities.IncidentStatusMonitoring}
+		update := &entities.IncidentUpdate{Status: entities.IncidentStatusResolved, Message: "fixed"}
+		createErr := errors.New("connection reset")
+
+		transaction(ctx)
+		mockRepo.On("GetByID", ctx, uint(5)).Return(previous, nil).Once()
+		mockRepo.On("Update", ctx, in).Return(nil).Once()
+		mockRepo.On("CreateUpdate", ctx, update).Return(createErr).Once()
+
+		err := svc.AddUpdate(ctx, in, update)
+
+		assert.ErrorIs(t, err, createErr)
+		mockRepo.AssertExpectations(t)
+		mockEventService.AssertExpectations(t)
+	})
+
+	t.Run("rejects invalid statuses", func(t *testing.T) {
+		err := svc.AddUpdate(context.Background(), &entities.Incident{ID: 3}, &entities.IncidentUpdate{Status: "FIXED"})
+
+		assert.ErrorIs(t, err, incident.ErrInvalidStatus)
+	})
+
+	t.Run("rejects updates of resolved incidents", func(t *testing.T) {
+		in := &entities.Incident{ID: 4, Resolved: true, Status: entities.IncidentStatusResolved}
+
+		err := svc.AddUpdate(context.Background(), in, &entities.IncidentUpdate{Status: entities.IncidentStatusMonitoring})
+
+		assert.ErrorIs(t, err, incident.ErrIncidentResolved)
+	})
+}
+
+func TestService_Assign(t *testing.T) {
+	mockRepo := mocks.NewRepository(t)
+	mockEventService := eventMocks.NewService(t)
+	svc := incident.NewService(mockRepo, mockEventService)
+
+	t.Run("notifies the new assignee", func(t *testing.T) {
+		ctx := context.Background()
+		in := &entities.Incident{ID: 1}
+		assignee := uint(5)
+
+		mockRepo.On("UpdateAssignee", ctx, uint(1), &assignee).Return(nil).Once()
+		mockEventService.On("Publish", mock.AnythingOfType("events.IncidentAssignedEvent")).Return(nil).Once()
+
+		err := svc.Assign(ctx, in, &assignee, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &assignee, in.AssigneeID)
+		mockRepo.AssertExpectations(t)
+		mockEventService.AssertExpectations(t)
+	})
+
+	t.Run("does not notify on self-assignment", func(t *testing.T) {
+		ctx := context.Background()
+		in := &entities.Incident{ID: 2}
+		assignee := uint(7)
+
+		mockRepo.On("UpdateAssignee", ctx, uint(2), &assignee).Return(nil).Once()
+
+		err := svc.Assign(ctx, in, &assignee, 7)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("does not notify when unassigning", func(t *testing.T) {
+		ctx := context.Background()
+		previous := uint(5)
+		in := &entities.Incident{ID: 3, AssigneeID: &previous}
+
+		mockRepo.On("UpdateAssignee", ctx, uint(3), (*uint)(nil)).Return(nil).Once()
+
+		err := svc.Assign(ctx, in, nil, 7)
+
+		assert.NoError(t, err)
+		assert.Nil(t, in.AssigneeID)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestService_SetParent(t *testing.T) {
+	mockRepo := mocks.NewRepository(t)
+	mockEventService := eventMocks.NewService(t)
+	svc := incident.NewService(mockRepo, mockEventService)
+
+	t.Run("groups the incident under the parent", func(t *testing.T) {
+		ctx := context.Background()
+		parent := &entities.Incident{ID: 1, TeamID: 1}
+		in := &entities.Incident{ID: 2, TeamID: 1}
+
+		mockRepo.On("GetChildrenByIncidentID", ctx, uint(2)).Return([]entities.Incident{}, nil).Once()
+		mockRepo.On("UpdateParent", ctx, uint(2), &parent.ID).Return(nil).Once()
+
+		err := svc.SetParent(ctx, in, parent)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &parent.ID, in.ParentID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects parents of other teams", func(t *testing.T) {
+		err := svc.SetParent(context.Background(), &entities.Incident{ID: 3, TeamID: 1}, &entities.Incident{ID: 1, TeamID: 2})
+
+		assert.ErrorIs(t, err, incident.ErrInvalidParent)
+	})
+
+	t.Run("rejects parents that are grouped themselves", func(t *testing.T) {
+		grandparent := uint(1)
+		parent := &entities.Incident{ID: 2, TeamID: 1, ParentID: &grandparent}
+
+		err := svc.SetParent(context.Background(), &entities.Incident{ID: 3, TeamID: 1}, parent)
+
+		assert.ErrorIs(t, err, incident.ErrInvalidParent)
+	})
+
+	t.Run("rejects incidents with children of their own", func(t *testing.T) {
+		ctx := context.Background()
+		parentID := uint(5)
+
+		mockRepo.On("GetChildrenByIncidentID", ctx, uint(5)).Return([]entities.Incident{{ID: 6, ParentID: &parentID}}, nil).Once()
+
+		err := svc.SetParent(ctx, &entities.Incident{ID: 5, TeamID: 1}, &entities.Incident{ID: 1, TeamID: 1})
+
+		assert.ErrorIs(t, err, incident.ErrInvalidParent)
+	})
+
+	t.Run("rejects the incident itself", func(t *testing.T) {
+		in := &entities.Incident{ID: 4, TeamID: 1}
+
+		err := svc.SetParent(context.Background(), in, in)
+
+		assert.ErrorIs(t, err, incident.ErrInvalidParent)
+	})
+}
+
+func TestService_Open(t *testing.T) {
+	mockRepo := mocks.NewRepository(t)
+	mockEventService := eventMocks.NewService(t)
+	svc := incident.NewService(mockRepo, mockEventService)
+
+	t.Run("returns the open incident with the same fingerprint", func(t *testing.T) {
+		ctx := context.Background()
+		fingerprint := entities.AssertionIncidentFingerprint(1)
+		open := &entities.Incident{ID: 1, TeamID: 1, Fingerprint: &fingerprint, Severity: entities.IncidentSeverityMajor}
+
+		mockRepo.On("GetOpenByFingerprint", ctx, uint(1), fingerprint).Return(open, nil).Once()
+
+		in, opened, err := svc.Open(ctx, &entities.Incident{TeamID: 1, Fingerprint: &fingerprint, Severity: entities.IncidentSeverityMinor})
+
+		assert.NoError(t, err)
+		assert.False(t, opened)
+		assert.Equal(t, open, in)
+		assert.Equal(t, entities.IncidentSeverityMajor, in.Severity)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("raises the severity of the open incident", func(t *testing.T) {
+		ctx := context.Background()
+		fingerprint := entities.AssertionIncidentFingerprint(2)
+		open := &entities.Incident{ID: 2, TeamID: 1, Fingerprint: &fingerprint, Severity: entities.IncidentSeverityMinor}
+
+		mockRepo.On("GetOpenByFingerprint", ctx, uint(1), fingerprint).Return(open, nil).Once()
+		mockRepo.On("Update", ctx, open).Return(nil).Once()
+
+		in, opened, err := svc.Open(ctx, &entities.Incident{TeamID: 1, Fingerprint: &fingerprint, Severity: entities.IncidentSeverityCritical})
+
+		assert.NoError(t, err)
+		assert.False(t, opened)
+		assert.Equal(t, entities.IncidentSeverityCritical, in.Severity)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("creates an incident when none is open", func(t *testing.T) {
+		ctx := context.Background()
+		fingerprint := entities.AssertionIncidentFingerprint(3)
+
+		mockRepo.On("GetOpenByFingerprint", ctx, uint(1), fingerprint).Return(nil, incident.ErrNotFound).Once()
+		mockRepo.On("GetLastResolvedByFingerprint", ctx, uint(1), fingerprint, mock.AnythingOfType("time.Time")).Return(nil, incident.ErrNotFound).Once()
+		mockRepo.On("Create", ctx, mock.AnythingOfType("*[]entities.Incident")).Return(nil).Once()
+		mockEventService.On("Publish", mock.AnythingOfType("events.IncidentCreatedEvent")).Return(nil).Once()
+
+		in, opened, err := svc.Open(ctx, &entities.Incident{TeamID: 1, Fingerprint: &fingerprint})
+
+		assert.NoError(t, err)
+		assert.True(t, opened)
+		assert.Equal(t, &fingerprint, in.Fingerprint)
+		mockRepo.AssertExpectations(t)
+		mockEventService.AssertExpectations(t)
+	})
+
+	t.Run("reopens an incident resolved within the reopen window", func(t *testing.T) {
+		ctx := context.Background()
+		fingerprint := entities.AssertionIncidentFingerprint(4)
+		resolved := &entities.Incident{ID: 4, TeamID: 1, Fingerprint: &fingerprint, Resolved: true, Status: entities.IncidentStatusResolved, Severity: entities.IncidentSeverityMajor}
+
+		mockRepo.On("GetOpenByFingerprint", ctx, uint(1), fingerprint).Return(nil, incident.ErrNotFound).Twice()
+		mockRepo.On("GetLastResolvedByFingerprint", ctx, uint(1), fingerprint, mock.AnythingOfType("time.Time")).Return(resolved, nil).Once()
+		mockRepo.On("Reopen", ctx, uint(4)).Return(nil).Once()
+		mockRepo.On("CreateUpdate", ctx, mock.AnythingOfType("*entities.IncidentUpdate")).Return(nil).Once()
+		mockEventService.On("Publish", mock.AnythingOfType("events.IncidentReopenedEvent")).Return(nil).Once()
+
+		in, opened, err := svc.Open(ctx, &entities.Incident{TeamID: 1, Fingerprint: &fingerprint, Severity: entities.IncidentSeverityMajor})
+
+		assert.NoError(t, err)
+		assert.True(t, opened)
+		assert.Equal(t, uint(4), in.ID)
+		assert.False(t, in.Resolved)
+		assert.Equal(t, entities.IncidentStatusInvestigating, in.Status)
+		mockRepo.AssertExpectations(t)
+		mockEventService.AssertExpectations(t)
+	})
+}
+
+func TestService_Reopen(t *testing.T) {
+	mockRepo := mocks.NewRepository(t)
+	mockEventService := eventMocks.NewService(t)
+	svc := incident.NewService(mockRepo, mockEventService)
+
+	t.Run("rejects unresolved incidents", func(t *testing.T) {
+		err := svc.Reopen(context.Background(), &entities.Incident{ID: 1, TeamID: 1}, nil, "")
+
+		assert.ErrorIs(t, err, incident.ErrIncidentNotResolved)
+	})
+
+	t.Run("rejects merged incidents", func(t *testing.T) {
+		target := uint(2)
+
+		err := svc.Reopen(context.Background(), &entities.Incident{ID: 1, TeamID: 1, Resolved: true, MergedIntoID: &target}, nil, "")
+
+		assert.ErrorIs(t, err, incident.ErrIncidentMerged)
+	})
+
+	t.Run("rejects incidents whose fingerprint is open again", func(t *testing.T) {
+		ctx := context.Background()
+		fingerprint := entities.HeartbeatIncidentFingerprint(1)
+
+		mockRepo.On("GetOpenByFingerprint", ctx, uint(1), fingerprint).Return(&entities.Incident{ID: 2}, nil).Once()
+
+		err := svc.Reopen(ctx, &entities.Incident{ID: 1, TeamID: 1, Resolved: true, Fingerprint: &fingerprint}, nil, "")
+
+		assert.ErrorIs(t, err, incident.ErrIncidentOpen)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestService_Unsuppress(t *testing.T) {
+	mockRepo := mocks.NewRepository(t)
+	mockEventService := eventMocks.NewService(t)
+	svc := incident.NewService(mockRepo, mockEventService)
+
+	t.Run("ignores incidents that are not suppressed", func(t *testing.T) {
+		err := svc.Unsuppress(context.Background(), &entities.Incident{ID: 1, TeamID: 1})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("clears the reason and publishes an event", func(t *testing.T) {
+		ctx := context.Background()
+		reason := entities.IncidentSuppressedUpstreamDown
+		inc := &entities.Incident{ID: 1, TeamID: 1, SuppressedReason: &reason}
+
+		mockRepo.On("UpdateSuppressedReason", ctx, uint(1), (*string)(nil)).Return(nil).Once()
+		mockEventService.On("Publish", mock.AnythingOfType("events.IncidentUnsuppressedEvent")).Return(nil).Once()
+
+		err := svc.Unsuppress(ctx, inc)
+
+		assert.NoError(t, err)
+		assert.Nil(t, inc.SuppressedReason)
+		mockRepo.AssertExpectations(t)
+		mockEventService.AssertExpectations(t)
+	})
+}
+
+func TestService_Merge(t *testing.T) {
+	mockRepo := mocks.NewRepository(t)
+	mockEventService := eventMocks.NewService(t)
+	svc := incident.NewService(mockRepo, mockEventService)
+
+	t.Run("merges the source into the target", func(t *testing.T) {
+		ctx := context.Background()
+		userID := uint(7)
+		source := &entities.Incident{ID: 1, TeamID: 1, Status: entities.IncidentStatusInvestigating, Severity: entities.IncidentSeverityMinor}
+		target := &entities.Incident{ID: 2, TeamID: 1, Status: entities.IncidentStatusInvestigating, Severity: entities.IncidentSeverityMajor}
+
+		mockRepo.On("Transaction", ctx, mock.Anything).Return(func(_ context.Context, fn func(incident.Repository) error) error {
+			return fn(mockRepo)
+		}).Once()
+		mockRepo.On("Merge", ctx, uint(1), uint(2), uint(2)).Return(nil).Once()
+		mockRepo.On("Update", ctx, source).Return(nil).Once()
+		mockEventService.On("Publish", mock.AnythingOfType("events.IncidentResolvedEvent")).Return(nil).Once()
+		mockRepo.On("CreateUpdate", ctx, mock.AnythingOfType("*entities.IncidentUpdate")).Return(nil).Twice()
+
+		err := svc.Merge(ctx, source, target, userID)
+
+		assert.NoError(t, err)
+		assert.True(t, source.Resolved)
+		assert.Equal(t, &target.ID, source.MergedIntoID)
+		mockRepo.AssertExpectations(t)
+		mockEventService.AssertExpectations(t)
+	})
+
+	t.Run("publishes nothing when the merge fails", func(t *testing.T) {
+		ctx := context.Background()
+		source := &entities.Incident{ID: 3, TeamID: 1, Acknowledged: true}
+		target := &entities.Incident{ID: 4, TeamID: 1}
+
+		mockRepo.On("Transaction", ctx, mock.Anything).Return(func(_ context.Context, fn func(incident.Repository) error) error {
+			return fn(mockRepo)
+		}).Once()
+		mockRepo.On("Merge", ctx, uint(3), uint(4), uint(4)).Return(incident.ErrNotFound).Once()
+
+		err := svc.Merge(ctx, source, target, 7)
+
+		assert.ErrorIs(t, err, incident.ErrNotFound)
+		mockRepo.AssertExpectations(t)
+		mockEventService.AssertExpectations(t)
+	})
+
+	t.Run("rejects incidents of other teams", func(t *testing.T) {
+		err := svc.Merge(context.Background(), &entities.Incident{ID: 1, TeamID: 1}, &entities.Incident{ID: 2, TeamID: 2}, 7)
+
+		assert.ErrorIs(t, err, incident.ErrInvalidMerge)
+	})
+
+	t.Run("rejects resolved targets", func(t *testing.T) {
+		err := svc.Merge(context.Background(), &entities.Incident{ID: 1, TeamID: 1}, &entities.Incident{ID: 2, TeamID: 1, Resolved: true}, 7)
+
+		assert.ErrorIs(t, err, incident.ErrIncidentResolved)
+	})
+}
+
+func TestService_Split(t *testing.T) {
+	mockRepo := mocks.NewRepository(t)
+	mockEventService := eventMocks.NewService(t)
+	svc := incident.NewService(mockRepo, mockEventService)
+
+	t.Run("rejects comments of other incidents", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockRepo.On("GetCommentsByIncidentID", ctx, uint(1)).Return([]entities.IncidentComment{{ID: 10, IncidentID: 1}}, nil).Once()
+
+		err := svc.Split(ctx, &entities.Incident{ID: 1, TeamID: 1}, &entities.Incident{Title: "split"}, []uint{10, 11}, 7)
+
+		assert.ErrorIs(t, err, incident.ErrCommentNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("splits off the incident", func(t *testing.T) {
+		ctx := context.Background()
+		in := &entities.Incident{ID: 2, TeamID: 1, Status: entities.IncidentStatusIdentified, Severity: entities.IncidentSeverityMajor}
+		split := &entities.Incident{Title: "split"}
+
+		mockRepo.On("GetCommentsByIncidentID", ctx, uint(2)).Return([]entities.IncidentComment{{ID: 10, IncidentID: 2}}, nil).Once()
+		mockRepo.On("Transaction", ctx, mock.Anything).Return(func(_ context.Context, fn func(incident.Repository) error) error {
+			return fn(mockRepo)
+		}).Once()
+		mockRepo.On("Create", ctx, mock.AnythingOfType("*[]entities.Incident")).Run(func(args mock.Arguments) {
+			(*args.Get(1).(*[]entities.Incident))[0].ID = 3
+		}).Return(nil).Once()
+		mockRepo.On("MoveComments", ctx, uint(2), uint(3), []uint{10}).Return(nil).Once()
+		mockRepo.On("CreateUpdate", ctx, mock.AnythingOfType("*entities.IncidentUpdate")).Return(nil).Twice()
+		mockEventService.On("Publish", mock.MatchedBy(func(ev events.IncidentCreatedEvent) bool {
+			return ev.Incident.ID == 3
+		})).Return(nil).Once()
+
+		err := svc.Split(ctx, in, split, []uint{10}, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(3), split.ID)
+		assert.Equal(t, &in.ID, split.SplitFromID)
+		mockRepo.AssertExpectations(t)
+		mockEventService.AssertExpectations(t)
+	})
+
+	t.Run("publishes nothing when the split fails", func(t *testing.T) {
+		ctx := context.Background()
+		in := &entities.Incident{ID: 4, TeamID: 1}
+		moveErr := errors.New("connection reset")
+
+		mockRepo.On("GetCommentsByIncidentID", ctx, uint(4)).Return([]entities.IncidentComment{{ID: 12, IncidentID: 4}}, nil).Once()
+		mockRepo.On("Transaction", ctx, mock.Anything).Return(func(_ context.Context, fn func(incident.Repository) error) error {
+			return fn(mockRepo)
+		}).Once()
+		mockRepo.On("Create", ctx, mock.AnythingOfType("*[]entities.Incident")).Return(nil).Once()
+		mockRepo.On("MoveComments", ctx, uint(4), uint(0), []uint{12}).Return(moveErr).Once()
+
+		err := svc.Split(ctx, in, &entities.Incident{Title: "split"}, []uint{12}, 7)
+
+		assert.ErrorIs(t, err, moveErr)
+		mockRepo.AssertExpectations(t)
+		mockEventService.AssertExpectations(t)
+	})
+}
+
+func TestService_GetAnalytics(t *testing.T) {
+	mockRepo := mocks.NewRepository(t)
+	mockEventService := eventMocks.NewService(t)
+	svc := incident.NewService(mockRepo, mockEventService)
+
+	end := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, -1, 0)
+
+	t.Run("returns the analytics of the time range", func(t *testing.T) {
+		ctx := context.Background()
+		mttr := 600.0
+		analytics := &entities.IncidentAnalytics{Start: start, End: end, Count: 2, Resolved: 2, MTTR: &mttr}
+
+		mockRepo.On("GetAnalyticsByTeamID", ctx, uint(1), incident.AnalyticsFilter{
+			Start:         start,
+			End:           end,
+			NoisyMonitors: 10,
+		}).Return(analytics, nil).Once()
+
+		res, err := svc.GetAnalytics(ctx, 1, incident.AnalyticsFilter{Start: start, End: end})
+
+		assert.NoError(t, err)
+		assert.Equal(t, analytics, res)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects ranges that end before they start", func(t *testing.T) {
+		_, err := svc.GetAnalytics(context.Background(), 1, incident.AnalyticsFilter{Start: end, End: start})
+
+		assert.ErrorIs(t, err, incident.ErrInvalidTimeRange)
+	})
+}
+
+func TestService_MigrateLegacyFingerprints(t *testing.T) {
+	mockRepo := mocks.NewRepository(t)
+	mockEventService := eventMocks.NewService(t)
+	svc := incident.NewService(mockRepo, mockEventService)
+
+	t.Run("fingerprints open heartbeat and assertion incidents", func(t *testing.T) {
+		ctx := context.Background()
+		heartbeatID := uint(3)
+		assertionID := uint(4)
+
+		mockRepo.On("GetOpenWithoutFingerprint", ctx).Return([]entities.Incident{
+			{ID: 1, TeamID: 1, HeartbeatID: &heartbeatID, Source: entities.IncidentSourceMonitor, Kind: entities.IncidentKindAssertionFailure},
+			{ID: 2, TeamID: 1, MonitorAssertionID: &assertionID, Source: entities.IncidentSourceMonitor},
+			{ID: 3, TeamID: 1, Source: entities.IncidentSourceMonitor, Title: "Database migration"},
+		}, nil).Once()
+		mockRepo.On("GetOpenByFingerprint", ctx, uint(1), mock.Anything).Return(nil, incident.ErrNotFound).Twice()
+		mockRepo.On("UpdateFingerprint", ctx, mock.MatchedBy(func(i *entities.Incident) bool {
+			return i.ID == 1 &&
+				i.Source == entities.IncidentSourceHeartbeat &&
+				i.Kind == entities.IncidentKindHeartbeat &&
+				*i.Fingerprint == entities.HeartbeatIncidentFingerprint(heartbeatID)
+		})).Return(nil).Once()
+		mockRepo.On("UpdateFingerprint", ctx, mock.MatchedBy(func(i *entities.Incident) bool {
+			return i.ID == 2 &&
+				i.Source == entities.IncidentSourceMonitor &&
+				i.Kind == entities.IncidentKindAssertionFailure &&
+				*i.Fingerprint == entities.AssertionIncidentFingerprint(assertionID)
+		})).Return(nil).Once()
+
+		migrated, err := svc.MigrateLegacyFingerprints(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, migrated)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("classifies legacy incidents by their titles", func(t *testing.T) {
+		ctx := context.Background()
+		monitorID := uint(5)
+
+		anomaly := entities.MonitorIncidentFingerprint(entities.IncidentKindAnomaly, monitorID)
+		certExpiry := entities.MonitorIncidentFingerprint(entities.IncidentKindCertExpiry, monitorID)
+		datadog := entities.ExternalIncidentFingerprint(entities.IncidentSourceDatadog, "CPU high")
+		newRelic := entities.ExternalIncidentFingerprint(entities.IncidentSourceNewRelic, "Error rate")
+
+		mockRepo.On("GetOpenWithoutFingerprint", ctx).Return([]entities.Incident{
+			{ID: 1, TeamID: 1, MonitorID: &monitorID, Title: "Anomaly Detected", Source: entities.IncidentSourceMonitor, Kind: entities.IncidentKindAssertionFailure},
+			{ID: 2, TeamID: 1, MonitorID: &monitorID, Title: "SSL/TLS Cert Expiry", Source: entities.IncidentSourceMonitor, Kind: entities.IncidentKindAssertionFailure},
+			{ID: 3, TeamID: 1, Title: "Datadog Alert: CPU high", Source: entities.IncidentSourceMonitor, Kind: entities.IncidentKindAssertionFailure},
+			{ID: 4, TeamID: 1, Title: "New Relic Alert: Error rate", Source: entities.IncidentSourceMonitor, Kind: entities.IncidentKindAssertionFailure},
+			{ID: 5, TeamID: 1, Title: "Datadog Alert: CPU high", Source: entities.IncidentSourceMonitor, Kind: entities.IncidentKindAssertionFailure},
+		}, nil).Once()
+
+		for _, fingerprint := range []string{anomaly, certExpiry, datadog, newRelic} {
+			mockRepo.On("GetOpenByFingerprint", ctx, uint(1), fingerprint).Return(nil, incident.ErrNotFound).Once()
+		}
+		// The first Datadog incident holds the fingerprint by then
+		mockRepo.On("GetOpenByFingerprint", ctx, uint(1), datadog).Return(&entities.Incident{ID: 3}, nil).Once()
+
+		expected := map[uint]struct {
+			source      entities.IncidentSource
+			kind        entities.IncidentKind
+			fingerprint *string
+		}{
+			1: {entities.IncidentSourceMonitor, entities.IncidentKindAnomaly, &anomaly},
+			2: {entities.IncidentSourceMonitor, entities.IncidentKindCertExpiry, &certExpiry},
+			3: {entities.IncidentSourceDatadog, entities.IncidentKindExternal, &datadog},
+			4: {entities.IncidentSourceNewRelic, entities.IncidentKindExternal, &newRelic},
+			5: {entities.IncidentSourceDatadog, entities.IncidentKindExternal, nil},
+		}
+
+		mockRepo.On("UpdateFingerprint", ctx, mock.MatchedBy(func(i *entities.Incident) bool {
+			e := expected[i.ID]
+			return i.Source == e.source && i.Kind == e.kind && assert.ObjectsAreEqual(e.fingerprint, i.Fingerprint)
+		})).Return(nil).Times(5)
+
+		migrated, err := svc.MigrateLegacyFingerprints(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, migrated)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -11,6 +11,7 @@ import (
 
 type Client interface {
 	GenerateRCA(ctx context.Context, prompt string) (string, error)
+	GeneratePostmortemSummary(ctx context.Context, prompt string) (string, error)
 }
 
 type clientImpl struct {
@@ -50,12 +51,20 @@ type ChatCompletionResponse struct {
 }
 
 func (c *clientImpl) GenerateRCA(ctx context.Context, prompt string) (string, error) {
+	return c.complete(ctx, "You are an expert DevOps AI assistant. Your task is to analyze the provided monitoring metrics and incident context to generate a short, concise Root Cause Analysis (RCA). Keep the RCA to 2-3 sentences max.", prompt)
+}
+
+func (c *clientImpl) GeneratePostmortemSummary(ctx context.Context, prompt string) (string, error) {
+	return c.complete(ctx, "You are an expert DevOps AI assistant. Your task is to write the summary of a blameless incident postmortem from the provided incident details and timeline. Describe what happened, the impact on customers and how it was resolved in a single short paragraph.", prompt)
+}
+
+func (c *clientImpl) complete(ctx context.Context, systemPrompt, prompt string) (string, error) {
 	reqBody := ChatCompletionRequest{
 		Model: c.model,
 		Messages: []Message{
 			{
 				Role:    "system",
-				Content: "You are an expert DevOps AI assistant. Your task is to analyze the provided monitoring metrics and incident context to generate a short, concise Root Cause Analysis (RCA). Keep the RCA to 2-3 sentences max.",
+				Content: systemPrompt,
 			},
 			{
 				Role:    "user",
@@ -0,0 +1,48 @@
+package monitor
+
+import "slices"
+
+// DependencyGraph maps monitors to the monitors they directly depend on
+type DependencyGraph map[uint][]uint
+
+// Upstream returns every monitor the monitor depends on, directly or through
+// other monitors.
+func (g DependencyGraph) Upstream(monitorID uint) []uint {
+	var upstream []uint
+
+	visited := map[uint]bool{monitorID: true}
+	queue := slices.Clone(g[monitorID])
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+
+		upstream = append(upstream, id)
+		queue = append(queue, g[id]...)
+	}
+
+	return upstream
+}
+
+// HasCycle reports whether replacing the dependencies of the monitor with the
+// given ones would make the monitor depend on itself.
+func (g DependencyGraph) HasCycle(monitorID uint, dependsOn []uint) bool {
+	next := make(DependencyGraph, len(g)+1)
+	for id, deps := range g {
+		next[id] = deps
+	}
+	next[monitorID] = dependsOn
+
+	for _, id := range dependsOn {
+		if id == monitorID || slices.Contains(next.Upstream(id), monitorID) {
+			return true
+		}
+	}
+
+	return false
+}
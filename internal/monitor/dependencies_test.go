@@ -0,0 +1,84 @@
+package monitor_test
+
+import (
+	"testing"
+
+	"github.com/opsway-io/backend/internal/monitor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDependencyGraph_Upstream(t *testing.T) {
+	t.Parallel()
+
+	// 1 (api) depends on 2 (auth) and 3 (db), 2 depends on 3
+	graph := monitor.DependencyGraph{
+		1: {2, 3},
+		2: {3},
+	}
+
+	assert.ElementsMatch(t, []uint{2, 3}, graph.Upstream(1))
+	assert.ElementsMatch(t, []uint{3}, graph.Upstream(2))
+	assert.Empty(t, graph.Upstream(3))
+	assert.Empty(t, graph.Upstream(4))
+}
+
+func TestDependencyGraph_HasCycle(t *testing.T) {
+	t.Parallel()
+
+	graph := monitor.DependencyGraph{
+		1: {2},
+		2: {3},
+	}
+
+	tests := []struct {
+		name      string
+		monitorID uint
+		dependsOn []uint
+		want      bool
+	}{
+		{
+			name:      "Depending on itself",
+			monitorID: 4,
+			dependsOn: []uint{4},
+			want:      true,
+		},
+		{
+			name:      "Depending on a direct dependent",
+			monitorID: 2,
+			dependsOn: []uint{1},
+			want:      true,
+		},
+		{
+			name:      "Depending on an indirect dependent",
+			monitorID: 3,
+			dependsOn: []uint{1},
+			want:      true,
+		},
+		{
+			name:      "Depending on a shared upstream monitor",
+			monitorID: 1,
+			dependsOn: []uint{2, 3},
+			want:      false,
+		},
+		{
+			name:      "Replacing dependencies removes the old edges",
+			monitorID: 2,
+			dependsOn: []uint{},
+			want:      false,
+		},
+		{
+			name:      "Depending on an unrelated monitor",
+			monitorID: 3,
+			dependsOn: []uint{4},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, graph.HasCycle(tt.monitorID, tt.dependsOn))
+		})
+	}
+}
@@ -80,6 +80,66 @@ func (_m *Repository) Delete(ctx context.Context, teamID uint, monitorID uint) e
 	return r0
 }
 
+// GetAssertionsBySourceAndMethods provides a mock function with given fields: ctx, source, methods
+func (_m *Repository) GetAssertionsBySourceAndMethods(ctx context.Context, source string, methods []string) ([]entities.MonitorAssertion, error) {
+	ret := _m.Called(ctx, source, methods)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssertionsBySourceAndMethods")
+	}
+
+	var r0 []entities.MonitorAssertion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) ([]entities.MonitorAssertion, error)); ok {
+		return rf(ctx, source, methods)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []entities.MonitorAssertion); ok {
+		r0 = rf(ctx, source, methods)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.MonitorAssertion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, source, methods)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDependencyGraphByTeamID provides a mock function with given fields: ctx, teamID
+func (_m *Repository) GetDependencyGraphByTeamID(ctx context.Context, teamID uint) (monitor.DependencyGraph, error) {
+	ret := _m.Called(ctx, teamID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDependencyGraphByTeamID")
+	}
+
+	var r0 monitor.DependencyGraph
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (monitor.DependencyGraph, error)); ok {
+		return rf(ctx, teamID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) monitor.DependencyGraph); ok {
+		r0 = rf(ctx, teamID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(monitor.DependencyGraph)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMonitorAndSettingsByTeamIDAndID provides a mock function with given fields: ctx, teamID, monitorID
 func (_m *Repository) GetMonitorAndSettingsByTeamIDAndID(ctx context.Context, teamID uint, monitorID uint) (*entities.Monitor, error) {
 	ret := _m.Called(ctx, teamID, monitorID)
@@ -170,6 +230,36 @@ func (_m *Repository) GetMonitorsAndIncidentsByTeamID(ctx context.Context, teamI
 	return r0, r1
 }
 
+// GetMonitorsAndSettingsByMethods provides a mock function with given fields: ctx, methods
+func (_m *Repository) GetMonitorsAndSettingsByMethods(ctx context.Context, methods []string) ([]entities.Monitor, error) {
+	ret := _m.Called(ctx, methods)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMonitorsAndSettingsByMethods")
+	}
+
+	var r0 []entities.Monitor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]entities.Monitor, error)); ok {
+		return rf(ctx, methods)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []entities.Monitor); ok {
+		r0 = rf(ctx, methods)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Monitor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, methods)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMonitorsAndSettingsByTeamID provides a mock function with given fields: ctx, teamID, offset, limit, query
 func (_m *Repository) GetMonitorsAndSettingsByTeamID(ctx context.Context, teamID uint, offset *int, limit *int, query *string) (*[]monitor.MonitorWithTotalCount, error) {
 	ret := _m.Called(ctx, teamID, offset, limit, query)
@@ -230,6 +320,60 @@ func (_m *Repository) GetMonitorsByStates(ctx context.Context, states []entities
 	return r0, r1
 }
 
+// LockDependencyGraphByTeamID provides a mock function with given fields: ctx, teamID
+func (_m *Repository) LockDependencyGraphByTeamID(ctx context.Context, teamID uint) error {
+	ret := _m.Called(ctx, teamID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockDependencyGraphByTeamID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, teamID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetDependencies provides a mock function with given fields: ctx, monitorID, dependsOnIDs
+func (_m *Repository) SetDependencies(ctx context.Context, monitorID uint, dependsOnIDs []uint) error {
+	ret := _m.Called(ctx, monitorID, dependsOnIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDependencies")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []uint) error); ok {
+		r0 = rf(ctx, monitorID, dependsOnIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetFlapping provides a mock function with given fields: ctx, monitorID, flapping
+func (_m *Repository) SetFlapping(ctx context.Context, monitorID uint, flapping bool) error {
+	ret := _m.Called(ctx, monitorID, flapping)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFlapping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, bool) error); ok {
+		r0 = rf(ctx, monitorID, flapping)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetState provides a mock function with given fields: ctx, teamID, monitorID, state
 func (_m *Repository) SetState(ctx context.Context, teamID uint, monitorID uint, state entities.MonitorState) error {
 	ret := _m.Called(ctx, teamID, monitorID, state)
@@ -248,6 +392,24 @@ func (_m *Repository) SetState(ctx context.Context, teamID uint, monitorID uint,
 	return r0
 }
 
+// Transaction provides a mock function with given fields: ctx, fn
+func (_m *Repository) Transaction(ctx context.Context, fn func(monitor.Repository) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(monitor.Repository) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, teamID, monitorID, _a3
 func (_m *Repository) Update(ctx context.Context, teamID uint, monitorID uint, _a3 *entities.Monitor) error {
 	ret := _m.Called(ctx, teamID, monitorID, _a3)
@@ -266,6 +428,42 @@ func (_m *Repository) Update(ctx context.Context, teamID uint, monitorID uint, _
 	return r0
 }
 
+// UpdateAssertion provides a mock function with given fields: ctx, assertion
+func (_m *Repository) UpdateAssertion(ctx context.Context, assertion *entities.MonitorAssertion) error {
+	ret := _m.Called(ctx, assertion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAssertion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.MonitorAssertion) error); ok {
+		r0 = rf(ctx, assertion)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateConnectionString provides a mock function with given fields: ctx, settings
+func (_m *Repository) UpdateConnectionString(ctx context.Context, settings *entities.MonitorSettings) error {
+	ret := _m.Called(ctx, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateConnectionString")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.MonitorSettings) error); ok {
+		r0 = rf(ctx, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepository(t interface {
@@ -80,6 +80,36 @@ func (_m *Service) Delete(ctx context.Context, teamID uint, monitorID uint) erro
 	return r0
 }
 
+// GetDependencies provides a mock function with given fields: ctx, teamID, monitorID
+func (_m *Service) GetDependencies(ctx context.Context, teamID uint, monitorID uint) ([]uint, error) {
+	ret := _m.Called(ctx, teamID, monitorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDependencies")
+	}
+
+	var r0 []uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) ([]uint, error)); ok {
+		return rf(ctx, teamID, monitorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []uint); ok {
+		r0 = rf(ctx, teamID, monitorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, teamID, monitorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMonitorAndSettingsByTeamIDAndID provides a mock function with given fields: ctx, teamID, monitorID
 func (_m *Service) GetMonitorAndSettingsByTeamIDAndID(ctx context.Context, teamID uint, monitorID uint) (*entities.Monitor, error) {
 	ret := _m.Called(ctx, teamID, monitorID)
@@ -230,6 +260,156 @@ func (_m *Service) GetMonitorsByStates(ctx context.Context, states []entities.Mo
 	return r0, r1
 }
 
+// GetProbeTarget provides a mock function with given fields: _a0
+func (_m *Service) GetProbeTarget(_a0 *entities.Monitor) (string, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProbeTarget")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*entities.Monitor) (string, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*entities.Monitor) string); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*entities.Monitor) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUpstreamIDs provides a mock function with given fields: ctx, teamID, monitorID
+func (_m *Service) GetUpstreamIDs(ctx context.Context, teamID uint, monitorID uint) ([]uint, error) {
+	ret := _m.Called(ctx, teamID, monitorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUpstreamIDs")
+	}
+
+	var r0 []uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) ([]uint, error)); ok {
+		return rf(ctx, teamID, monitorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []uint); ok {
+		r0 = rf(ctx, teamID, monitorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, teamID, monitorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MigrateConnectionStrings provides a mock function with given fields: ctx
+func (_m *Service) MigrateConnectionStrings(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MigrateConnectionStrings")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MigrateLegacyAssertions provides a mock function with given fields: ctx
+func (_m *Service) MigrateLegacyAssertions(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MigrateLegacyAssertions")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetDependencies provides a mock function with given fields: ctx, teamID, monitorID, dependsOnIDs
+func (_m *Service) SetDependencies(ctx context.Context, teamID uint, monitorID uint, dependsOnIDs []uint) error {
+	ret := _m.Called(ctx, teamID, monitorID, dependsOnIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDependencies")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, []uint) error); ok {
+		r0 = rf(ctx, teamID, monitorID, dependsOnIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetFlapping provides a mock function with given fields: ctx, monitorID, flapping
+func (_m *Service) SetFlapping(ctx context.Context, monitorID uint, flapping bool) error {
+	ret := _m.Called(ctx, monitorID, flapping)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFlapping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, bool) error); ok {
+		r0 = rf(ctx, monitorID, flapping)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetState provides a mock function with given fields: ctx, teamID, monitorID, state
 func (_m *Service) SetState(ctx context.Context, teamID uint, monitorID uint, state entities.MonitorState) error {
 	ret := _m.Called(ctx, teamID, monitorID, state)
@@ -3,25 +3,36 @@ package monitor
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/opsway-io/backend/internal/connectors/postgres"
 	"github.com/opsway-io/backend/internal/entities"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var ErrNotFound = errors.New("monitor not found")
 
 type Repository interface {
+	Transaction(ctx context.Context, fn func(repository Repository) error) error
 	GetMonitorAndSettingsByTeamIDAndID(ctx context.Context, teamID uint, monitorID uint) (*entities.Monitor, error)
 	CountByTeamID(ctx context.Context, teamID uint) (int64, error)
 	GetMonitorsAndSettingsByTeamID(ctx context.Context, teamID uint, offset *int, limit *int, query *string) (*[]MonitorWithTotalCount, error)
 	GetMonitorsByStates(ctx context.Context, states []entities.MonitorState) (*[]entities.Monitor, error)
 	GetMonitorsAndIncidentsByTeamID(ctx context.Context, teamID uint) (*[]entities.Monitor, error)
 	GetMonitorAssertionByID(ctx context.Context, monitorAssertionID uint) (*entities.MonitorAssertion, error)
+	GetAssertionsBySourceAndMethods(ctx context.Context, source string, methods []string) ([]entities.MonitorAssertion, error)
+	UpdateAssertion(ctx context.Context, assertion *entities.MonitorAssertion) error
+	GetMonitorsAndSettingsByMethods(ctx context.Context, methods []string) ([]entities.Monitor, error)
+	UpdateConnectionString(ctx context.Context, settings *entities.MonitorSettings) error
 	SetState(ctx context.Context, teamID, monitorID uint, state entities.MonitorState) error
 	Create(ctx context.Context, monitor *entities.Monitor) error
 	Update(ctx context.Context, teamID, monitorID uint, monitor *entities.Monitor) error
 	Delete(ctx context.Context, teamID, monitorID uint) error
+	LockDependencyGraphByTeamID(ctx context.Context, teamID uint) error
+	GetDependencyGraphByTeamID(ctx context.Context, teamID uint) (DependencyGraph, error)
+	SetDependencies(ctx context.Context, monitorID uint, dependsOnIDs []uint) error
+	SetFlapping(ctx context.Context, monitorID uint, flapping bool) error
 }
 
 type RepositoryImpl struct {
@@ -34,6 +45,14 @@ func NewRepository(db *gorm.DB) Repository {
 	}
 }
 
+// Transaction runs fn with a repository whose changes are committed together,
+// or rolled back if fn returns an error
+func (r *RepositoryImpl) Transaction(ctx context.Context, fn func(repository Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&RepositoryImpl{db: tx})
+	})
+}
+
 func (r *RepositoryImpl) GetMonitorAndSettingsByTeamIDAndID(ctx context.Context, teamID uint, monitorID uint) (*entities.Monitor, error) {
 	var monitor entities.Monitor
 	err := r.db.WithContext(
@@ -131,6 +150,54 @@ func (r *RepositoryImpl) GetMonitorAssertionByID(ctx context.Context, monitorAss
 	return &monitorAssertion, err
 }
 
+func (r *RepositoryImpl) GetAssertionsBySourceAndMethods(ctx context.Context, source string, methods []string) ([]entities.MonitorAssertion, error) {
+	var assertions []entities.MonitorAssertion
+	err := r.db.WithContext(
+		ctx,
+	).Joins(
+		"JOIN monitor_settings ON monitor_settings.monitor_id = monitor_assertions.monitor_id",
+	).Where(
+		"monitor_assertions.source = ? AND monitor_settings.method IN ?", source, methods,
+	).Find(&assertions).Error
+
+	return assertions, err
+}
+
+func (r *RepositoryImpl) UpdateAssertion(ctx context.Context, assertion *entities.MonitorAssertion) error {
+	return r.db.WithContext(
+		ctx,
+	).Model(
+		assertion,
+	).Select(
+		"source", "property", "operator", "target",
+	).Updates(assertion).Error
+}
+
+func (r *RepositoryImpl) GetMonitorsAndSettingsByMethods(ctx context.Context, methods []string) ([]entities.Monitor, error) {
+	var monitors []entities.Monitor
+	err := r.db.WithContext(
+		ctx,
+	).Preload(
+		"Settings",
+	).Joins(
+		"JOIN monitor_settings ON monitor_settings.monitor_id = monitors.id",
+	).Where(
+		"monitor_settings.method IN ?", methods,
+	).Find(&monitors).Error
+
+	return monitors, err
+}
+
+func (r *RepositoryImpl) UpdateConnectionString(ctx context.Context, settings *entities.MonitorSettings) error {
+	return r.db.WithContext(
+		ctx,
+	).Model(
+		settings,
+	).Select(
+		"url", "database_connection_string",
+	).Updates(settings).Error
+}
+
 func (r *RepositoryImpl) SetState(ctx context.Context, teamID, monitorID uint, state entities.MonitorState) error {
 	err := r.db.WithContext(ctx).Model(
 		&entities.Monitor{},
@@ -158,21 +225,22 @@ func (r *RepositoryImpl) Update(ctx context.Context, teamID, monitorID uint, m *
 	).Where(entities.Monitor{
 		ID:     monitorID,
 		TeamID: teamID,
-	}).Updates(entities.Monitor{
+	}).Select("Name", "State", "Tags").Updates(entities.Monitor{
 		Name:  m.Name,
 		State: m.State,
+		Tags:  m.Tags,
 	}).Error; err != nil {
 		tx.Rollback()
 
 		return err
 	}
 
-	// Update monitor settings
+	// Update monitor settings, including zero values such as a disabled window
 	if err := tx.Model(
 		&entities.MonitorSettings{},
 	).Where(entities.MonitorSettings{
 		MonitorID: monitorID,
-	}).Updates(m.Settings).Error; err != nil {
+	}).Select("*").Omit("ID", "MonitorID").Updates(m.Settings).Error; err != nil {
 		tx.Rollback()
 
 		return err
@@ -211,3 +279,80 @@ func (r *RepositoryImpl) Delete(ctx context.Context, teamID, monitorID uint) err
 
 	return err
 }
+
+// LockDependencyGraphByTeamID locks the dependency graph of the team until the
+// transaction ends. The monitors of the team are locked, as a new dependency
+// has no row that could be locked yet.
+func (r *RepositoryImpl) LockDependencyGraphByTeamID(ctx context.Context, teamID uint) error {
+	var ids []uint
+	return r.db.WithContext(ctx).Model(
+		&entities.Monitor{},
+	).Clauses(
+		clause.Locking{Strength: "UPDATE"},
+	).Where(
+		"team_id = ?", teamID,
+	).Order(
+		"id asc",
+	).Pluck("id", &ids).Error
+}
+
+func (r *RepositoryImpl) GetDependencyGraphByTeamID(ctx context.Context, teamID uint) (DependencyGraph, error) {
+	var dependencies []entities.MonitorDependency
+	if err := r.db.WithContext(
+		ctx,
+	).Joins(
+		"INNER JOIN monitors as m ON m.id = monitor_dependencies.monitor_id",
+	).Where(
+		"m.team_id = ?", teamID,
+	).Order(
+		"monitor_dependencies.depends_on_id asc",
+	).Find(&dependencies).Error; err != nil {
+		return nil, err
+	}
+
+	graph := DependencyGraph{}
+	for _, d := range dependencies {
+		graph[d.MonitorID] = append(graph[d.MonitorID], d.DependsOnID)
+	}
+
+	return graph, nil
+}
+
+func (r *RepositoryImpl) SetDependencies(ctx context.Context, monitorID uint, dependsOnIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entities.MonitorDependency{}, "monitor_id = ?", monitorID).Error; err != nil {
+			return err
+		}
+
+		if len(dependsOnIDs) == 0 {
+			return nil
+		}
+
+		dependencies := make([]entities.MonitorDependency, len(dependsOnIDs))
+		for i, id := range dependsOnIDs {
+			dependencies[i] = entities.MonitorDependency{
+				MonitorID:   monitorID,
+				DependsOnID: id,
+			}
+		}
+
+		return tx.Create(&dependencies).Error
+	})
+}
+
+func (r *RepositoryImpl) SetFlapping(ctx context.Context, monitorID uint, flapping bool) error {
+	var since *time.Time
+	if flapping {
+		now := time.Now()
+		since = &now
+	}
+
+	return r.db.WithContext(ctx).Model(
+		&entities.Monitor{},
+	).Where(entities.Monitor{
+		ID: monitorID,
+	}).Updates(map[string]any{
+		"flapping":       flapping,
+		"flapping_since": since,
+	}).Error
+}
@@ -35,10 +35,7 @@ func (s *ScheduleImpl) Add(ctx context.Context, monitor *entities.Monitor) error
 		return err
 	}
 
-	locations := monitor.Settings.Locations
-	if len(locations) == 0 {
-		locations = []string{"global"}
-	}
+	locations := monitor.Settings.GetLocations()
 
 	for _, loc := range locations {
 		t := boomerang.NewTask(fmt.Sprintf("%s:%s", taskKind, loc), fmt.Sprintf("%d", monitor.ID), data)
@@ -51,10 +48,7 @@ func (s *ScheduleImpl) Add(ctx context.Context, monitor *entities.Monitor) error
 }
 
 func (s *ScheduleImpl) Remove(ctx context.Context, monitor *entities.Monitor) error {
-	locations := monitor.Settings.Locations
-	if len(locations) == 0 {
-		locations = []string{"global"}
-	}
+	locations := monitor.Settings.GetLocations()
 
 	for _, loc := range locations {
 		if err := s.bschedule.Remove(ctx, fmt.Sprintf("%s:%s", taskKind, loc), fmt.Sprintf("%d", monitor.ID)); err != nil {
@@ -3,13 +3,28 @@ package monitor
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/opsway-io/backend/internal/entities"
+	"github.com/opsway-io/backend/internal/probes"
+	"github.com/opsway-io/backend/internal/probes/http/asserter"
+	probeRedis "github.com/opsway-io/backend/internal/probes/redis"
+	"github.com/opsway-io/backend/internal/secret"
 	"github.com/opsway-io/boomerang"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
+var (
+	ErrInvalidDependency = errors.New("invalid monitor dependency")
+	ErrDependencyCycle   = errors.New("monitor dependencies form a cycle")
+	ErrInvalidQuery      = errors.New("invalid monitor query")
+)
+
 type Service interface {
 	GetMonitorAndSettingsByTeamIDAndID(ctx context.Context, teamID uint, monitorID uint) (*entities.Monitor, error)
 	CountByTeamID(ctx context.Context, teamID uint) (int64, error)
@@ -21,25 +36,35 @@ type Service interface {
 	Create(ctx context.Context, monitor *entities.Monitor) error
 	Update(ctx context.Context, teamID, monitorID uint, monitor *entities.Monitor) error
 	Delete(ctx context.Context, teamID, monitorID uint) error
+	GetDependencies(ctx context.Context, teamID, monitorID uint) ([]uint, error)
+	SetDependencies(ctx context.Context, teamID, monitorID uint, dependsOnIDs []uint) error
+	GetUpstreamIDs(ctx context.Context, teamID, monitorID uint) ([]uint, error)
+	SetFlapping(ctx context.Context, monitorID uint, flapping bool) error
+	MigrateLegacyAssertions(ctx context.Context) (int, error)
+	MigrateConnectionStrings(ctx context.Context) (int, error)
+	GetProbeTarget(monitor *entities.Monitor) (string, error)
 }
 
 type ServiceImpl struct {
 	repository Repository
 	schedule   Schedule
+	secrets    secret.Service
 }
 
-func NewService(db *gorm.DB, redisClient *redis.Client) Service {
+func NewService(db *gorm.DB, redisClient *redis.Client, secrets secret.Service) Service {
 	return &ServiceImpl{
 		repository: NewRepository(db),
 		schedule:   NewSchedule(redisClient),
+		secrets:    secrets,
 	}
 }
 
 // NewServiceWithDeps is primarily used for testing
-func NewServiceWithDeps(repo Repository, schedule Schedule) Service {
+func NewServiceWithDeps(repo Repository, schedule Schedule, secrets secret.Service) Service {
 	return &ServiceImpl{
 		repository: repo,
 		schedule:   schedule,
+		secrets:    secrets,
 	}
 }
 
@@ -97,6 +122,14 @@ func (s *ServiceImpl) SetState(ctx context.Context, teamID, monitorID uint, stat
 func (s *ServiceImpl) Create(ctx context.Context, m *entities.Monitor) error {
 	m.State = entities.MonitorStateActive
 
+	if err := validateQuery(m); err != nil {
+		return err
+	}
+
+	if err := s.sealConnectionString(m, nil); err != nil {
+		return err
+	}
+
 	err := s.repository.Create(ctx, m)
 	if err != nil {
 		return err
@@ -108,7 +141,18 @@ func (s *ServiceImpl) Create(ctx context.Context, m *entities.Monitor) error {
 func (s *ServiceImpl) Update(ctx context.Context, teamID, monitorID uint, m *entities.Monitor) error {
 	m.ID = monitorID
 
+	if err := validateQuery(m); err != nil {
+		return err
+	}
+
 	oldMonitor, err := s.repository.GetMonitorAndSettingsByTeamIDAndID(ctx, teamID, monitorID)
+
+	// Sealed before the monitor is unscheduled, so a monitor that cannot be
+	// saved keeps running
+	if sealErr := s.sealConnectionString(m, oldMonitor); sealErr != nil {
+		return sealErr
+	}
+
 	if err == nil {
 		err = s.schedule.Remove(ctx, oldMonitor)
 		if err != nil {
@@ -151,3 +195,308 @@ func (s *ServiceImpl) Delete(ctx context.Context, teamID, monitorID uint) error
 
 	return nil
 }
+
+func (s *ServiceImpl) GetDependencies(ctx context.Context, teamID, monitorID uint) ([]uint, error) {
+	graph, err := s.repository.GetDependencyGraphByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return graph[monitorID], nil
+}
+
+// SetDependencies replaces the monitors the monitor depends on. The monitors
+// must belong to the same team and may not depend on each other in a cycle.
+func (s *ServiceImpl) SetDependencies(ctx context.Context, teamID, monitorID uint, dependsOnIDs []uint) error {
+	if _, err := s.repository.GetMonitorAndSettingsByTeamIDAndID(ctx, teamID, monitorID); err != nil {
+		return err
+	}
+
+	slices.Sort(dependsOnIDs)
+	dependsOnIDs = slices.Compact(dependsOnIDs)
+
+	for _, id := range dependsOnIDs {
+		if _, err := s.repository.GetMonitorAndSettingsByTeamIDAndID(ctx, teamID, id); err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return ErrInvalidDependency
+			}
+
+			return err
+		}
+	}
+
+	// The graph is checked and written under a lock, so concurrent changes
+	// cannot add up to a cycle
+	return s.repository.Transaction(ctx, func(repository Repository) error {
+		if err := repository.LockDependencyGraphByTeamID(ctx, teamID); err != nil {
+			return err
+		}
+
+		graph, err := repository.GetDependencyGraphByTeamID(ctx, teamID)
+		if err != nil {
+			return err
+		}
+
+		if graph.HasCycle(monitorID, dependsOnIDs) {
+			return ErrDependencyCycle
+		}
+
+		return repository.SetDependencies(ctx, monitorID, dependsOnIDs)
+	})
+}
+
+// GetUpstreamIDs returns every monitor the monitor depends on, directly or
+// through other monitors.
+func (s *ServiceImpl) GetUpstreamIDs(ctx context.Context, teamID, monitorID uint) ([]uint, error) {
+	graph, err := s.repository.GetDependencyGraphByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return graph.Upstream(monitorID), nil
+}
+
+func (s *ServiceImpl) SetFlapping(ctx context.Context, monitorID uint, flapping bool) error {
+	return s.repository.SetFlapping(ctx, monitorID, flapping)
+}
+
+// Methods of the monitors whose probes do not speak HTTP. Their probes used to
+// report a status code of 200 on success and 503 on failure.
+var nonHTTPMethods = []string{"TCP", "ICMP", "DNS", "POSTGRES", "MYSQL", "REDIS", "BROWSER"}
+
+// MigrateLegacyAssertions replaces the status code assertions of the monitors
+// whose probes do not speak HTTP with assertions on whether the probe succeeded
+// or failed, keeping what they asserted on the status codes their probes used
+// to report. It returns the number of assertions replaced.
+func (s *ServiceImpl) MigrateLegacyAssertions(ctx context.Context) (int, error) {
+	assertions, err := s.repository.GetAssertionsBySourceAndMethods(ctx, "STATUS_CODE", nonHTTPMethods)
+	if err != nil {
+		return 0, err
+	}
+
+	a := asserter.NewStatusCodeAsserter()
+	succeeded := &probes.Result{HTTP: &probes.HTTPResponse{StatusCode: 200}}
+	failed := &probes.Result{HTTP: &probes.HTTPResponse{StatusCode: 503}}
+
+	migrated := 0
+	for i := range assertions {
+		assertion := &assertions[i]
+
+		rule := []asserter.Rule{{
+			Source:   assertion.Source,
+			Property: assertion.Property,
+			Operator: assertion.Operator,
+			Target:   assertion.Target,
+		}}
+
+		onSuccess, err := a.Assert(succeeded, rule)
+		if err != nil {
+			continue
+		}
+
+		onFailure, err := a.Assert(failed, rule)
+		if err != nil {
+			continue
+		}
+
+		// Assertions that passed or failed regardless of the outcome are
+		// left alone, they meant nothing before either
+		switch {
+		case onSuccess[0] && !onFailure[0]:
+			assertion.Operator = "SUCCEEDED"
+		case !onSuccess[0] && onFailure[0]:
+			assertion.Operator = "FAILED"
+		default:
+			continue
+		}
+
+		assertion.Source = "PROBE"
+		assertion.Property = ""
+		assertion.Target = ""
+
+		if err := s.repository.UpdateAssertion(ctx, assertion); err != nil {
+			return migrated, err
+		}
+
+		migrated++
+	}
+
+	return migrated, nil
+}
+
+// Methods of the monitors whose URL is a connection string, which holds the
+// credentials of the database
+var databaseMethods = []string{"POSTGRES", "MYSQL", "REDIS"}
+
+// GetProbeTarget returns what the prober connects to, the decrypted connection
+// string of database monitors and the URL of the others
+func (s *ServiceImpl) GetProbeTarget(m *entities.Monitor) (string, error) {
+	if m.Settings.Database.ConnectionString == "" {
+		return m.Settings.URL, nil
+	}
+
+	return s.secrets.Decrypt(m.Settings.Database.ConnectionString)
+}
+
+// MigrateConnectionStrings encrypts the connection strings of the database
+// monitors created before they were, and redacts their URL. It returns the
+// number of monitors migrated.
+func (s *ServiceImpl) MigrateConnectionStrings(ctx context.Context) (int, error) {
+	monitors, err := s.repository.GetMonitorsAndSettingsByMethods(ctx, databaseMethods)
+	if err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	for i := range monitors {
+		m := &monitors[i]
+
+		if m.Settings.Database.ConnectionString != "" {
+			continue
+		}
+
+		if err := s.sealConnectionString(m, nil); err != nil {
+			return migrated, err
+		}
+
+		if err := s.repository.UpdateConnectionString(ctx, &m.Settings); err != nil {
+			return migrated, err
+		}
+
+		// The scheduled task holds a copy of the monitor with the plain URL
+		if m.State != entities.MonitorStateInactive {
+			if err := s.schedule.Remove(ctx, m); err != nil && !errors.Is(err, boomerang.ErrTaskDoesNotExist) {
+				return migrated, err
+			}
+
+			if err := s.schedule.Add(ctx, m); err != nil {
+				return migrated, err
+			}
+		}
+
+		migrated++
+	}
+
+	return migrated, nil
+}
+
+// validateQuery rejects queries the probe of the monitor would refuse to run
+func validateQuery(m *entities.Monitor) error {
+	if m.Settings.Method != "REDIS" {
+		return nil
+	}
+
+	if err := probeRedis.ValidateQuery(m.Settings.Database.Query); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidQuery, err)
+	}
+
+	return nil
+}
+
+// sealConnectionString moves the URL of database monitors into their encrypted
+// connection string, leaving the URL with the password redacted. An update
+// that sends back the redacted URL keeps the connection string of the old
+// monitor, and one that changes it but keeps the redacted password keeps the
+// password of the old monitor.
+func (s *ServiceImpl) sealConnectionString(m *entities.Monitor, old *entities.Monitor) error {
+	if !slices.Contains(databaseMethods, m.Settings.Method) {
+		m.Settings.Database.ConnectionString = ""
+
+		return nil
+	}
+
+	plain := m.Settings.URL
+
+	if old != nil && old.Settings.Database.ConnectionString != "" {
+		if old.Settings.URL == m.Settings.URL {
+			m.Settings.Database.ConnectionString = old.Settings.Database.ConnectionString
+
+			return nil
+		}
+
+		if password, ok := connectionStringPassword(plain); ok && password == redactedPassword {
+			stored, err := s.secrets.Decrypt(old.Settings.Database.ConnectionString)
+			if err != nil {
+				return err
+			}
+
+			if storedPassword, ok := connectionStringPassword(stored); ok {
+				plain = setConnectionStringPassword(plain, storedPassword)
+			}
+		}
+	}
+
+	connectionString, err := s.secrets.Encrypt(plain)
+	if err != nil {
+		return err
+	}
+
+	m.Settings.Database.ConnectionString = connectionString
+	m.Settings.URL = redactConnectionString(plain)
+
+	return nil
+}
+
+// Password shown instead of the real one in redacted connection strings
+const redactedPassword = "xxxxx"
+
+var passwordParameterRegexp = regexp.MustCompile(`(?i)(password=)('[^']*'|\S+)`)
+
+// redactConnectionString replaces the password of URLs, of MySQL DSNs such as
+// user:pass@tcp(host)/db and of Postgres key value strings such as
+// host=db password=pass
+func redactConnectionString(connectionString string) string {
+	if u, err := url.Parse(connectionString); err == nil && u.Scheme != "" && u.Host != "" {
+		return u.Redacted()
+	}
+
+	return setConnectionStringPassword(connectionString, redactedPassword)
+}
+
+// connectionStringPassword returns the password of a connection string in any
+// of the formats redactConnectionString redacts, if it has one
+func connectionStringPassword(connectionString string) (string, bool) {
+	if u, err := url.Parse(connectionString); err == nil && u.Scheme != "" && u.Host != "" {
+		if u.User == nil {
+			return "", false
+		}
+
+		return u.User.Password()
+	}
+
+	if i := strings.LastIndex(connectionString, "@"); i >= 0 {
+		if _, password, ok := strings.Cut(connectionString[:i], ":"); ok {
+			return password, true
+		}
+	}
+
+	if match := passwordParameterRegexp.FindStringSubmatch(connectionString); match != nil {
+		return match[2], true
+	}
+
+	return "", false
+}
+
+// setConnectionStringPassword replaces the password of a connection string in
+// any of the formats redactConnectionString redacts
+func setConnectionStringPassword(connectionString string, password string) string {
+	if u, err := url.Parse(connectionString); err == nil && u.Scheme != "" && u.Host != "" {
+		if u.User == nil {
+			return connectionString
+		}
+
+		u.User = url.UserPassword(u.User.Username(), password)
+
+		return u.String()
+	}
+
+	if i := strings.LastIndex(connectionString, "@"); i >= 0 {
+		if user, _, ok := strings.Cut(connectionString[:i], ":"); ok {
+			return user + ":" + password + connectionString[i:]
+		}
+	}
+
+	return passwordParameterRegexp.ReplaceAllStringFunc(connectionString, func(parameter string) string {
+		return parameter[:len("password=")] + password
+	})
+}
@@ -0,0 +1,232 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type CheckState struct {
+	ConsecutiveFailures  uint
+	ConsecutiveSuccesses uint
+
+	// Most recent check results, newest first
+	RecentResults []bool
+}
+
+type State interface {
+	RecordCheck(ctx context.Context, monitorID uint, location string, passed bool, historySize uint) (*CheckState, error)
+	SetLocationDown(ctx context.Context, monitorID uint, location string, window time.Duration) ([]string, error)
+	SetLocationUp(ctx context.Context, monitorID uint, location string, window time.Duration) ([]string, error)
+	MarkDown(ctx context.Context, monitorID uint) (bool, error)
+	MarkUp(ctx context.Context, monitorID uint) error
+	IsFlapping(ctx context.Context, monitorID uint) (bool, error)
+	IsLocationFlapping(ctx context.Context, monitorID uint, location string) (bool, error)
+	MarkFlapping(ctx context.Context, monitorID uint, location string) (bool, error)
+	MarkStable(ctx context.Context, monitorID uint, location string) (bool, error)
+}
+
+type StateImpl struct {
+	cli *redis.Client
+}
+
+func NewState(cli *redis.Client) State {
+	return &StateImpl{
+		cli: cli,
+	}
+}
+
+func monitorFailuresKey(monitorID uint, location string) string {
+	return fmt.Sprintf("monitor:%d:%s:failures", monitorID, location)
+}
+
+func monitorSuccessesKey(monitorID uint, location string) string {
+	return fmt.Sprintf("monitor:%d:%s:successes", monitorID, location)
+}
+
+func monitorResultsKey(monitorID uint, location string) string {
+	return fmt.Sprintf("monitor:%d:%s:results", monitorID, location)
+}
+
+func monitorFailingLocationsKey(monitorID uint) string {
+	return fmt.Sprintf("monitor:%d:failing_locations", monitorID)
+}
+
+func monitorDownKey(monitorID uint) string {
+	return fmt.Sprintf("monitor:%d:down", monitorID)
+}
+
+func monitorFlappingLocationsKey(monitorID uint) string {
+	return fmt.Sprintf("monitor:%d:flapping_locations", monitorID)
+}
+
+// RecordCheck stores the outcome of a check in a location and returns the
+// updated state of that location. Only the last historySize results are kept.
+func (s *StateImpl) RecordCheck(ctx context.Context, monitorID uint, location string, passed bool, historySize uint) (*CheckState, error) {
+	failuresKey := monitorFailuresKey(monitorID, location)
+	successesKey := monitorSuccessesKey(monitorID, location)
+	resultsKey := monitorResultsKey(monitorID, location)
+
+	var (
+		failures  *redis.IntCmd
+		successes *redis.IntCmd
+		results   *redis.StringSliceCmd
+	)
+
+	_, err := s.cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if passed {
+			pipe.Del(ctx, failuresKey)
+			successes = pipe.Incr(ctx, successesKey)
+		} else {
+			pipe.Del(ctx, successesKey)
+			failures = pipe.Incr(ctx, failuresKey)
+		}
+
+		if historySize == 0 {
+			pipe.Del(ctx, resultsKey)
+
+			return nil
+		}
+
+		result := "0"
+		if passed {
+			result = "1"
+		}
+
+		pipe.LPush(ctx, resultsKey, result)
+		pipe.LTrim(ctx, resultsKey, 0, int64(historySize)-1)
+		results = pipe.LRange(ctx, resultsKey, 0, -1)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	state := &CheckState{}
+
+	if failures != nil {
+		state.ConsecutiveFailures = uint(failures.Val())
+	}
+
+	if successes != nil {
+		state.ConsecutiveSuccesses = uint(successes.Val())
+	}
+
+	if results != nil {
+		for _, r := range results.Val() {
+			state.RecentResults = append(state.RecentResults, r == "1")
+		}
+	}
+
+	return state, nil
+}
+
+// SetLocationDown flags a location as failing and returns all locations that
+// have been failing within the window, including this one.
+func (s *StateImpl) SetLocationDown(ctx context.Context, monitorID uint, location string, window time.Duration) ([]string, error) {
+	return s.setLocation(ctx, monitorID, location, true, window)
+}
+
+// SetLocationUp clears the failing flag of a location and returns the
+// locations that are still failing within the window.
+func (s *StateImpl) SetLocationUp(ctx context.Context, monitorID uint, location string, window time.Duration) ([]string, error) {
+	return s.setLocation(ctx, monitorID, location, false, window)
+}
+
+func (s *StateImpl) setLocation(ctx context.Context, monitorID uint, location string, down bool, window time.Duration) ([]string, error) {
+	key := monitorFailingLocationsKey(monitorID)
+	now := time.Now()
+
+	var locations *redis.StringSliceCmd
+
+	_, err := s.cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if down {
+			pipe.ZAdd(ctx, key, redis.Z{Score: float64(now.Unix()), Member: location})
+		} else {
+			pipe.ZRem(ctx, key, location)
+		}
+
+		// Locations that have not reported a failure within the window are stale
+		pipe.ZRemRangeByScore(ctx, key, "-inf", "("+strconv.FormatInt(now.Add(-window).Unix(), 10))
+		locations = pipe.ZRange(ctx, key, 0, -1)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return locations.Val(), nil
+}
+
+// MarkDown flags the monitor as down. It returns true if the monitor was not
+// already flagged, meaning an incident should be opened.
+func (s *StateImpl) MarkDown(ctx context.Context, monitorID uint) (bool, error) {
+	return s.cli.SetNX(ctx, monitorDownKey(monitorID), 1, 0).Result()
+}
+
+func (s *StateImpl) MarkUp(ctx context.Context, monitorID uint) error {
+	return s.cli.Del(ctx, monitorDownKey(monitorID)).Err()
+}
+
+// IsFlapping reports whether the monitor is flapping in any location.
+func (s *StateImpl) IsFlapping(ctx context.Context, monitorID uint) (bool, error) {
+	n, err := s.cli.Exists(ctx, monitorFlappingLocationsKey(monitorID)).Result()
+
+	return n > 0, err
+}
+
+func (s *StateImpl) IsLocationFlapping(ctx context.Context, monitorID uint, location string) (bool, error) {
+	return s.cli.SIsMember(ctx, monitorFlappingLocationsKey(monitorID), location).Result()
+}
+
+// MarkFlapping flags a location of the monitor as flapping. It returns true if
+// no location was flagged before, meaning a flapping incident should be opened.
+func (s *StateImpl) MarkFlapping(ctx context.Context, monitorID uint, location string) (bool, error) {
+	key := monitorFlappingLocationsKey(monitorID)
+
+	var (
+		added     *redis.IntCmd
+		locations *redis.IntCmd
+	)
+
+	_, err := s.cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		added = pipe.SAdd(ctx, key, location)
+		locations = pipe.SCard(ctx, key)
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return added.Val() > 0 && locations.Val() == 1, nil
+}
+
+// MarkStable clears the flapping flag of a location of the monitor. It returns
+// true if it was the last flagged location, meaning the flapping incident
+// should be resolved.
+func (s *StateImpl) MarkStable(ctx context.Context, monitorID uint, location string) (bool, error) {
+	key := monitorFlappingLocationsKey(monitorID)
+
+	var (
+		removed   *redis.IntCmd
+		locations *redis.IntCmd
+	)
+
+	_, err := s.cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		removed = pipe.SRem(ctx, key, location)
+		locations = pipe.SCard(ctx, key)
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return removed.Val() > 0 && locations.Val() == 0, nil
+}
@@ -0,0 +1,134 @@
+package monitor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/opsway-io/backend/internal/monitor"
+	redisclient "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	testcontainersredis "github.com/testcontainers/testcontainers-go/modules/redis"
+)
+
+func TestStateIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	redisContainer, err := testcontainersredis.Run(ctx,
+		"redis/redis-stack:7.4.0-v3",
+	)
+	require.NoError(t, err)
+	defer func() {
+		if err := redisContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+
+	connStr, err := redisContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	opts, err := redisclient.ParseURL(connStr)
+	require.NoError(t, err)
+
+	s := monitor.NewState(redisclient.NewClient(opts))
+
+	t.Run("Record checks", func(t *testing.T) {
+		state, err := s.RecordCheck(ctx, 1, "eu-central-1", false, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), state.ConsecutiveFailures)
+
+		state, err = s.RecordCheck(ctx, 1, "eu-central-1", false, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2), state.ConsecutiveFailures)
+
+		state, err = s.RecordCheck(ctx, 1, "eu-central-1", true, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(0), state.ConsecutiveFailures)
+		assert.Equal(t, uint(1), state.ConsecutiveSuccesses)
+		assert.Equal(t, []bool{true, false, false}, state.RecentResults)
+
+		state, err = s.RecordCheck(ctx, 1, "eu-central-1", false, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), state.ConsecutiveFailures)
+		assert.Equal(t, uint(0), state.ConsecutiveSuccesses)
+		assert.Equal(t, []bool{false, true, false}, state.RecentResults)
+	})
+
+	t.Run("Failing locations", func(t *testing.T) {
+		locations, err := s.SetLocationDown(ctx, 3, "eu-central-1", time.Minute)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"eu-central-1"}, locations)
+
+		locations, err = s.SetLocationDown(ctx, 3, "us-east-1", time.Minute)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"eu-central-1", "us-east-1"}, locations)
+
+		locations, err = s.SetLocationUp(ctx, 3, "eu-central-1", time.Minute)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"us-east-1"}, locations)
+	})
+
+	t.Run("Mark down and up", func(t *testing.T) {
+		down, err := s.MarkDown(ctx, 2)
+		assert.NoError(t, err)
+		assert.True(t, down)
+
+		down, err = s.MarkDown(ctx, 2)
+		assert.NoError(t, err)
+		assert.False(t, down)
+
+		err = s.MarkUp(ctx, 2)
+		assert.NoError(t, err)
+
+		down, err = s.MarkDown(ctx, 2)
+		assert.NoError(t, err)
+		assert.True(t, down)
+	})
+
+	t.Run("Mark flapping and stable", func(t *testing.T) {
+		flapping, err := s.IsFlapping(ctx, 4)
+		assert.NoError(t, err)
+		assert.False(t, flapping)
+
+		started, err := s.MarkFlapping(ctx, 4, "eu")
+		assert.NoError(t, err)
+		assert.True(t, started)
+
+		started, err = s.MarkFlapping(ctx, 4, "eu")
+		assert.NoError(t, err)
+		assert.False(t, started)
+
+		started, err = s.MarkFlapping(ctx, 4, "us")
+		assert.NoError(t, err)
+		assert.False(t, started)
+
+		flapping, err = s.IsFlapping(ctx, 4)
+		assert.NoError(t, err)
+		assert.True(t, flapping)
+
+		flapping, err = s.IsLocationFlapping(ctx, 4, "asia")
+		assert.NoError(t, err)
+		assert.False(t, flapping)
+
+		stopped, err := s.MarkStable(ctx, 4, "eu")
+		assert.NoError(t, err)
+		assert.False(t, stopped)
+
+		flapping, err = s.IsFlapping(ctx, 4)
+		assert.NoError(t, err)
+		assert.True(t, flapping)
+
+		stopped, err = s.MarkStable(ctx, 4, "us")
+		assert.NoError(t, err)
+		assert.True(t, stopped)
+
+		stopped, err = s.MarkStable(ctx, 4, "us")
+		assert.NoError(t, err)
+		assert.False(t, stopped)
+	})
+}
@@ -0,0 +1,53 @@
+package delivery
+
+import (
+	"context"
+	"time"
+
+	"github.com/opsway-io/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, attempt *entities.NotificationAttempt) error
+	GetByIncidentID(ctx context.Context, incidentID uint) ([]entities.NotificationAttempt, error)
+	GetDue(ctx context.Context, now time.Time, channels []string) ([]entities.NotificationAttempt, error)
+	ClearNextAttemptAt(ctx context.Context, id uint) (bool, error)
+}
+
+type RepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &RepositoryImpl{db: db}
+}
+
+func (r *RepositoryImpl) Create(ctx context.Context, attempt *entities.NotificationAttempt) error {
+	return r.db.WithContext(ctx).Create(attempt).Error
+}
+
+func (r *RepositoryImpl) GetByIncidentID(ctx context.Context, incidentID uint) ([]entities.NotificationAttempt, error) {
+	var attempts []entities.NotificationAttempt
+	err := r.db.WithContext(ctx).Where("incident_id = ?", incidentID).Order("created_at asc, id asc").Find(&attempts).Error
+	return attempts, err
+}
+
+// GetDue returns the failed attempts over the channels whose retry is due,
+// oldest first
+func (r *RepositoryImpl) GetDue(ctx context.Context, now time.Time, channels []string) ([]entities.NotificationAttempt, error) {
+	var attempts []entities.NotificationAttempt
+	err := r.db.WithContext(ctx).Where(
+		"next_attempt_at <= ? AND channel IN ?", now, channels,
+	).Order("next_attempt_at asc, id asc").Find(&attempts).Error
+	return attempts, err
+}
+
+// ClearNextAttemptAt unschedules the retry of the attempt. It returns false if
+// it was not scheduled anymore, meaning another process is retrying it.
+func (r *RepositoryImpl) ClearNextAttemptAt(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entities.NotificationAttempt{}).Where(
+		"id = ? AND next_attempt_at IS NOT NULL", id,
+	).Update("next_attempt_at", nil)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,215 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/opsway-io/backend/internal/entities"
+)
+
+var (
+	ErrUnexpectedStatus = errors.New("unexpected response status")
+	// Wraps the error of a failed attempt that will be retried
+	ErrRetryScheduled = errors.New("retry scheduled")
+	// Wrapped by a SendFunc to fail without retrying
+	ErrPermanent = errors.New("permanent failure")
+)
+
+// DefaultBackoff is the wait before each retry of a failed notification
+var DefaultBackoff = []time.Duration{
+	2 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+}
+
+// Delivery identifies a notification to a single recipient over a channel
+type Delivery struct {
+	TeamID        uint
+	IncidentID    *uint
+	MaintenanceID *uint
+	AlertRuleID   *uint
+	Channel       string
+	Recipient     string
+	// Stored with the attempts and passed to the Resender of the channel
+	RetryContext map[string]string
+}
+
+// SendFunc makes a single attempt at sending a notification. It returns the
+// response status code of the provider, or 0 if there was none.
+type SendFunc func(ctx context.Context) (int, error)
+
+// Resender rebuilds the send function of a notification from its failed
+// attempt, so it can be retried by any process after a restart. It returns an
+// error wrapping ErrPermanent if the notification should not be sent anymore.
+type Resender func(ctx context.Context, attempt *entities.NotificationAttempt) (SendFunc, error)
+
+type Service interface {
+	Deliver(ctx context.Context, d Delivery, send SendFunc) error
+	RegisterResender(channel string, resend Resender)
+	RetryDue(ctx context.Context) error
+	GetByIncidentID(ctx context.Context, incidentID uint) ([]entities.NotificationAttempt, error)
+}
+
+type ServiceImpl struct {
+	repository Repository
+	backoff    []time.Duration
+
+	mu        sync.RWMutex
+	resenders map[string]Resender
+}
+
+func NewService(repository Repository) Service {
+	return NewServiceWithBackoff(repository, DefaultBackoff)
+}
+
+func NewServiceWithBackoff(repository Repository, backoff []time.Duration) Service {
+	return &ServiceImpl{
+		repository: repository,
+		backoff:    backoff,
+		resenders:  map[string]Resender{},
+	}
+}
+
+// Deliver makes the first attempt at sending the notification and records it.
+// Failed attempts the provider did not reject are scheduled for a retry with
+// backoff, which RetryDue makes, and returned wrapped in ErrRetryScheduled.
+func (s *ServiceImpl) Deliver(ctx context.Context, d Delivery, send SendFunc) error {
+	return s.attempt(ctx, d, send, 1)
+}
+
+// RegisterResender makes the failed notifications over the channel retried
+// by RetryDue. Notifications over channels without one are not retried.
+func (s *ServiceImpl) RegisterResender(channel string, resend Resender) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.resenders[channel] = resend
+}
+
+// RetryDue makes the retries that are due, as scheduled in the attempt
+// records. It is called periodically by the Worker.
+func (s *ServiceImpl) RetryDue(ctx context.Context) error {
+	s.mu.RLock()
+	channels := make([]string, 0, len(s.resenders))
+	for channel := range s.resenders {
+		channels = append(channels, channel)
+	}
+	s.mu.RUnlock()
+
+	if len(channels) == 0 {
+		return nil
+	}
+
+	attempts, err := s.repository.GetDue(ctx, time.Now(), channels)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range attempts {
+		a := &attempts[i]
+
+		claimed, err := s.repository.ClearNextAttemptAt(ctx, a.ID)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if !claimed {
+			continue
+		}
+
+		s.mu.RLock()
+		resend := s.resenders[a.Channel]
+		s.mu.RUnlock()
+
+		send, err := resend(ctx, a)
+		if err != nil {
+			// Recorded as a failed attempt like any other failure
+			send = func(context.Context) (int, error) {
+				return 0, err
+			}
+		}
+
+		d := Delivery{
+			TeamID:        a.TeamID,
+			IncidentID:    a.IncidentID,
+			MaintenanceID: a.MaintenanceID,
+			AlertRuleID:   a.AlertRuleID,
+			Channel:       a.Channel,
+			Recipient:     a.Recipient,
+			RetryContext:  a.RetryContext,
+		}
+
+		if err := s.attempt(ctx, d, send, a.Attempt+1); err != nil && !errors.Is(err, ErrRetryScheduled) {
+			errs = append(errs, fmt.Errorf("%s to %s: %w", a.Channel, a.Recipient, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *ServiceImpl) attempt(ctx context.Context, d Delivery, send SendFunc, attempt int) error {
+	code, err := send(ctx)
+	if err == nil && code >= http.StatusBadRequest {
+		err = fmt.Errorf("%w: %d", ErrUnexpectedStatus, code)
+	}
+
+	record := &entities.NotificationAttempt{
+		TeamID:        d.TeamID,
+		IncidentID:    d.IncidentID,
+		MaintenanceID: d.MaintenanceID,
+		AlertRuleID:   d.AlertRuleID,
+		Channel:       d.Channel,
+		Recipient:     d.Recipient,
+		Attempt:       attempt,
+		Status:        entities.NotificationAttemptStatusSent,
+		RetryContext:  d.RetryContext,
+	}
+
+	if code != 0 {
+		record.ResponseCode = &code
+	}
+
+	retrying := false
+	if err != nil {
+		msg := err.Error()
+		record.Status = entities.NotificationAttemptStatusFailed
+		record.Error = &msg
+
+		if !errors.Is(err, ErrPermanent) && isRetryable(code) && attempt <= len(s.backoff) {
+			retrying = true
+			next := time.Now().Add(s.backoff[attempt-1])
+			record.NextAttemptAt = &next
+		}
+	}
+
+	// A failure to record the attempt must not stop the notification, but
+	// the retry is only scheduled once it was recorded
+	recordErr := s.repository.Create(ctx, record)
+
+	if err == nil {
+		return recordErr
+	}
+
+	if !retrying || recordErr != nil {
+		return errors.Join(err, recordErr)
+	}
+
+	return fmt.Errorf("%w: %w", ErrRetryScheduled, err)
+}
+
+func (s *ServiceImpl) GetByIncidentID(ctx context.Context, incidentID uint) ([]entities.NotificationAttempt, error) {
+	return s.repository.GetByIncidentID(ctx, incidentID)
+}
+
+// isRetryable reports whether a failed attempt may succeed later, which is the
+// case for network errors, rate limiting and server errors.
+func isRetryable(code int) bool {
+	return code == 0 || code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
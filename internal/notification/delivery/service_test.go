@@ -0,0 +1,223 @@
+package delivery_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/opsway-io/backend/internal/entities"
+	"github.com/opsway-io/backend/internal/notification/delivery"
+	"github.com/opsway-io/backend/internal/notification/delivery/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_Deliver(t *testing.T) {
+	ctx := context.Background()
+	incidentID := uint(100)
+
+	d := delivery.Delivery{
+		TeamID:     1,
+		IncidentID: &incidentID,
+		Channel:    "discord",
+		Recipient:  "https://discord.example.com/webhook",
+	}
+
+	statusIs := func(status entities.NotificationAttemptStatus, attempt int) interface{} {
+		return mock.MatchedBy(func(a *entities.NotificationAttempt) bool {
+			return a.Status == status && a.Attempt == attempt && a.TeamID == 1 && *a.IncidentID == incidentID
+		})
+	}
+
+	// The repository mock returns the retries of the attempts it recorded once
+	// they are due, like the database does
+	scheduleRetries := func(repo *mocks.Repository) {
+		var attempts []*entities.NotificationAttempt
+
+		repo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			a := args.Get(1).(*entities.NotificationAttempt)
+			a.ID = uint(len(attempts) + 1)
+			attempts = append(attempts, a)
+		}).Return(nil).Maybe()
+
+		repo.On("GetDue", ctx, mock.Anything, []string{"discord"}).Return(func(_ context.Context, now time.Time, _ []string) ([]entities.NotificationAttempt, error) {
+			var due []entities.NotificationAttempt
+			for _, a := range attempts {
+				if a.NextAttemptAt != nil && !a.NextAttemptAt.After(now) {
+					due = append(due, *a)
+				}
+			}
+
+			return due, nil
+		}).Maybe()
+
+		repo.On("ClearNextAttemptAt", ctx, mock.Anything).Return(func(_ context.Context, id uint) (bool, error) {
+			scheduled := attempts[id-1].NextAttemptAt != nil
+			attempts[id-1].NextAttemptAt = nil
+
+			return scheduled, nil
+		}).Maybe()
+	}
+
+	t.Run("records a successful attempt", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		svc := delivery.NewServiceWithBackoff(repo, []time.Duration{0, 0})
+
+		repo.On("Create", ctx, statusIs(entities.NotificationAttemptStatusSent, 1)).Return(nil).Once()
+
+		err := svc.Deliver(ctx, d, func(ctx context.Context) (int, error) {
+			return http.StatusNoContent, nil
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("retries server errors until it succeeds", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		svc := delivery.NewServiceWithBackoff(repo, []time.Duration{0, 0})
+		scheduleRetries(repo)
+
+		codes := []int{http.StatusBadGateway, http.StatusOK}
+		send := func(ctx context.Context) (int, error) {
+			code := codes[0]
+			codes = codes[1:]
+			return code, nil
+		}
+
+		var resent *entities.NotificationAttempt
+		svc.RegisterResender("discord", func(ctx context.Context, a *entities.NotificationAttempt) (delivery.SendFunc, error) {
+			resent = a
+			return send, nil
+		})
+
+		err := svc.Deliver(ctx, d, send)
+
+		assert.ErrorIs(t, err, delivery.ErrRetryScheduled)
+		assert.NoError(t, svc.RetryDue(ctx))
+		assert.Empty(t, codes)
+		assert.Equal(t, d.Recipient, resent.Recipient)
+		repo.AssertCalled(t, "Create", ctx, statusIs(entities.NotificationAttemptStatusSent, 2))
+	})
+
+	t.Run("gives up after the last retry", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		svc := delivery.NewServiceWithBackoff(repo, []time.Duration{0, 0})
+		scheduleRetries(repo)
+
+		sendErr := errors.New("connection refused")
+		send := func(ctx context.Context) (int, error) {
+			return 0, sendErr
+		}
+
+		svc.RegisterResender("discord", func(ctx context.Context, a *entities.NotificationAttempt) (delivery.SendFunc, error) {
+			return send, nil
+		})
+
+		err := svc.Deliver(ctx, d, send)
+
+		assert.ErrorIs(t, err, delivery.ErrRetryScheduled)
+		assert.ErrorIs(t, err, sendErr)
+
+		assert.NoError(t, svc.RetryDue(ctx))
+		assert.ErrorIs(t, svc.RetryDue(ctx), sendErr)
+		assert.NoError(t, svc.RetryDue(ctx))
+		repo.AssertCalled(t, "Create", ctx, statusIs(entities.NotificationAttemptStatusFailed, 3))
+		repo.AssertNotCalled(t, "Create", ctx, statusIs(entities.NotificationAttemptStatusFailed, 4))
+	})
+
+	t.Run("retries once the backoff passed", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		svc := delivery.NewServiceWithBackoff(repo, []time.Duration{time.Hour})
+		scheduleRetries(repo)
+
+		sent := 0
+		send := func(ctx context.Context) (int, error) {
+			sent++
+			return http.StatusServiceUnavailable, nil
+		}
+
+		svc.RegisterResender("discord", func(ctx context.Context, a *entities.NotificationAttempt) (delivery.SendFunc, error) {
+			return send, nil
+		})
+
+		err := svc.Deliver(ctx, d, send)
+
+		assert.ErrorIs(t, err, delivery.ErrRetryScheduled)
+		assert.NoError(t, svc.RetryDue(ctx))
+		assert.Equal(t, 1, sent)
+	})
+
+	t.Run("retries after a restart", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		scheduleRetries(repo)
+
+		err := delivery.NewServiceWithBackoff(repo, []time.Duration{0}).Deliver(ctx, d, func(ctx context.Context) (int, error) {
+			return http.StatusTooManyRequests, nil
+		})
+		assert.ErrorIs(t, err, delivery.ErrRetryScheduled)
+
+		// Another process picks up the scheduled retry
+		svc := delivery.NewServiceWithBackoff(repo, []time.Duration{0})
+		svc.RegisterResender("discord", func(ctx context.Context, a *entities.NotificationAttempt) (delivery.SendFunc, error) {
+			return func(ctx context.Context) (int, error) {
+				return http.StatusOK, nil
+			}, nil
+		})
+
+		assert.NoError(t, svc.RetryDue(ctx))
+		assert.NoError(t, svc.RetryDue(ctx))
+		repo.AssertCalled(t, "Create", ctx, statusIs(entities.NotificationAttemptStatusSent, 2))
+	})
+
+	t.Run("does not retry notifications the resender gives up on", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		svc := delivery.NewServiceWithBackoff(repo, []time.Duration{0, 0})
+		scheduleRetries(repo)
+
+		svc.RegisterResender("discord", func(ctx context.Context, a *entities.NotificationAttempt) (delivery.SendFunc, error) {
+			return nil, fmt.Errorf("%w: incident resolved", delivery.ErrPermanent)
+		})
+
+		err := svc.Deliver(ctx, d, func(ctx context.Context) (int, error) {
+			return http.StatusBadGateway, nil
+		})
+
+		assert.ErrorIs(t, err, delivery.ErrRetryScheduled)
+		assert.ErrorIs(t, svc.RetryDue(ctx), delivery.ErrPermanent)
+		assert.NoError(t, svc.RetryDue(ctx))
+		repo.AssertNotCalled(t, "Create", ctx, statusIs(entities.NotificationAttemptStatusFailed, 3))
+	})
+
+	t.Run("does not retry rejected notifications", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		svc := delivery.NewServiceWithBackoff(repo, []time.Duration{0, 0})
+
+		repo.On("Create", ctx, mock.MatchedBy(func(a *entities.NotificationAttempt) bool {
+			return a.Status == entities.NotificationAttemptStatusFailed &&
+				a.ResponseCode != nil && *a.ResponseCode == http.StatusBadRequest &&
+				a.Error != nil
+		})).Return(nil).Once()
+
+		err := svc.Deliver(ctx, d, func(ctx context.Context) (int, error) {
+			return http.StatusBadRequest, nil
+		})
+
+		assert.ErrorIs(t, err, delivery.ErrUnexpectedStatus)
+	})
+
+	t.Run("does not retry permanent failures", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		svc := delivery.NewServiceWithBackoff(repo, []time.Duration{0, 0})
+
+		repo.On("Create", ctx, statusIs(entities.NotificationAttemptStatusFailed, 1)).Return(nil).Once()
+
+		err := svc.Deliver(ctx, d, func(ctx context.Context) (int, error) {
+			return 0, delivery.ErrPermanent
+		})
+
+		assert.ErrorIs(t, err, delivery.ErrPermanent)
+	})
+}
@@ -0,0 +1,47 @@
+package delivery
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Worker interface {
+	Start(ctx context.Context) error
+}
+
+type worker struct {
+	logger   *logrus.Entry
+	delivery Service
+	interval time.Duration
+}
+
+// NewWorker returns a worker retrying the failed notifications of the
+// service every interval
+func NewWorker(logger *logrus.Entry, delivery Service, interval time.Duration) Worker {
+	return &worker{
+		logger:   logger.WithField("component", "delivery-worker"),
+		delivery: delivery,
+		interval: interval,
+	}
+}
+
+func (w *worker) Start(ctx context.Context) error {
+	w.logger.Info("starting delivery worker")
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("stopping delivery worker")
+			return nil
+		case <-ticker.C:
+			if err := w.delivery.RetryDue(ctx); err != nil {
+				w.logger.WithError(err).Error("failed to retry notifications")
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package templates
+
+import (
+	_ "embed"
+	"fmt"
+)
+
+//go:embed incident_assigned.hbs
+var IncidentAssignedTemplateSource string
+
+type IncidentAssignedTemplate struct {
+	BaseTemplate
+
+	Name          string
+	AssignedBy    string
+	MonitorName   string
+	IncidentTitle string
+	DashboardURL  string
+}
+
+func (t *IncidentAssignedTemplate) Subject() string {
+	return fmt.Sprintf("Assigned: Incident for %s", t.MonitorName)
+}
+
+func (t *IncidentAssignedTemplate) HTML() string {
+	return t.Render(IncidentAssignedTemplateSource, map[string]any{
+		"title":          "Incident Assigned",
+		"name":           t.Name,
+		"assigned_by":    t.AssignedBy,
+		"monitor_name":   t.MonitorName,
+		"incident_title": t.IncidentTitle,
+		"dashboard_url":  t.DashboardURL,
+	})
+}
+
+func (t *IncidentAssignedTemplate) PlainText() string {
+	return fmt.Sprintf(`
+Hi %s,
+
+%s assigned you an incident for monitor "%s":
+Issue: %s
+
+View the incident here: %s
+	`,
+		t.Name,
+		t.AssignedBy,
+		t.MonitorName,
+		t.IncidentTitle,
+		t.DashboardURL,
+	)
+}
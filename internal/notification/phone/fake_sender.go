@@ -0,0 +1,64 @@
+package phone
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+type SMS struct {
+	To   string
+	Body string
+}
+
+type Call struct {
+	To      string
+	Message string
+	Ack     *Acknowledgement
+}
+
+type FakeSender struct {
+	mu       sync.Mutex
+	messages []SMS
+	calls    []Call
+}
+
+// Records messages and calls instead of sending them. Useful for development
+// and tests.
+func NewFakeSender() *FakeSender {
+	return &FakeSender{}
+}
+
+func (s *FakeSender) SendSMS(ctx context.Context, to string, body string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, SMS{To: to, Body: body})
+
+	return http.StatusCreated, nil
+}
+
+func (s *FakeSender) Call(ctx context.Context, to string, message string, ack *Acknowledgement) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, Call{To: to, Message: message, Ack: ack})
+
+	return http.StatusCreated, nil
+}
+
+// Messages returns the text messages sent so far
+func (s *FakeSender) Messages() []SMS {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]SMS(nil), s.messages...)
+}
+
+// Calls returns the calls placed so far
+func (s *FakeSender) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Call(nil), s.calls...)
+}
@@ -0,0 +1,82 @@
+package phone
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	ProviderFake   = "fake"
+	ProviderTwilio = "twilio"
+)
+
+type Config struct {
+	Provider   string `mapstructure:"provider" default:"fake"`
+	AccountSID string `mapstructure:"account_sid"`
+	AuthToken  string `mapstructure:"auth_token"`
+	FromNumber string `mapstructure:"from_number"`
+	APIBaseURL string `mapstructure:"api_base_url" default:"https://api.twilio.com"`
+
+	// Public URL of the API, the provider posts keypresses of voice calls to
+	// it. Defaults to the local address of the REST server.
+	CallbackBaseURL string `mapstructure:"callback_base_url"`
+	CallbackSecret  string `mapstructure:"callback_secret"`
+}
+
+// Acknowledgement is the incident the recipient of a call can acknowledge, and
+// the user they acknowledge it as
+type Acknowledgement struct {
+	IncidentID uint
+	UserID     uint
+}
+
+type Sender interface {
+	SendSMS(ctx context.Context, to string, body string) (int, error)
+	// Call reads the message to the recipient. If an acknowledgement is given
+	// the recipient can acknowledge the incident by pressing 1.
+	Call(ctx context.Context, to string, message string, ack *Acknowledgement) (int, error)
+}
+
+func NewSender(config Config) (Sender, error) {
+	switch config.Provider {
+	case ProviderTwilio:
+		return NewTwilioSender(config), nil
+	case ProviderFake, "":
+		return NewFakeSender(), nil
+	default:
+		return nil, fmt.Errorf("unknown phone provider: %s", config.Provider)
+	}
+}
+
+// CallbackURL is where the provider reports the key pressed during a voice
+// call about the incident.
+func CallbackURL(config Config, ack Acknowledgement) string {
+	return fmt.Sprintf(
+		"%s/v1/webhooks/voice/%d?user=%d&token=%s",
+		strings.TrimSuffix(config.CallbackBaseURL, "/"),
+		ack.IncidentID,
+		ack.UserID,
+		callbackToken(config, ack),
+	)
+}
+
+// VerifyCallback reports whether the token was issued for the incident and
+// user. Callbacks are always rejected when no secret is configured.
+func VerifyCallback(config Config, ack Acknowledgement, token string) bool {
+	if config.CallbackSecret == "" {
+		return false
+	}
+
+	return hmac.Equal([]byte(callbackToken(config, ack)), []byte(token))
+}
+
+func callbackToken(config Config, ack Acknowledgement) string {
+	mac := hmac.New(sha256.New, []byte(config.CallbackSecret))
+	fmt.Fprintf(mac, "incident:%d:user:%d", ack.IncidentID, ack.UserID)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,112 @@
+package phone_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/opsway-io/backend/internal/notification/phone"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwilioSender(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		path string
+		form map[string]string
+		user string
+		pass string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		path = r.URL.Path
+		user, pass, _ = r.BasicAuth()
+		form = map[string]string{}
+		for k := range r.PostForm {
+			form[k] = r.PostForm.Get(k)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	config := phone.Config{
+		AccountSID:      "AC123",
+		AuthToken:       "secret",
+		FromNumber:      "+4512345678",
+		APIBaseURL:      server.URL,
+		CallbackBaseURL: "https://api.opsway.eu",
+		CallbackSecret:  "callback-secret",
+	}
+
+	sender := phone.NewTwilioSender(config)
+
+	t.Run("sends sms", func(t *testing.T) {
+		code, err := sender.SendSMS(ctx, "+4587654321", "Incident: API down")
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, code)
+		assert.Equal(t, "/2010-04-01/Accounts/AC123/Messages.json", path)
+		assert.Equal(t, "AC123", user)
+		assert.Equal(t, "secret", pass)
+		assert.Equal(t, "+4587654321", form["To"])
+		assert.Equal(t, "+4512345678", form["From"])
+		assert.Equal(t, "Incident: API down", form["Body"])
+	})
+
+	t.Run("places call with acknowledge prompt", func(t *testing.T) {
+		code, err := sender.Call(ctx, "+4587654321", "Incident: API <down>", &phone.Acknowledgement{IncidentID: 42, UserID: 3})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, code)
+		assert.Equal(t, "/2010-04-01/Accounts/AC123/Calls.json", path)
+		assert.Contains(t, form["Twiml"], `<Gather numDigits="1" method="POST" action="https://api.opsway.eu/v1/webhooks/voice/42?user=3&amp;token=`)
+		assert.Contains(t, form["Twiml"], "Incident: API &lt;down&gt; Press 1 to acknowledge.")
+	})
+
+	t.Run("places call without acknowledge prompt", func(t *testing.T) {
+		_, err := sender.Call(ctx, "+4587654321", "Maintenance started", nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "<Response><Say>Maintenance started</Say></Response>", form["Twiml"])
+	})
+}
+
+func TestCallback(t *testing.T) {
+	config := phone.Config{
+		CallbackBaseURL: "https://api.opsway.eu/",
+		CallbackSecret:  "callback-secret",
+	}
+
+	ack := phone.Acknowledgement{IncidentID: 42, UserID: 3}
+
+	callbackURL := phone.CallbackURL(config, ack)
+	token := callbackURL[strings.Index(callbackURL, "token=")+len("token="):]
+
+	assert.True(t, strings.HasPrefix(callbackURL, "https://api.opsway.eu/v1/webhooks/voice/42?user=3&token="))
+	assert.True(t, phone.VerifyCallback(config, ack, token))
+	assert.False(t, phone.VerifyCallback(config, phone.Acknowledgement{IncidentID: 43, UserID: 3}, token))
+	assert.False(t, phone.VerifyCallback(config, phone.Acknowledgement{IncidentID: 42, UserID: 4}, token))
+	assert.False(t, phone.VerifyCallback(phone.Config{CallbackSecret: "other"}, ack, token))
+}
+
+func TestFakeSender(t *testing.T) {
+	ctx := context.Background()
+	sender := phone.NewFakeSender()
+	ack := &phone.Acknowledgement{IncidentID: 7, UserID: 3}
+
+	_, err := sender.SendSMS(ctx, "+4511111111", "hello")
+	require.NoError(t, err)
+
+	_, err = sender.Call(ctx, "+4522222222", "call", ack)
+	require.NoError(t, err)
+
+	assert.Equal(t, []phone.SMS{{To: "+4511111111", Body: "hello"}}, sender.Messages())
+	assert.Equal(t, []phone.Call{{To: "+4522222222", Message: "call", Ack: ack}}, sender.Calls())
+}
@@ -0,0 +1,99 @@
+package phone
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// How long the provider gets to respond, so a hung request does not hold up
+// the alerts queued behind it
+const requestTimeout = 10 * time.Second
+
+type TwilioSender struct {
+	config Config
+	client *http.Client
+}
+
+// Sends messages and places calls through the Twilio REST API, or any
+// provider compatible with it.
+func NewTwilioSender(config Config) Sender {
+	return &TwilioSender{
+		config: config,
+		client: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+func (s *TwilioSender) SendSMS(ctx context.Context, to string, body string) (int, error) {
+	return s.post(ctx, "Messages.json", url.Values{
+		"To":   {to},
+		"From": {s.config.FromNumber},
+		"Body": {body},
+	})
+}
+
+func (s *TwilioSender) Call(ctx context.Context, to string, message string, ack *Acknowledgement) (int, error) {
+	return s.post(ctx, "Calls.json", url.Values{
+		"To":    {to},
+		"From":  {s.config.FromNumber},
+		"Twiml": {CallTwiML(s.config, message, ack)},
+	})
+}
+
+func (s *TwilioSender) post(ctx context.Context, resource string, form url.Values) (int, error) {
+	endpoint := fmt.Sprintf(
+		"%s/2010-04-01/Accounts/%s/%s",
+		strings.TrimSuffix(s.config.APIBaseURL, "/"),
+		url.PathEscape(s.config.AccountSID),
+		resource,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(s.config.AccountSID, s.config.AuthToken)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return resp.StatusCode, nil
+}
+
+// SayTwiML returns instructions that read the message and hang up.
+func SayTwiML(message string) string {
+	return CallTwiML(Config{}, message, nil)
+}
+
+// CallTwiML returns the instructions for a voice call. When an acknowledgement
+// is given the message is read while waiting for a keypress, which is posted
+// to the callback URL of the incident.
+func CallTwiML(config Config, message string, ack *Acknowledgement) string {
+	var b strings.Builder
+
+	b.WriteString("<Response>")
+
+	if ack != nil {
+		b.WriteString(`<Gather numDigits="1" method="POST" action="`)
+		_ = xml.EscapeText(&b, []byte(CallbackURL(config, *ack)))
+		b.WriteString(`"><Say>`)
+		_ = xml.EscapeText(&b, []byte(message))
+		b.WriteString(" Press 1 to acknowledge.</Say></Gather>")
+	} else {
+		b.WriteString("<Say>")
+		_ = xml.EscapeText(&b, []byte(message))
+		b.WriteString("</Say>")
+	}
+
+	b.WriteString("</Response>")
+
+	return b.String()
+}
@@ -0,0 +1,93 @@
+package postmortem
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opsway-io/backend/internal/entities"
+)
+
+const markdownTimeFormat = "2006-01-02 15:04 MST"
+
+// Markdown renders the postmortem as a Markdown document
+func Markdown(p *entities.Postmortem) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Postmortem: %s\n\n", p.Title)
+
+	b.WriteString("## Impact\n\n")
+	fmt.Fprintf(&b, "- **Started:** %s\n", p.ImpactStartedAt.UTC().Format(markdownTimeFormat))
+	if p.ImpactEndedAt != nil {
+		fmt.Fprintf(&b, "- **Ended:** %s\n", p.ImpactEndedAt.UTC().Format(markdownTimeFormat))
+		fmt.Fprintf(&b, "- **Duration:** %s\n", p.ImpactEndedAt.Sub(p.ImpactStartedAt).Round(time.Minute))
+	}
+	b.WriteString("\n")
+
+	if p.Summary != nil && *p.Summary != "" {
+		fmt.Fprintf(&b, "## Summary\n\n%s\n\n", *p.Summary)
+	}
+
+	if p.RootCause != nil && *p.RootCause != "" {
+		fmt.Fprintf(&b, "## Root cause\n\n%s\n\n", *p.RootCause)
+	}
+
+	if len(p.ContributingFactors) > 0 {
+		b.WriteString("## Contributing factors\n\n")
+		for _, f := range p.ContributingFactors {
+			fmt.Fprintf(&b, "- %s\n", f)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(p.Timeline) > 0 {
+		b.WriteString("## Timeline\n\n")
+		b.WriteString("| Time | Event |\n")
+		b.WriteString("| --- | --- |\n")
+		for _, e := range p.Timeline {
+			fmt.Fprintf(&b, "| %s | %s |\n", e.At.UTC().Format(markdownTimeFormat), escapeTableCell(e.Description))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(p.ActionItems) > 0 {
+		b.WriteString("## Action items\n\n")
+		for _, item := range p.ActionItems {
+			check := " "
+			if item.Completed {
+				check = "x"
+			}
+
+			fmt.Fprintf(&b, "- [%s] %s", check, item.Description)
+
+			var details []string
+			if item.Owner != nil {
+				details = append(details, "owner: "+ownerName(item.Owner))
+			}
+			if item.DueAt != nil {
+				details = append(details, "due: "+item.DueAt.UTC().Format("2006-01-02"))
+			}
+			if len(details) > 0 {
+				fmt.Fprintf(&b, " (%s)", strings.Join(details, ", "))
+			}
+
+			b.WriteString("\n")
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+func ownerName(u *entities.User) string {
+	if u.DisplayName != nil && *u.DisplayName != "" {
+		return *u.DisplayName
+	}
+
+	return u.Name
+}
+
+func escapeTableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+
+	return strings.ReplaceAll(s, "\n", " ")
+}
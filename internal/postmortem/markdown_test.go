@@ -0,0 +1,70 @@
+package postmortem_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/opsway-io/backend/internal/entities"
+	"github.com/opsway-io/backend/internal/postmortem"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdown(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+	due := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	summary := "The API was unavailable."
+	rootCause := "A bad deploy."
+	displayName := "Jane Doe"
+
+	p := &entities.Postmortem{
+		Title:               "API is down",
+		Summary:             &summary,
+		RootCause:           &rootCause,
+		ContributingFactors: pq.StringArray{"No canary"},
+		ImpactStartedAt:     start,
+		ImpactEndedAt:       &end,
+		Timeline: []entities.PostmortemTimelineEntry{
+			{At: start, Kind: entities.PostmortemTimelineEntryKindOpened, Description: "Incident opened | API"},
+		},
+		ActionItems: []entities.PostmortemActionItem{
+			{Description: "Add canary deploys", DueAt: &due, Owner: &entities.User{Name: "jane", DisplayName: &displayName}},
+			{Description: "Write runbook", Completed: true},
+		},
+	}
+
+	expected := `# Postmortem: API is down
+
+## Impact
+
+- **Started:** 2024-01-01 12:00 UTC
+- **Ended:** 2024-01-01 13:30 UTC
+- **Duration:** 1h30m0s
+
+## Summary
+
+The API was unavailable.
+
+## Root cause
+
+A bad deploy.
+
+## Contributing factors
+
+- No canary
+
+## Timeline
+
+| Time | Event |
+| --- | --- |
+| 2024-01-01 12:00 UTC | Incident opened \| API |
+
+## Action items
+
+- [ ] Add canary deploys (owner: Jane Doe, due: 2024-02-01)
+- [x] Write runbook
+`
+
+	assert.Equal(t, expected, postmortem.Markdown(p))
+}
@@ -0,0 +1,138 @@
+package postmortem
+
+import (
+	"context"
+	"errors"
+
+	"github.com/opsway-io/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+var ErrNotFound = errors.New("postmortem not found")
+
+type Repository interface {
+	GetByIncidentID(ctx context.Context, teamID, incidentID uint) (*entities.Postmortem, error)
+	GetPublishedByMonitorIDs(ctx context.Context, monitorIDs []uint, limit int) ([]entities.Postmortem, error)
+	Create(ctx context.Context, postmortem *entities.Postmortem) error
+	Update(ctx context.Context, postmortem *entities.Postmortem) error
+	Delete(ctx context.Context, teamID, incidentID uint) error
+}
+
+type RepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &RepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *RepositoryImpl) GetByIncidentID(ctx context.Context, teamID, incidentID uint) (*entities.Postmortem, error) {
+	var postmortem entities.Postmortem
+	err := r.db.WithContext(ctx).
+		Preload("ActionItems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id asc")
+		}).
+		Preload("ActionItems.Owner").
+		Where("team_id = ? AND incident_id = ?", teamID, incidentID).
+		First(&postmortem).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &postmortem, nil
+}
+
+func (r *RepositoryImpl) GetPublishedByMonitorIDs(ctx context.Context, monitorIDs []uint, limit int) ([]entities.Postmortem, error) {
+	if len(monitorIDs) == 0 {
+		return []entities.Postmortem{}, nil
+	}
+
+	var postmortems []entities.Postmortem
+	err := r.db.WithContext(ctx).
+		Joins("JOIN incidents ON incidents.id = postmortems.incident_id").
+		Where("incidents.monitor_id IN ? AND postmortems.published = ?", monitorIDs, true).
+		Order("postmortems.published_at desc").
+		Limit(limit).
+		Find(&postmortems).Error
+	if err != nil {
+		return nil, err
+	}
+	return postmortems, nil
+}
+
+func (r *RepositoryImpl) Create(ctx context.Context, postmortem *entities.Postmortem) error {
+	return r.db.WithContext(ctx).Omit("ActionItems.Owner").Create(postmortem).Error
+}
+
+// Update saves the postmortem and replaces its action items with the ones
+// on the postmortem, items without an ID are created.
+func (r *RepositoryImpl) Update(ctx context.Context, postmortem *entities.Postmortem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(&entities.Postmortem{}).
+			Where("team_id = ? AND id = ?", postmortem.TeamID, postmortem.ID).
+			Select("Title", "Summary", "RootCause", "ContributingFactors", "ImpactStartedAt", "ImpactEndedAt", "Timeline", "Published", "PublishedAt").
+			Updates(postmortem)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		keep := []uint{0}
+		for _, item := range postmortem.ActionItems {
+			keep = append(keep, item.ID)
+		}
+
+		if err := tx.
+			Where("postmortem_id = ? AND id NOT IN ?", postmortem.ID, keep).
+			Delete(&entities.PostmortemActionItem{}).Error; err != nil {
+			return err
+		}
+
+		for i := range postmortem.ActionItems {
+			item := &postmortem.ActionItems[i]
+			item.PostmortemID = postmortem.ID
+
+			if item.ID == 0 {
+				if err := tx.Omit("Owner").Create(item).Error; err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			result := tx.
+				Model(&entities.PostmortemActionItem{}).
+				Where("postmortem_id = ? AND id = ?", postmortem.ID, item.ID).
+				Select("Description", "OwnerUserID", "DueAt", "Completed").
+				Updates(item)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return ErrNotFound
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *RepositoryImpl) Delete(ctx context.Context, teamID, incidentID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("team_id = ? AND incident_id = ?", teamID, incidentID).
+		Delete(&entities.Postmortem{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package postmortem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opsway-io/backend/internal/check"
+	"github.com/opsway-io/backend/internal/entities"
+	"github.com/opsway-io/backend/internal/incident"
+	"github.com/opsway-io/backend/internal/llm"
+)
+
+var (
+	ErrIncidentNotResolved = errors.New("incident is not resolved")
+	ErrAlreadyExists       = errors.New("postmortem already exists")
+)
+
+// Upper bound of failed checks fetched when building the timeline
+const maxTimelineChecks = 500
+
+type Service interface {
+	GetByIncidentID(ctx context.Context, teamID, incidentID uint) (*entities.Postmortem, error)
+	GetPublishedByMonitorIDs(ctx context.Context, monitorIDs []uint, limit int) ([]entities.Postmortem, error)
+	Create(ctx context.Context, incident *entities.Incident) (*entities.Postmortem, error)
+	Update(ctx context.Context, postmortem *entities.Postmortem) error
+	Publish(ctx context.Context, postmortem *entities.Postmortem) error
+	Unpublish(ctx context.Context, postmortem *entities.Postmortem) error
+	Delete(ctx context.Context, teamID, incidentID uint) error
+	DraftSummary(ctx context.Context, postmortem *entities.Postmortem) error
+}
+
+type ServiceImpl struct {
+	repository      Repository
+	incidentService incident.Service
+	checkService    check.Service
+	llmClient       llm.Client
+}
+
+func NewService(repository Repository, incidentService incident.Service, checkService check.Service, llmClient llm.Client) Service {
+	return &ServiceImpl{
+		repository:      repository,
+		incidentService: incidentService,
+		checkService:    checkService,
+		llmClient:       llmClient,
+	}
+}
+
+func (s *ServiceImpl) GetByIncidentID(ctx context.Context, teamID, incidentID uint) (*entities.Postmortem, error) {
+	return s.repository.GetByIncidentID(ctx, teamID, incidentID)
+}
+
+func (s *ServiceImpl) GetPublishedByMonitorIDs(ctx context.Context, monitorIDs []uint, limit int) ([]entities.Postmortem, error) {
+	return s.repository.GetPublishedByMonitorIDs(ctx, monitorIDs, limit)
+}
+
+// Create creates a postmortem for a resolved incident, with the impact window
+// and timeline prefilled from the incident.
+func (s *ServiceImpl) Create(ctx context.Context, in *entities.Incident) (*entities.Postmortem, error) {
+	if !in.Resolved {
+		return nil, ErrIncidentNotResolved
+	}
+
+	if _, err := s.repository.GetByIncidentID(ctx, in.TeamID, in.ID); err == nil {
+		return nil, ErrAlreadyExists
+	} else if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	impactEnd := in.UpdatedAt
+	if in.ResolvedAt != nil {
+		impactEnd = *in.ResolvedAt
+	}
+
+	comments, err := s.incidentService.GetComments(ctx, in.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates, err := s.incidentService.GetUpdates(ctx, in.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var failedChecks []check.Check
+	if in.MonitorID != nil {
+		// Failing checks before the incident was opened are part of the impact
+		checks, err := s.checkService.GetFailedByMonitorIDBetween(ctx, *in.MonitorID, in.CreatedAt.Add(-time.Hour), impactEnd, maxTimelineChecks)
+		if err != nil {
+			return nil, err
+		}
+
+		failedChecks = *checks
+	}
+
+	impactStart := in.CreatedAt
+	for _, c := range failedChecks {
+		if c.CreatedAt.Before(impactStart) {
+			impactStart = c.CreatedAt
+		}
+	}
+
+	postmortem := &entities.Postmortem{
+		TeamID:          in.TeamID,
+		IncidentID:      in.ID,
+		Title:           in.Title,
+		RootCause:       in.RootCauseAnalysis,
+		ImpactStartedAt: impactStart,
+		ImpactEndedAt:   &impactEnd,
+		Timeline:        BuildTimeline(in, comments, updates, failedChecks),
+	}
+
+	if err := s.repository.Create(ctx, postmortem); err != nil {
+		return nil, err
+	}
+
+	return postmortem, nil
+}
+
+func (s *ServiceImpl) Update(ctx context.Context, postmortem *entities.Postmortem) error {
+	return s.repository.Update(ctx, postmortem)
+}
+
+func (s *ServiceImpl) Publish(ctx context.Context, postmortem *entities.Postmortem) error {
+	now := time.Now()
+	postmortem.Published = true
+	postmortem.PublishedAt = &now
+
+	return s.repository.Update(ctx, postmortem)
+}
+
+func (s *ServiceImpl) Unpublish(ctx context.Context, postmortem *entities.Postmortem) error {
+	postmortem.Published = false
+	postmortem.PublishedAt = nil
+
+	return s.repository.Update(ctx, postmortem)
+}
+
+func (s *ServiceImpl) Delete(ctx context.Context, teamID, incidentID uint) error {
+	return s.repository.Delete(ctx, teamID, incidentID)
+}
+
+// DraftSummary replaces the summary of the postmortem with one drafted by the
+// LLM from the rest of the postmortem.
+func (s *ServiceImpl) DraftSummary(ctx context.Context, postmortem *entities.Postmortem) error {
+	summary, err := s.llmClient.GeneratePostmortemSummary(ctx, summaryPrompt(postmortem))
+	if err != nil {
+		return err
+	}
+
+	summary = strings.TrimSpace(summary)
+	postmortem.Summary = &summary
+
+	return s.repository.Update(ctx, postmortem)
+}
+
+func summaryPrompt(p *entities.Postmortem) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Incident: %s\n", p.Title)
+	fmt.Fprintf(&b, "Impact started: %s\n", p.ImpactStartedAt.UTC().Format(time.RFC3339))
+	if p.ImpactEndedAt != nil {
+		fmt.Fprintf(&b, "Impact ended: %s\n", p.ImpactEndedAt.UTC().Format(time.RFC3339))
+	}
+	if p.RootCause != nil {
+		fmt.Fprintf(&b, "Root cause: %s\n", *p.RootCause)
+	}
+	for _, f := range p.ContributingFactors {
+		fmt.Fprintf(&b, "Contributing factor: %s\n", f)
+	}
+
+	b.WriteString("Timeline:\n")
+	for _, e := range p.Timeline {
+		fmt.Fprintf(&b, "- %s %s\n", e.At.UTC().Format(time.RFC3339), e.Description)
+	}
+
+	b.WriteString("Write the postmortem summary.")
+
+	return b.String()
+}
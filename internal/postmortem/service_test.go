@@ -0,0 +1,147 @@
+package postmortem_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/opsway-io/backend/internal/check"
+	checkMocks "github.com/opsway-io/backend/internal/check/mocks"
+	"github.com/opsway-io/backend/internal/entities"
+	incidentMocks "github.com/opsway-io/backend/internal/incident/mocks"
+	llmMocks "github.com/opsway-io/backend/internal/llm/mocks"
+	"github.com/opsway-io/backend/internal/postmortem"
+	"github.com/opsway-io/backend/internal/postmortem/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Create(t *testing.T) {
+	ctx := context.Background()
+	openedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ackedAt := openedAt.Add(5 * time.Minute)
+	resolvedAt := openedAt.Add(30 * time.Minute)
+	monitorID := uint(7)
+
+	t.Run("prefills impact window and timeline", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		incidentSvc := incidentMocks.NewService(t)
+		checkSvc := checkMocks.NewService(t)
+		svc := postmortem.NewService(repo, incidentSvc, checkSvc, llmMocks.NewClient(t))
+
+		in := &entities.Incident{
+			ID:             1,
+			TeamID:         2,
+			MonitorID:      &monitorID,
+			Title:          "API is down",
+			Resolved:       true,
+			CreatedAt:      openedAt,
+			AcknowledgedAt: &ackedAt,
+			ResolvedAt:     &resolvedAt,
+		}
+
+		repo.On("GetByIncidentID", ctx, uint(2), uint(1)).Return(nil, postmortem.ErrNotFound)
+		incidentSvc.On("GetComments", ctx, uint(1)).Return([]entities.IncidentComment{
+			{Content: "Rolling back the deploy", CreatedAt: openedAt.Add(10 * time.Minute)},
+		}, nil)
+		incidentSvc.On("GetUpdates", ctx, uint(1)).Return([]entities.IncidentUpdate{
+			{Status: entities.IncidentStatusIdentified, Message: "Bad deploy", Public: true, CreatedAt: openedAt.Add(8 * time.Minute)},
+			{Status: entities.IncidentStatusMonitoring, Message: "Watching error rates", CreatedAt: openedAt.Add(12 * time.Minute)},
+		}, nil)
+		checkSvc.On("GetFailedByMonitorIDBetween", ctx, monitorID, openedAt.Add(-time.Hour), resolvedAt, mock.Anything).Return(&[]check.Check{
+			{Location: "eu-central", StatusCode: 503, CreatedAt: openedAt.Add(-2 * time.Minute)},
+			{Location: "eu-central", StatusCode: 503, CreatedAt: openedAt.Add(-time.Minute)},
+			{Location: "us-east", StatusCode: 0, CreatedAt: openedAt.Add(-time.Minute)},
+		}, nil)
+		repo.On("Create", ctx, mock.Anything).Return(nil)
+
+		p, err := svc.Create(ctx, in)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint(2), p.TeamID)
+		assert.Equal(t, uint(1), p.IncidentID)
+		assert.Equal(t, "API is down", p.Title)
+		assert.Equal(t, openedAt.Add(-2*time.Minute), p.ImpactStartedAt)
+		assert.Equal(t, resolvedAt, *p.ImpactEndedAt)
+
+		kinds := make([]entities.PostmortemTimelineEntryKind, len(p.Timeline))
+		for i, e := range p.Timeline {
+			kinds[i] = e.Kind
+		}
+		assert.Equal(t, []entities.PostmortemTimelineEntryKind{
+			entities.PostmortemTimelineEntryKindFailedCheck,
+			entities.PostmortemTimelineEntryKindFailedCheck,
+			entities.PostmortemTimelineEntryKindOpened,
+			entities.PostmortemTimelineEntryKindAcknowledged,
+			entities.PostmortemTimelineEntryKindUpdate,
+			entities.PostmortemTimelineEntryKindComment,
+			entities.PostmortemTimelineEntryKindUpdate,
+			entities.PostmortemTimelineEntryKindResolved,
+		}, kinds)
+		assert.Equal(t, "Check from eu-central failed with status code 503", p.Timeline[0].Description)
+		assert.Equal(t, "Check from us-east failed without a response", p.Timeline[1].Description)
+		assert.Equal(t, "Identified: Bad deploy", p.Timeline[4].Description)
+		assert.False(t, p.Timeline[4].IsInternal())
+		assert.Equal(t, "Rolling back the deploy", p.Timeline[5].Description)
+		assert.True(t, p.Timeline[5].IsInternal())
+		assert.Equal(t, "Monitoring: Watching error rates", p.Timeline[6].Description)
+		assert.True(t, p.Timeline[6].IsInternal())
+	})
+
+	t.Run("rejects unresolved incidents", func(t *testing.T) {
+		svc := postmortem.NewService(mocks.NewRepository(t), incidentMocks.NewService(t), checkMocks.NewService(t), llmMocks.NewClient(t))
+
+		_, err := svc.Create(ctx, &entities.Incident{ID: 1, TeamID: 2})
+
+		assert.ErrorIs(t, err, postmortem.ErrIncidentNotResolved)
+	})
+
+	t.Run("rejects a second postmortem", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		svc := postmortem.NewService(repo, incidentMocks.NewService(t), checkMocks.NewService(t), llmMocks.NewClient(t))
+
+		repo.On("GetByIncidentID", ctx, uint(2), uint(1)).Return(&entities.Postmortem{ID: 3}, nil)
+
+		_, err := svc.Create(ctx, &entities.Incident{ID: 1, TeamID: 2, Resolved: true})
+
+		assert.ErrorIs(t, err, postmortem.ErrAlreadyExists)
+	})
+}
+
+func TestService_DraftSummary(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("saves the drafted summary", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		llmClient := llmMocks.NewClient(t)
+		svc := postmortem.NewService(repo, incidentMocks.NewService(t), checkMocks.NewService(t), llmClient)
+
+		p := &entities.Postmortem{ID: 1, Title: "API is down"}
+
+		llmClient.On("GeneratePostmortemSummary", ctx, mock.MatchedBy(func(prompt string) bool {
+			return assert.Contains(t, prompt, "API is down")
+		})).Return("  The API was down.\n", nil)
+		repo.On("Update", ctx, p).Return(nil)
+
+		err := svc.DraftSummary(ctx, p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "The API was down.", *p.Summary)
+	})
+
+	t.Run("keeps the summary when drafting fails", func(t *testing.T) {
+		llmClient := llmMocks.NewClient(t)
+		svc := postmortem.NewService(mocks.NewRepository(t), incidentMocks.NewService(t), checkMocks.NewService(t), llmClient)
+
+		p := &entities.Postmortem{ID: 1, Title: "API is down"}
+
+		llmClient.On("GeneratePostmortemSummary", ctx, mock.Anything).Return("", errors.New("unavailable"))
+
+		err := svc.DraftSummary(ctx, p)
+
+		assert.Error(t, err)
+		assert.Nil(t, p.Summary)
+	})
+}
@@ -0,0 +1,101 @@
+package postmortem
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opsway-io/backend/internal/check"
+	"github.com/opsway-io/backend/internal/entities"
+)
+
+// BuildTimeline prefills the timeline of a postmortem from the history of the
+// incident. Only the first failed check of each location is included to keep
+// the timeline readable. Comments and non public updates are marked internal,
+// so they are left out when the postmortem is published.
+func BuildTimeline(
+	incident *entities.Incident,
+	comments []entities.IncidentComment,
+	updates []entities.IncidentUpdate,
+	failedChecks []check.Check,
+) []entities.PostmortemTimelineEntry {
+	timeline := []entities.PostmortemTimelineEntry{
+		{
+			At:          incident.CreatedAt,
+			Kind:        entities.PostmortemTimelineEntryKindOpened,
+			Description: fmt.Sprintf("Incident opened: %s", incident.Title),
+		},
+	}
+
+	seenLocations := map[string]bool{}
+	for _, c := range failedChecks {
+		if seenLocations[c.Location] {
+			continue
+		}
+		seenLocations[c.Location] = true
+
+		description := fmt.Sprintf("Check from %s failed without a response", c.Location)
+		switch {
+		case c.StatusCode != 0:
+			description = fmt.Sprintf("Check from %s failed with status code %d", c.Location, c.StatusCode)
+		case c.Error != "":
+			description = fmt.Sprintf("Check from %s failed: %s", c.Location, c.Error)
+		}
+
+		timeline = append(timeline, entities.PostmortemTimelineEntry{
+			At:          c.CreatedAt,
+			Kind:        entities.PostmortemTimelineEntryKindFailedCheck,
+			Description: description,
+		})
+	}
+
+	if incident.AcknowledgedAt != nil {
+		timeline = append(timeline, entities.PostmortemTimelineEntry{
+			At:          *incident.AcknowledgedAt,
+			Kind:        entities.PostmortemTimelineEntryKindAcknowledged,
+			Description: "Incident acknowledged",
+		})
+	}
+
+	for _, u := range updates {
+		timeline = append(timeline, entities.PostmortemTimelineEntry{
+			At:          u.CreatedAt,
+			Kind:        entities.PostmortemTimelineEntryKindUpdate,
+			Description: fmt.Sprintf("%s: %s", statusLabel(u.Status), u.Message),
+			Internal:    !u.Public,
+		})
+	}
+
+	for _, c := range comments {
+		timeline = append(timeline, entities.PostmortemTimelineEntry{
+			At:          c.CreatedAt,
+			Kind:        entities.PostmortemTimelineEntryKindComment,
+			Description: c.Content,
+			Internal:    true,
+		})
+	}
+
+	if incident.ResolvedAt != nil {
+		timeline = append(timeline, entities.PostmortemTimelineEntry{
+			At:          *incident.ResolvedAt,
+			Kind:        entities.PostmortemTimelineEntryKindResolved,
+			Description: "Incident resolved",
+		})
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].At.Before(timeline[j].At)
+	})
+
+	return timeline
+}
+
+// statusLabel turns INVESTIGATING into Investigating
+func statusLabel(status entities.IncidentStatus) string {
+	s := strings.ToLower(string(status))
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:]
+}
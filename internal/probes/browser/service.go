@@ -6,12 +6,12 @@ import (
 	"time"
 
 	"github.com/chromedp/chromedp"
-	"github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
 	"github.com/pkg/errors"
 )
 
 type Service interface {
-	Probe(ctx context.Context, url string, scriptJSON string, timeout time.Duration) (*http.Result, error)
+	Probe(ctx context.Context, url string, scriptJSON string, timeout time.Duration) (*probes.Result, error)
 }
 
 type ServiceImpl struct{}
@@ -26,7 +26,7 @@ type Action struct {
 	Value    string `json:"value"`
 }
 
-func (s *ServiceImpl) Probe(ctx context.Context, url string, scriptJSON string, timeout time.Duration) (*http.Result, error) {
+func (s *ServiceImpl) Probe(ctx context.Context, url string, scriptJSON string, timeout time.Duration) (*probes.Result, error) {
 	// Parse the actions from JSON script
 	var actions []Action
 	if scriptJSON != "" {
@@ -67,23 +67,25 @@ func (s *ServiceImpl) Probe(ctx context.Context, url string, scriptJSON string,
 		}
 	}
 
-	if err := chromedp.Run(timeoutCtx, tasks); err != nil {
-		return nil, errors.Wrap(err, "failed to execute browser actions")
-	}
+	err := chromedp.Run(timeoutCtx, tasks)
 
 	duration := time.Since(start)
 
-	res := &http.Result{
-		Response: http.Response{
-			StatusCode: 200,
-			Body:       []byte("OK"),
-		},
-		Timing: http.Timing{
-			Phases: http.TimingPhases{
+	res := &probes.Result{
+		Timing: probes.Timing{
+			Phases: probes.TimingPhases{
 				Total: duration,
 			},
 		},
 	}
 
+	if err != nil {
+		res.Fail(errors.Wrap(err, "failed to execute browser actions"))
+
+		return res, nil
+	}
+
+	res.Success = true
+
 	return res, nil
 }
@@ -0,0 +1,206 @@
+package dns
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	xhttp "net/http"
+	"os"
+	"strings"
+
+	"github.com/opsway-io/backend/internal/probes"
+)
+
+const (
+	ProtocolUDP = "UDP"
+	ProtocolTCP = "TCP"
+	// DNS over TLS
+	ProtocolDoT = "DOT"
+	// DNS over HTTPS
+	ProtocolDoH = "DOH"
+)
+
+var Protocols = []string{ProtocolUDP, ProtocolTCP, ProtocolDoT, ProtocolDoH}
+
+// Largest message the nameservers are told they can answer with over UDP
+const maxUDPSize = 4096
+
+// Configuration of the system resolver, which names the nameservers that also
+// resolve internal and split-horizon names
+const resolvConfPath = "/etc/resolv.conf"
+
+// Nameserver asked when the system resolver names none, like the resolver of
+// the standard library does
+const defaultSystemNameserver = "127.0.0.1"
+
+// systemNameserver returns the first nameserver of the resolver configuration
+func systemNameserver(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return defaultSystemNameserver
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			// Zones of link-local addresses are not supported by the dialer
+			return strings.SplitN(fields[1], "%", 2)[0]
+		}
+	}
+
+	return defaultSystemNameserver
+}
+
+// exchange sends the query to the nameserver over the protocol and returns
+// the raw response
+func exchange(ctx context.Context, query []byte, nameserver, protocol string) ([]byte, error) {
+	switch protocol {
+	case ProtocolUDP:
+		return exchangeUDP(ctx, query, withDefaultPort(nameserver, "53"))
+	case ProtocolTCP:
+		return exchangeTCP(ctx, query, withDefaultPort(nameserver, "53"))
+	case ProtocolDoT:
+		return exchangeDoT(ctx, query, withDefaultPort(nameserver, "853"))
+	case ProtocolDoH:
+		return exchangeDoH(ctx, query, dohURL(nameserver))
+	default:
+		return nil, fmt.Errorf("unsupported protocol: %s", protocol)
+	}
+}
+
+func exchangeUDP(ctx context.Context, query []byte, address string) ([]byte, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if _, err := conn.Write(query); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, maxUDPSize)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf[:n], nil
+}
+
+func exchangeTCP(ctx context.Context, query []byte, address string) ([]byte, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return exchangeStream(ctx, conn, query)
+}
+
+func exchangeDoT(ctx context.Context, query []byte, address string) ([]byte, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := tls.Dialer{
+		Config: &tls.Config{
+			ServerName: host,
+			MinVersion: tls.VersionTLS12,
+		},
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return exchangeStream(ctx, conn, query)
+}
+
+// exchangeStream sends the query over a stream connection, on which messages
+// are prefixed with their length
+func exchangeStream(ctx context.Context, conn net.Conn, query []byte) ([]byte, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	msg := binary.BigEndian.AppendUint16(make([]byte, 0, len(query)+2), uint16(len(query)))
+	msg = append(msg, query...)
+
+	if _, err := conn.Write(msg); err != nil {
+		return nil, err
+	}
+
+	var length uint16
+	if err := binary.Read(conn, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+
+	resp := make([]byte, length)
+	if _, err := io.ReadFull(conn, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func exchangeDoH(ctx context.Context, query []byte, url string) ([]byte, error) {
+	req, err := xhttp.NewRequestWithContext(ctx, xhttp.MethodPost, url, bytes.NewReader(query))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+
+	resp, err := xhttp.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != xhttp.StatusOK {
+		return nil, &probes.Error{
+			Kind:    probes.ErrorKindBadResponse,
+			Message: fmt.Sprintf("DNS over HTTPS endpoint returned status %d", resp.StatusCode),
+		}
+	}
+
+	// A DNS message is never larger than 64 KiB
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+}
+
+// withDefaultPort adds the port to the address of the nameserver if it has none
+func withDefaultPort(address, port string) string {
+	if _, _, err := net.SplitHostPort(address); err == nil {
+		return address
+	}
+
+	return net.JoinHostPort(strings.Trim(address, "[]"), port)
+}
+
+// dohURL returns the URL of the DNS over HTTPS endpoint, the nameserver may be
+// given as the host of an endpoint at the standard path
+func dohURL(nameserver string) string {
+	if strings.HasPrefix(nameserver, "https://") {
+		return nameserver
+	}
+
+	return "https://" + nameserver + "/dns-query"
+}
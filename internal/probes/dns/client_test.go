@@ -0,0 +1,32 @@
+package dns
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_systemNameserver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the first nameserver", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "resolv.conf")
+		require.NoError(t, os.WriteFile(path, []byte("# internal resolvers\nsearch corp.example\nnameserver 10.0.0.2\nnameserver 10.0.0.3\n"), 0o600))
+
+		assert.Equal(t, "10.0.0.2", systemNameserver(path))
+	})
+
+	t.Run("drops the zone of link-local addresses", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "resolv.conf")
+		require.NoError(t, os.WriteFile(path, []byte("nameserver fe80::1%eth0\n"), 0o600))
+
+		assert.Equal(t, "fe80::1", systemNameserver(path))
+	})
+
+	t.Run("falls back to the local nameserver", func(t *testing.T) {
+		assert.Equal(t, defaultSystemNameserver, systemNameserver(filepath.Join(t.TempDir(), "missing")))
+	})
+}
@@ -0,0 +1,115 @@
+package dns
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/opsway-io/backend/internal/probes"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// CAA records are not known to dnsmessage
+const typeCAA dnsmessage.Type = 257
+
+var recordTypes = map[string]dnsmessage.Type{
+	"A":     dnsmessage.TypeA,
+	"AAAA":  dnsmessage.TypeAAAA,
+	"CNAME": dnsmessage.TypeCNAME,
+	"MX":    dnsmessage.TypeMX,
+	"TXT":   dnsmessage.TypeTXT,
+	"NS":    dnsmessage.TypeNS,
+	"SOA":   dnsmessage.TypeSOA,
+	"CAA":   typeCAA,
+	"SRV":   dnsmessage.TypeSRV,
+}
+
+var rcodeNames = map[dnsmessage.RCode]string{
+	dnsmessage.RCodeSuccess:        "NOERROR",
+	dnsmessage.RCodeFormatError:    "FORMERR",
+	dnsmessage.RCodeServerFailure:  "SERVFAIL",
+	dnsmessage.RCodeNameError:      "NXDOMAIN",
+	dnsmessage.RCodeNotImplemented: "NOTIMP",
+	dnsmessage.RCodeRefused:        "REFUSED",
+}
+
+func recordTypeName(t dnsmessage.Type) string {
+	for name, recordType := range recordTypes {
+		if recordType == t {
+			return name
+		}
+	}
+
+	return fmt.Sprintf("TYPE%d", t)
+}
+
+func rcodeName(rcode dnsmessage.RCode) string {
+	if name, ok := rcodeNames[rcode]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("RCODE%d", rcode)
+}
+
+// newRecord converts a resource of the answer section to a record, resources
+// of types that cannot be looked up are skipped
+func newRecord(r dnsmessage.Resource) (probes.DNSRecord, bool) {
+	var value string
+
+	switch body := r.Body.(type) {
+	case *dnsmessage.AResource:
+		value = netip.AddrFrom4(body.A).String()
+	case *dnsmessage.AAAAResource:
+		value = netip.AddrFrom16(body.AAAA).String()
+	case *dnsmessage.CNAMEResource:
+		value = nameString(body.CNAME)
+	case *dnsmessage.MXResource:
+		value = fmt.Sprintf("%d %s", body.Pref, nameString(body.MX))
+	case *dnsmessage.TXTResource:
+		value = strings.Join(body.TXT, "")
+	case *dnsmessage.NSResource:
+		value = nameString(body.NS)
+	case *dnsmessage.SOAResource:
+		value = fmt.Sprintf("%s %s %d %d %d %d %d", nameString(body.NS), nameString(body.MBox), body.Serial, body.Refresh, body.Retry, body.Expire, body.MinTTL)
+	case *dnsmessage.SRVResource:
+		value = fmt.Sprintf("%d %d %d %s", body.Priority, body.Weight, body.Port, nameString(body.Target))
+	case *dnsmessage.UnknownResource:
+		if body.Type != typeCAA {
+			return probes.DNSRecord{}, false
+		}
+
+		caa, ok := caaString(body.Data)
+		if !ok {
+			return probes.DNSRecord{}, false
+		}
+
+		value = caa
+	default:
+		return probes.DNSRecord{}, false
+	}
+
+	return probes.DNSRecord{
+		Name:  nameString(r.Header.Name),
+		Type:  recordTypeName(r.Header.Type),
+		TTL:   r.Header.TTL,
+		Value: value,
+	}, true
+}
+
+// caaString formats the data of a CAA record as flags, tag and quoted value,
+// such as `0 issue "letsencrypt.org"`
+func caaString(data []byte) (string, bool) {
+	if len(data) < 2 || len(data) < 2+int(data[1]) {
+		return "", false
+	}
+
+	flags := data[0]
+	tag := string(data[2 : 2+data[1]])
+	value := string(data[2+data[1]:])
+
+	return fmt.Sprintf("%d %s %q", flags, tag, value), true
+}
+
+func nameString(name dnsmessage.Name) string {
+	return strings.TrimSuffix(name.String(), ".")
+}
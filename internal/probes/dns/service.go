@@ -2,86 +2,205 @@ package dns
 
 import (
 	"context"
-	"net"
+	"fmt"
+	"math/rand/v2"
+	"slices"
 	"strings"
 	"time"
 
-	probeHttp "github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
+type Config struct {
+	// Nameserver asked when the monitor does not name one, the nameserver of
+	// the system resolver is asked when empty
+	Nameserver string `mapstructure:"nameserver"`
+}
+
+// Options of a lookup, the zero value looks up the A records with the default
+// nameserver over UDP
+type Options struct {
+	RecordType string
+	// Address of the nameserver, or the URL of the endpoint for DNS over HTTPS
+	Nameserver string
+	Protocol   string
+}
+
 type Service interface {
-	Probe(ctx context.Context, target string, timeout time.Duration) (*probeHttp.Result, error)
+	Probe(ctx context.Context, target string, opts Options, timeout time.Duration) (*probes.Result, error)
 }
 
-type ServiceImpl struct{}
+type ServiceImpl struct {
+	config Config
+}
 
-func NewService() Service {
-	return &ServiceImpl{}
+func NewService(config Config) Service {
+	return &ServiceImpl{
+		config: config,
+	}
 }
 
-func (s *ServiceImpl) Probe(ctx context.Context, target string, timeout time.Duration) (*probeHttp.Result, error) {
-	start := time.Now()
+// Probe looks up the records of the target, lookups that do not find any
+// records of the requested type are failed results. Only invalid options
+// return an error.
+func (s *ServiceImpl) Probe(ctx context.Context, target string, opts Options, timeout time.Duration) (*probes.Result, error) {
+	host, recordType := parseTarget(target)
+	if opts.RecordType != "" {
+		recordType = strings.ToUpper(opts.RecordType)
+	}
+
+	qtype, ok := recordTypes[recordType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported record type: %s", recordType)
+	}
+
+	protocol := strings.ToUpper(opts.Protocol)
+	if protocol == "" {
+		protocol = ProtocolUDP
+	}
+
+	if !slices.Contains(Protocols, protocol) {
+		return nil, fmt.Errorf("unsupported protocol: %s", protocol)
+	}
+
+	nameserver := opts.Nameserver
+	if nameserver == "" {
+		nameserver = s.config.Nameserver
+	}
+
+	if nameserver == "" {
+		nameserver = systemNameserver(resolvConfPath)
+	}
+
+	// Queries over HTTPS use ID 0 so that they can be cached
+	var id uint16
+	if protocol != ProtocolDoH {
+		id = uint16(rand.N(1 << 16))
+	}
+
+	query, err := newQuery(id, host, qtype)
+	if err != nil {
+		return nil, err
+	}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-    recordType := "A"
-    host := target
-    
-    // Simple parsing if format is host?type=MX
-    if strings.Contains(target, "?type=") {
-        parts := strings.Split(target, "?type=")
-        host = parts[0]
-        recordType = strings.ToUpper(parts[1])
-    }
-
-	resolver := net.Resolver{}
-    var err error
-
-    switch recordType {
-    case "MX":
-        _, err = resolver.LookupMX(timeoutCtx, host)
-    case "TXT":
-        _, err = resolver.LookupTXT(timeoutCtx, host)
-    case "CNAME":
-        _, err = resolver.LookupCNAME(timeoutCtx, host)
-    case "AAAA":
-        // go net package handles both IPv4 and IPv6 for LookupIP
-        var ips []net.IPAddr
-        ips, err = resolver.LookupIPAddr(timeoutCtx, host)
-        hasAAAA := false
-        for _, ip := range ips {
-            if ip.IP.To4() == nil {
-                hasAAAA = true
-                break
-            }
-        }
-        if err == nil && !hasAAAA {
-            err = &net.DNSError{Err: "no AAAA records found", Name: host}
-        }
-    default:
-        _, err = resolver.LookupIPAddr(timeoutCtx, host)
-    }
-	
+	start := time.Now()
+
+	resp, err := exchange(timeoutCtx, query, nameserver, protocol)
+
+	// Truncated answers over UDP are asked for again over TCP
+	var msg dnsmessage.Message
+	if err == nil {
+		err = msg.Unpack(resp)
+		if err == nil && msg.Truncated && protocol == ProtocolUDP {
+			resp, err = exchange(timeoutCtx, query, nameserver, ProtocolTCP)
+			if err == nil {
+				err = msg.Unpack(resp)
+			}
+		}
+	}
+
 	duration := time.Since(start)
 
-	res := &probeHttp.Result{
-		Timing: probeHttp.Timing{
-			Phases: probeHttp.TimingPhases{
+	res := &probes.Result{
+		Timing: probes.Timing{
+			Phases: probes.TimingPhases{
 				DNSLookup: duration,
 				Total:     duration,
 			},
 		},
-		Response: probeHttp.Response{
-			StatusCode: 200,
-			Body:       []byte("OK"),
+		Metadata: map[string]string{
+			"record_type": recordType,
+			"nameserver":  nameserver,
+			"protocol":    protocol,
 		},
 	}
 
 	if err != nil {
-		res.Response.StatusCode = 503
-		res.Response.Body = []byte(err.Error())
+		res.Fail(err)
+
+		return res, nil
+	}
+
+	if msg.ID != id {
+		res.FailWithKind(probes.ErrorKindBadResponse, fmt.Errorf("response ID %d does not match query ID %d", msg.ID, id))
+
+		return res, nil
+	}
+
+	res.DNS = &probes.DNSResponse{
+		RCode:   rcodeName(msg.RCode),
+		Records: []probes.DNSRecord{},
+	}
+
+	found := false
+	for _, answer := range msg.Answers {
+		record, ok := newRecord(answer)
+		if !ok {
+			continue
+		}
+
+		res.DNS.Records = append(res.DNS.Records, record)
+		found = found || answer.Header.Type == qtype
+	}
+
+	switch {
+	case msg.RCode != dnsmessage.RCodeSuccess:
+		res.FailWithKind(probes.ErrorKindDNS, fmt.Errorf("lookup %s %s: %s", recordType, host, res.DNS.RCode))
+	case !found:
+		res.FailWithKind(probes.ErrorKindDNS, fmt.Errorf("lookup %s %s: no records found", recordType, host))
+	default:
+		res.Success = true
 	}
 
 	return res, nil
 }
+
+// parseTarget splits the record type off targets of the form host?type=MX,
+// which monitors used before they had DNS settings
+func parseTarget(target string) (host string, recordType string) {
+	host, recordType, ok := strings.Cut(target, "?type=")
+	if !ok {
+		return target, "A"
+	}
+
+	return host, strings.ToUpper(recordType)
+}
+
+func newQuery(id uint16, host string, qtype dnsmessage.Type) ([]byte, error) {
+	name, err := dnsmessage.NewName(strings.TrimSuffix(host, ".") + ".")
+	if err != nil {
+		return nil, fmt.Errorf("invalid host: %w", err)
+	}
+
+	// Allow answers larger than the 512 bytes of plain DNS over UDP
+	var opt dnsmessage.ResourceHeader
+	if err := opt.SetEDNS0(maxUDPSize, dnsmessage.RCodeSuccess, false); err != nil {
+		return nil, err
+	}
+
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:               id,
+			RecursionDesired: true,
+		},
+		Questions: []dnsmessage.Question{
+			{
+				Name:  name,
+				Type:  qtype,
+				Class: dnsmessage.ClassINET,
+			},
+		},
+		Additionals: []dnsmessage.Resource{
+			{
+				Header: opt,
+				Body:   &dnsmessage.OPTResource{},
+			},
+		},
+	}
+
+	return msg.Pack()
+}
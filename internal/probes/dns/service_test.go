@@ -0,0 +1,199 @@
+package dns_test
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/opsway-io/backend/internal/probes"
+	"github.com/opsway-io/backend/internal/probes/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// answer responds to the queries for example.com, other names do not exist
+func answer(t *testing.T, query []byte) []byte {
+	t.Helper()
+
+	var msg dnsmessage.Message
+	require.NoError(t, msg.Unpack(query))
+
+	q := msg.Questions[0]
+	resp := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:                 msg.ID,
+			Response:           true,
+			RecursionAvailable: true,
+		},
+		Questions: msg.Questions,
+	}
+
+	if q.Name.String() != "example.com." {
+		resp.RCode = dnsmessage.RCodeNameError
+
+		b, err := resp.Pack()
+		require.NoError(t, err)
+
+		return b
+	}
+
+	header := dnsmessage.ResourceHeader{
+		Name:  q.Name,
+		Type:  q.Type,
+		Class: dnsmessage.ClassINET,
+		TTL:   300,
+	}
+
+	switch q.Type {
+	case dnsmessage.TypeA:
+		resp.Answers = []dnsmessage.Resource{
+			{Header: header, Body: &dnsmessage.AResource{A: [4]byte{10, 0, 0, 5}}},
+		}
+	case dnsmessage.TypeTXT:
+		resp.Answers = []dnsmessage.Resource{
+			{Header: header, Body: &dnsmessage.TXTResource{TXT: []string{"v=spf1 -all"}}},
+		}
+	case dnsmessage.TypeMX:
+		resp.Answers = []dnsmessage.Resource{
+			{Header: header, Body: &dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mail.example.com.")}},
+		}
+	case dnsmessage.Type(257):
+		resp.Answers = []dnsmessage.Resource{
+			{Header: header, Body: &dnsmessage.UnknownResource{Type: 257, Data: append([]byte{0, 5}, "issueletsencrypt.org"...)}},
+		}
+	}
+
+	b, err := resp.Pack()
+	require.NoError(t, err)
+
+	return b
+}
+
+func serveUDP(t *testing.T) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			_, _ = conn.WriteTo(answer(t, buf[:n]), addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func serveTCP(t *testing.T) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			var length uint16
+			if err := binary.Read(conn, binary.BigEndian, &length); err != nil {
+				conn.Close()
+				continue
+			}
+
+			query := make([]byte, length)
+			if _, err := io.ReadFull(conn, query); err != nil {
+				conn.Close()
+				continue
+			}
+
+			resp := answer(t, query)
+			_, _ = conn.Write(binary.BigEndian.AppendUint16(nil, uint16(len(resp))))
+			_, _ = conn.Write(resp)
+			conn.Close()
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+func TestDNSProbeService(t *testing.T) {
+	t.Parallel()
+
+	svc := dns.NewService(dns.Config{})
+	udp := serveUDP(t)
+	tcp := serveTCP(t)
+
+	t.Run("returns the A records over UDP", func(t *testing.T) {
+		res, err := svc.Probe(context.Background(), "example.com", dns.Options{Nameserver: udp}, time.Second)
+		require.NoError(t, err)
+
+		assert.True(t, res.Success)
+		assert.Equal(t, "NOERROR", res.DNS.RCode)
+		assert.Equal(t, []probes.DNSRecord{
+			{Name: "example.com", Type: "A", TTL: 300, Value: "10.0.0.5"},
+		}, res.DNS.Records)
+		assert.Equal(t, "A", res.Metadata["record_type"])
+	})
+
+	t.Run("returns the TXT records over TCP", func(t *testing.T) {
+		res, err := svc.Probe(context.Background(), "example.com", dns.Options{RecordType: "txt", Nameserver: tcp, Protocol: "tcp"}, time.Second)
+		require.NoError(t, err)
+
+		assert.True(t, res.Success)
+		assert.Equal(t, "v=spf1 -all", res.DNS.Records[0].Value)
+	})
+
+	t.Run("reads the record type from the target", func(t *testing.T) {
+		res, err := svc.Probe(context.Background(), "example.com?type=MX", dns.Options{Nameserver: udp}, time.Second)
+		require.NoError(t, err)
+
+		assert.True(t, res.Success)
+		assert.Equal(t, "10 mail.example.com", res.DNS.Records[0].Value)
+	})
+
+	t.Run("formats CAA records", func(t *testing.T) {
+		res, err := svc.Probe(context.Background(), "example.com", dns.Options{RecordType: "CAA", Nameserver: udp}, time.Second)
+		require.NoError(t, err)
+
+		assert.True(t, res.Success)
+		assert.Equal(t, `0 issue "letsencrypt.org"`, res.DNS.Records[0].Value)
+	})
+
+	t.Run("fails when there are no records of the type", func(t *testing.T) {
+		res, err := svc.Probe(context.Background(), "example.com", dns.Options{RecordType: "AAAA", Nameserver: udp}, time.Second)
+		require.NoError(t, err)
+
+		assert.False(t, res.Success)
+		assert.Equal(t, probes.ErrorKindDNS, res.Error.Kind)
+		assert.Empty(t, res.DNS.Records)
+	})
+
+	t.Run("fails when the name does not exist", func(t *testing.T) {
+		res, err := svc.Probe(context.Background(), "missing.example.com", dns.Options{Nameserver: udp}, time.Second)
+		require.NoError(t, err)
+
+		assert.False(t, res.Success)
+		assert.Equal(t, probes.ErrorKindDNS, res.Error.Kind)
+		assert.Equal(t, "NXDOMAIN", res.DNS.RCode)
+	})
+
+	t.Run("rejects unsupported record types", func(t *testing.T) {
+		_, err := svc.Probe(context.Background(), "example.com", dns.Options{RecordType: "PTR", Nameserver: udp}, time.Second)
+		assert.Error(t, err)
+	})
+}
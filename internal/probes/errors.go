@@ -0,0 +1,79 @@
+package probes
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"syscall"
+)
+
+// ErrorKind classifies why a probe failed, so that for example an
+// authentication failure can be told apart from a network timeout
+type ErrorKind string
+
+const (
+	ErrorKindTimeout           ErrorKind = "TIMEOUT"
+	ErrorKindConnectionRefused ErrorKind = "CONNECTION_REFUSED"
+	ErrorKindDNS               ErrorKind = "DNS_FAILURE"
+	ErrorKindAuth              ErrorKind = "AUTH_FAILURE"
+	ErrorKindTLS               ErrorKind = "TLS_FAILURE"
+	// The target responded, but not as a healthy target does
+	ErrorKindBadResponse ErrorKind = "BAD_RESPONSE"
+	ErrorKindUnknown     ErrorKind = "UNKNOWN"
+)
+
+var ErrorKinds = []ErrorKind{
+	ErrorKindTimeout,
+	ErrorKindConnectionRefused,
+	ErrorKindDNS,
+	ErrorKindAuth,
+	ErrorKindTLS,
+	ErrorKindBadResponse,
+	ErrorKindUnknown,
+}
+
+type Error struct {
+	Kind    ErrorKind
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// ClassifyError tells the kind of the network level errors shared by all
+// protocols, errors it does not recognize are of ErrorKindUnknown. Errors that
+// wrap an *Error keep its kind.
+func ClassifyError(err error) ErrorKind {
+	var (
+		probeErr     *Error
+		dnsErr       *net.DNSError
+		netErr       net.Error
+		certErr      *tls.CertificateVerificationError
+		recordErr    tls.RecordHeaderError
+		alertErr     tls.AlertError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+	)
+
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &probeErr):
+		return probeErr.Kind
+	case errors.As(err, &dnsErr):
+		return ErrorKindDNS
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorKindTimeout
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return ErrorKindConnectionRefused
+	case errors.As(err, &certErr), errors.As(err, &recordErr), errors.As(err, &alertErr),
+		errors.As(err, &authorityErr), errors.As(err, &hostnameErr), errors.As(err, &invalidErr):
+		return ErrorKindTLS
+	default:
+		return ErrorKindUnknown
+	}
+}
@@ -0,0 +1,61 @@
+package probes_test
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/opsway-io/backend/internal/probes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want probes.ErrorKind
+	}{
+		{
+			name: "no error",
+			err:  nil,
+			want: "",
+		},
+		{
+			name: "DNS failure",
+			err:  &net.DNSError{Err: "no such host", Name: "example.invalid", IsNotFound: true},
+			want: probes.ErrorKindDNS,
+		},
+		{
+			name: "deadline exceeded",
+			err:  fmt.Errorf("ping: %w", context.DeadlineExceeded),
+			want: probes.ErrorKindTimeout,
+		},
+		{
+			name: "connection refused",
+			err:  &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+			want: probes.ErrorKindConnectionRefused,
+		},
+		{
+			name: "unknown certificate authority",
+			err:  x509.UnknownAuthorityError{},
+			want: probes.ErrorKindTLS,
+		},
+		{
+			name: "other error",
+			err:  errors.New("something went wrong"),
+			want: probes.ErrorKindUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, probes.ClassifyError(tt.err))
+		})
+	}
+}
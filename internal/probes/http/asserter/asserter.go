@@ -3,7 +3,7 @@ package asserter
 import (
 	"fmt"
 
-	"github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
 )
 
 type Rule struct {
@@ -21,7 +21,7 @@ type Rule struct {
 }
 
 type Asserter interface {
-	Assert(result *http.Result, rules []Rule) (ok []bool, err error)
+	Assert(result *probes.Result, rules []Rule) (ok []bool, err error)
 	IsRuleValid(rule Rule) error
 }
 
@@ -38,11 +38,17 @@ func New() *HTTPResultAsserter {
 			"TLS":           NewTLSAsserter(),
 			"RAW_BODY":      NewRawBodyAsserter(),
 			"JSON_BODY":     NewJSONBodyAsserter(),
+			"PROBE":         NewProbeAsserter(),
+			"ERROR":         NewErrorAsserter(),
+			"DNS_RECORD":    NewDNSRecordAsserter(),
+			"PING":          NewPingAsserter(),
+			"BANNER":        NewBannerAsserter(),
+			"QUERY":         NewQueryAsserter(),
 		},
 	}
 }
 
-func (a *HTTPResultAsserter) Assert(result *http.Result, rules []Rule) (ok []bool, err error) {
+func (a *HTTPResultAsserter) Assert(result *probes.Result, rules []Rule) (ok []bool, err error) {
 	if len(rules) == 0 {
 		return []bool{}, nil
 	}
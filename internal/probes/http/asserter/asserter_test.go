@@ -4,13 +4,13 @@ import (
 	"testing"
 	"time"
 
-	"github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestHTTPResultAsserter_Assert(t *testing.T) {
 	type args struct {
-		result *http.Result
+		result *probes.Result
 		rules  []Rule
 	}
 	tests := []struct {
@@ -22,7 +22,7 @@ func TestHTTPResultAsserter_Assert(t *testing.T) {
 		{
 			name: "No rules",
 			args: args{
-				result: &http.Result{},
+				result: &probes.Result{},
 				rules:  []Rule{},
 			},
 			wantOk:  []bool{},
@@ -47,9 +47,9 @@ func TestHTTPResultAsserter_Assert(t *testing.T) {
 		{
 			name: "Known source RESPONSE_TIME and valid rule passes",
 			args: args{
-				result: &http.Result{
-					Timing: http.Timing{
-						Phases: http.TimingPhases{
+				result: &probes.Result{
+					Timing: probes.Timing{
+						Phases: probes.TimingPhases{
 							Total: 100 * time.Millisecond,
 						},
 					},
@@ -69,7 +69,7 @@ func TestHTTPResultAsserter_Assert(t *testing.T) {
 		{
 			name: "Known source RESPONSE_TIME and invalid rule fails",
 			args: args{
-				result: &http.Result{},
+				result: &probes.Result{},
 				rules: []Rule{
 					{
 						Source:   "RESPONSE_TIME",
@@ -85,13 +85,13 @@ func TestHTTPResultAsserter_Assert(t *testing.T) {
 		{
 			name: "Response time and status code rules pass",
 			args: args{
-				result: &http.Result{
-					Timing: http.Timing{
-						Phases: http.TimingPhases{
+				result: &probes.Result{
+					Timing: probes.Timing{
+						Phases: probes.TimingPhases{
 							Total: 100 * time.Millisecond,
 						},
 					},
-					Response: http.Response{
+					HTTP: &probes.HTTPResponse{
 						StatusCode: 200,
 					},
 				},
@@ -115,7 +115,7 @@ func TestHTTPResultAsserter_Assert(t *testing.T) {
 		{
 			name: "Unknown source fails",
 			args: args{
-				result: &http.Result{},
+				result: &probes.Result{},
 				rules: []Rule{
 					{
 						Source: "UNKNOWN",
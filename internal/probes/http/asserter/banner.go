@@ -0,0 +1,148 @@
+package asserter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/opsway-io/backend/internal/probes"
+)
+
+/*
+	Assertions about the data read by TCP probes, the banner of the service
+	or its response to the payload sent.
+
+	The following operators are supported:
+		- Equal
+		- Not Equal
+		- Empty
+		- Not empty
+		- Contains
+		- Not contains
+		- Matches, the target being a regular expression
+		- Not matches, the target being a regular expression
+
+	Trailing line breaks are ignored when comparing for equality.
+*/
+
+var allowedBannerOperators = []string{
+	"EQUAL",
+	"NOT_EQUAL",
+	"EMPTY",
+	"NOT_EMPTY",
+	"CONTAINS",
+	"NOT_CONTAINS",
+	"MATCHES",
+	"NOT_MATCHES",
+}
+
+type BannerAsserter struct{}
+
+func NewBannerAsserter() *BannerAsserter {
+	return &BannerAsserter{}
+}
+
+func (a *BannerAsserter) Assert(result *probes.Result, rules []Rule) (ok []bool, err error) {
+	if len(rules) == 0 {
+		return []bool{}, nil
+	}
+
+	errs := isRulesValid(a, rules)
+	if !allErrorsNil(errs) {
+		return nil, fmt.Errorf("invalid rules: %v", errs)
+	}
+
+	ok = make([]bool, len(rules))
+
+	for i, rule := range rules {
+		ok[i] = a.assert(result, rule)
+	}
+
+	return ok, nil
+}
+
+func (a *BannerAsserter) IsRuleValid(rule Rule) error {
+	// Source must be "BANNER"
+	if ok := rule.Source == "BANNER"; !ok {
+		return fmt.Errorf("invalid source: %s", rule.Source)
+	}
+
+	// The property must be empty
+	if ok := rule.Property == ""; !ok {
+		return fmt.Errorf("property must be empty: %s", rule.Property)
+	}
+
+	// The operator must be one of the allowed operators
+	if ok := isStringInSlice(rule.Operator, allowedBannerOperators); !ok {
+		return fmt.Errorf("invalid operator: %s", rule.Operator)
+	}
+
+	// The target must be set for the following operators:
+	// - CONTAINS
+	// - NOT_CONTAINS
+	// Not for EQUAL and NOT_EQUAL because the target can be empty
+	if ok := rule.Operator == "CONTAINS" || rule.Operator == "NOT_CONTAINS"; ok {
+		if ok := rule.Target != ""; !ok {
+			return fmt.Errorf("target must be set for operator: %s", rule.Operator)
+		}
+	}
+
+	// The target must be empty for the following operators:
+	// - EMPTY
+	// - NOT_EMPTY
+	if ok := rule.Operator == "EMPTY" || rule.Operator == "NOT_EMPTY"; ok {
+		if ok := rule.Target == ""; !ok {
+			return fmt.Errorf("target must be empty for operator: %s", rule.Operator)
+		}
+	}
+
+	// The target must be a regular expression for the following operators:
+	// - MATCHES
+	// - NOT_MATCHES
+	if ok := rule.Operator == "MATCHES" || rule.Operator == "NOT_MATCHES"; ok {
+		if _, err := regexp.Compile(rule.Target); err != nil {
+			return fmt.Errorf("target must be a regular expression for operator: %s", rule.Operator)
+		}
+	}
+
+	return nil
+}
+
+func (a *BannerAsserter) assert(result *probes.Result, rule Rule) bool {
+	// Only TCP probes read from the connection
+	if result.TCP == nil {
+		return false
+	}
+
+	banner := string(result.TCP.Data)
+
+	switch rule.Operator {
+	case "EQUAL":
+		return strings.TrimRight(banner, "\r\n") == rule.Target
+	case "NOT_EQUAL":
+		return strings.TrimRight(banner, "\r\n") != rule.Target
+	case "EMPTY":
+		return banner == ""
+	case "NOT_EMPTY":
+		return banner != ""
+	case "CONTAINS":
+		return strings.Contains(banner, rule.Target)
+	case "NOT_CONTAINS":
+		return !strings.Contains(banner, rule.Target)
+	case "MATCHES":
+		return a.assertMatches(banner, rule)
+	case "NOT_MATCHES":
+		return !a.assertMatches(banner, rule)
+	default:
+		return false
+	}
+}
+
+func (a *BannerAsserter) assertMatches(banner string, rule Rule) bool {
+	re, err := regexp.Compile(rule.Target)
+	if err != nil {
+		return false
+	}
+
+	return re.MatchString(banner)
+}
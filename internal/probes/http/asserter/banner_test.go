@@ -0,0 +1,163 @@
+package asserter
+
+import (
+	"testing"
+
+	"github.com/opsway-io/backend/internal/probes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBannerAsserter_IsRuleValid(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		rule Rule
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid matches",
+			args: args{
+				rule: Rule{
+					Source:   "BANNER",
+					Operator: "MATCHES",
+					Target:   "^SSH-2\\.0-",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid regular expression",
+			args: args{
+				rule: Rule{
+					Source:   "BANNER",
+					Operator: "MATCHES",
+					Target:   "(",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "property must be empty",
+			args: args{
+				rule: Rule{
+					Source:   "BANNER",
+					Property: "LINE",
+					Operator: "EQUAL",
+					Target:   "",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "contains without target",
+			args: args{
+				rule: Rule{
+					Source:   "BANNER",
+					Operator: "CONTAINS",
+					Target:   "",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewBannerAsserter()
+			err := a.IsRuleValid(tt.args.rule)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBannerAsserter_Assert(t *testing.T) {
+	t.Parallel()
+
+	result := &probes.Result{
+		TCP: &probes.TCPResponse{
+			Data: []byte("SSH-2.0-OpenSSH_9.6\r\n"),
+		},
+	}
+
+	type args struct {
+		result *probes.Result
+		rules  []Rule
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOk  []bool
+		wantErr bool
+	}{
+		{
+			name: "EQUAL ignores the trailing line break",
+			args: args{
+				result: result,
+				rules: []Rule{
+					{
+						Source:   "BANNER",
+						Operator: "EQUAL",
+						Target:   "SSH-2.0-OpenSSH_9.6",
+					},
+				},
+			},
+			wantOk:  []bool{true},
+			wantErr: false,
+		},
+		{
+			name: "MATCHES and NOT_CONTAINS",
+			args: args{
+				result: result,
+				rules: []Rule{
+					{
+						Source:   "BANNER",
+						Operator: "MATCHES",
+						Target:   "^SSH-2\\.0-OpenSSH_\\d",
+					},
+					{
+						Source:   "BANNER",
+						Operator: "NOT_CONTAINS",
+						Target:   "OpenSSH",
+					},
+				},
+			},
+			wantOk:  []bool{true, false},
+			wantErr: false,
+		},
+		{
+			name: "NOT_EMPTY fails without data read",
+			args: args{
+				result: &probes.Result{},
+				rules: []Rule{
+					{
+						Source:   "BANNER",
+						Operator: "NOT_EMPTY",
+					},
+				},
+			},
+			wantOk:  []bool{false},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewBannerAsserter()
+			gotOk, err := a.Assert(tt.args.result, tt.args.rules)
+
+			assert.Equal(t, tt.wantOk, gotOk)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package asserter
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/opsway-io/backend/internal/probes"
+)
+
+/*
+	Assertions about the records found by a DNS lookup.
+
+	The property is the type of the records to assert on, such as A or TXT.
+	The following operators are supported:
+		- Equal, any record equals the target
+		- Not Equal, no record equals the target
+		- Contains, any record contains the target
+		- Not Contains, no record contains the target
+		- Empty, there are no records
+		- Not Empty, there are records
+*/
+
+var allowedDNSRecordOperators = []string{
+	"EQUAL",
+	"NOT_EQUAL",
+	"CONTAINS",
+	"NOT_CONTAINS",
+	"EMPTY",
+	"NOT_EMPTY",
+}
+
+type DNSRecordAsserter struct{}
+
+func NewDNSRecordAsserter() *DNSRecordAsserter {
+	return &DNSRecordAsserter{}
+}
+
+func (a *DNSRecordAsserter) Assert(result *probes.Result, rules []Rule) (ok []bool, err error) {
+	if len(rules) == 0 {
+		return []bool{}, nil
+	}
+
+	errs := isRulesValid(a, rules)
+	if !allErrorsNil(errs) {
+		return nil, fmt.Errorf("invalid rules: %v", errs)
+	}
+
+	ok = make([]bool, len(rules))
+
+	for i, rule := range rules {
+		ok[i] = a.assert(result, rule)
+	}
+
+	return ok, nil
+}
+
+func (a *DNSRecordAsserter) IsRuleValid(rule Rule) error {
+	// Source must be "DNS_RECORD"
+	if ok := rule.Source == "DNS_RECORD"; !ok {
+		return fmt.Errorf("invalid source: %s", rule.Source)
+	}
+
+	// The property must be a record type
+	if ok := isStringInSlice(rule.Property, probes.DNSRecordTypes); !ok {
+		return fmt.Errorf("unknown record type: %s", rule.Property)
+	}
+
+	// The operator must be one of the allowed operators
+	if ok := isStringInSlice(rule.Operator, allowedDNSRecordOperators); !ok {
+		return fmt.Errorf("unknown operator: %v", rule.Operator)
+	}
+
+	// The target must be empty for the following operators:
+	//	- EMPTY
+	//	- NOT_EMPTY
+	if ok := rule.Operator == "EMPTY" || rule.Operator == "NOT_EMPTY"; ok {
+		if ok := rule.Target == ""; !ok {
+			return fmt.Errorf("target must be empty: %s", rule.Target)
+		}
+	}
+
+	return nil
+}
+
+func (a *DNSRecordAsserter) assert(result *probes.Result, rule Rule) bool {
+	// Only DNS lookups have records
+	if result.DNS == nil {
+		return false
+	}
+
+	values := []string{}
+	for _, record := range result.DNS.Records {
+		if record.Type == rule.Property {
+			values = append(values, record.Value)
+		}
+	}
+
+	switch rule.Operator {
+	case "EQUAL":
+		return anyValue(values, rule.Target, isRecordValueEqual)
+	case "NOT_EQUAL":
+		return !anyValue(values, rule.Target, isRecordValueEqual)
+	case "CONTAINS":
+		return anyValue(values, rule.Target, strings.Contains)
+	case "NOT_CONTAINS":
+		return !anyValue(values, rule.Target, strings.Contains)
+	case "EMPTY":
+		return len(values) == 0
+	case "NOT_EMPTY":
+		return len(values) > 0
+	default:
+		return false
+	}
+}
+
+func anyValue(values []string, target string, match func(value, target string) bool) bool {
+	for _, value := range values {
+		if match(value, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRecordValueEqual compares addresses by value, so that IPv6 addresses match
+// however they are written, names may be written with the trailing dot
+func isRecordValueEqual(value, target string) bool {
+	valueAddr, valueErr := netip.ParseAddr(value)
+	targetAddr, targetErr := netip.ParseAddr(target)
+	if valueErr == nil && targetErr == nil {
+		return valueAddr == targetAddr
+	}
+
+	return value == strings.TrimSuffix(target, ".")
+}
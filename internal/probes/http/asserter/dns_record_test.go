@@ -0,0 +1,204 @@
+package asserter
+
+import (
+	"testing"
+
+	"github.com/opsway-io/backend/internal/probes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDNSRecordAsserter_IsRuleValid(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		rule Rule
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid rule",
+			args: args{
+				rule: Rule{
+					Source:   "DNS_RECORD",
+					Property: "TXT",
+					Operator: "CONTAINS",
+					Target:   "v=spf1",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown record type",
+			args: args{
+				rule: Rule{
+					Source:   "DNS_RECORD",
+					Property: "PTR",
+					Operator: "EQUAL",
+					Target:   "example.com",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "target not empty",
+			args: args{
+				rule: Rule{
+					Source:   "DNS_RECORD",
+					Property: "A",
+					Operator: "EMPTY",
+					Target:   "10.0.0.5",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewDNSRecordAsserter()
+			err := a.IsRuleValid(tt.args.rule)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDNSRecordAsserter_Assert(t *testing.T) {
+	t.Parallel()
+
+	result := &probes.Result{
+		DNS: &probes.DNSResponse{
+			Records: []probes.DNSRecord{
+				{Name: "example.com", Type: "A", Value: "10.0.0.5"},
+				{Name: "example.com", Type: "A", Value: "10.0.0.6"},
+				{Name: "example.com", Type: "AAAA", Value: "2001:db8::1"},
+				{Name: "example.com", Type: "TXT", Value: "v=spf1 include:_spf.example.com -all"},
+			},
+		},
+	}
+
+	type args struct {
+		result *probes.Result
+		rules  []Rule
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOk  []bool
+		wantErr bool
+	}{
+		{
+			name: "EQUAL passes when any record equals",
+			args: args{
+				result: result,
+				rules: []Rule{
+					{
+						Source:   "DNS_RECORD",
+						Property: "A",
+						Operator: "EQUAL",
+						Target:   "10.0.0.6",
+					},
+				},
+			},
+			wantOk:  []bool{true},
+			wantErr: false,
+		},
+		{
+			name: "EQUAL compares addresses by value",
+			args: args{
+				result: result,
+				rules: []Rule{
+					{
+						Source:   "DNS_RECORD",
+						Property: "AAAA",
+						Operator: "EQUAL",
+						Target:   "2001:0db8:0000::1",
+					},
+				},
+			},
+			wantOk:  []bool{true},
+			wantErr: false,
+		},
+		{
+			name: "NOT_EQUAL fails when any record equals",
+			args: args{
+				result: result,
+				rules: []Rule{
+					{
+						Source:   "DNS_RECORD",
+						Property: "A",
+						Operator: "NOT_EQUAL",
+						Target:   "10.0.0.5",
+					},
+				},
+			},
+			wantOk:  []bool{false},
+			wantErr: false,
+		},
+		{
+			name: "CONTAINS passes",
+			args: args{
+				result: result,
+				rules: []Rule{
+					{
+						Source:   "DNS_RECORD",
+						Property: "TXT",
+						Operator: "CONTAINS",
+						Target:   "v=spf1",
+					},
+				},
+			},
+			wantOk:  []bool{true},
+			wantErr: false,
+		},
+		{
+			name: "EMPTY passes without records of the type",
+			args: args{
+				result: result,
+				rules: []Rule{
+					{
+						Source:   "DNS_RECORD",
+						Property: "MX",
+						Operator: "EMPTY",
+					},
+				},
+			},
+			wantOk:  []bool{true},
+			wantErr: false,
+		},
+		{
+			name: "NOT_EMPTY fails without DNS response",
+			args: args{
+				result: &probes.Result{},
+				rules: []Rule{
+					{
+						Source:   "DNS_RECORD",
+						Property: "A",
+						Operator: "NOT_EMPTY",
+					},
+				},
+			},
+			wantOk:  []bool{false},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewDNSRecordAsserter()
+			gotOk, err := a.Assert(tt.args.result, tt.args.rules)
+
+			assert.Equal(t, tt.wantOk, gotOk)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
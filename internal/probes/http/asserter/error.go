@@ -0,0 +1,96 @@
+package asserter
+
+import (
+	"fmt"
+
+	"github.com/opsway-io/backend/internal/probes"
+)
+
+/*
+	Assertions about the kind of error a probe failed with.
+
+	The following operators are supported:
+		- Equal
+		- Not Equal
+	against the error kinds of the probes package.
+*/
+
+var allowedErrorOperators = []string{
+	"EQUAL",
+	"NOT_EQUAL",
+}
+
+type ErrorAsserter struct{}
+
+func NewErrorAsserter() *ErrorAsserter {
+	return &ErrorAsserter{}
+}
+
+func (a *ErrorAsserter) Assert(result *probes.Result, rules []Rule) (ok []bool, err error) {
+	if len(rules) == 0 {
+		return []bool{}, nil
+	}
+
+	errs := isRulesValid(a, rules)
+	if !allErrorsNil(errs) {
+		return nil, fmt.Errorf("invalid rules: %v", errs)
+	}
+
+	ok = make([]bool, len(rules))
+
+	for i, rule := range rules {
+		ok[i] = a.assert(result, rule)
+	}
+
+	return ok, nil
+}
+
+func (a *ErrorAsserter) IsRuleValid(rule Rule) error {
+	// Source must be "ERROR"
+	if ok := rule.Source == "ERROR"; !ok {
+		return fmt.Errorf("invalid source: %s", rule.Source)
+	}
+
+	// The property must be empty
+	if ok := rule.Property == ""; !ok {
+		return fmt.Errorf("property must be empty: %s", rule.Property)
+	}
+
+	// The operator must be one of the allowed operators
+	if ok := isStringInSlice(rule.Operator, allowedErrorOperators); !ok {
+		return fmt.Errorf("unknown operator: %v", rule.Operator)
+	}
+
+	// The target must be an error kind
+	if ok := isErrorKind(rule.Target); !ok {
+		return fmt.Errorf("unknown error kind: %s", rule.Target)
+	}
+
+	return nil
+}
+
+func (a *ErrorAsserter) assert(result *probes.Result, rule Rule) bool {
+	var kind probes.ErrorKind
+	if result.Error != nil {
+		kind = result.Error.Kind
+	}
+
+	switch rule.Operator {
+	case "EQUAL":
+		return kind == probes.ErrorKind(rule.Target)
+	case "NOT_EQUAL":
+		return kind != probes.ErrorKind(rule.Target)
+	default:
+		return false
+	}
+}
+
+func isErrorKind(str string) bool {
+	for _, kind := range probes.ErrorKinds {
+		if string(kind) == str {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,140 @@
+package asserter
+
+import (
+	"testing"
+
+	"github.com/opsway-io/backend/internal/probes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorAsserter_IsRuleValid(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		rule Rule
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid rule",
+			args: args{
+				rule: Rule{
+					Source:   "ERROR",
+					Operator: "EQUAL",
+					Target:   "AUTH_FAILURE",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown error kind",
+			args: args{
+				rule: Rule{
+					Source:   "ERROR",
+					Operator: "EQUAL",
+					Target:   "503",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewErrorAsserter()
+			err := a.IsRuleValid(tt.args.rule)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestErrorAsserter_Assert(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		result *probes.Result
+		rules  []Rule
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOk  []bool
+		wantErr bool
+	}{
+		{
+			name: "EQUAL passes",
+			args: args{
+				result: &probes.Result{
+					Error: &probes.Error{
+						Kind: probes.ErrorKindAuth,
+					},
+				},
+				rules: []Rule{
+					{
+						Source:   "ERROR",
+						Operator: "EQUAL",
+						Target:   "AUTH_FAILURE",
+					},
+				},
+			},
+			wantOk:  []bool{true},
+			wantErr: false,
+		},
+		{
+			name: "EQUAL fails",
+			args: args{
+				result: &probes.Result{
+					Error: &probes.Error{
+						Kind: probes.ErrorKindTimeout,
+					},
+				},
+				rules: []Rule{
+					{
+						Source:   "ERROR",
+						Operator: "EQUAL",
+						Target:   "AUTH_FAILURE",
+					},
+				},
+			},
+			wantOk:  []bool{false},
+			wantErr: false,
+		},
+		{
+			name: "NOT_EQUAL passes without error",
+			args: args{
+				result: &probes.Result{
+					Success: true,
+				},
+				rules: []Rule{
+					{
+						Source:   "ERROR",
+						Operator: "NOT_EQUAL",
+						Target:   "TIMEOUT",
+					},
+				},
+			},
+			wantOk:  []bool{true},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewErrorAsserter()
+			gotOk, err := a.Assert(tt.args.result, tt.args.rules)
+
+			assert.Equal(t, tt.wantOk, gotOk)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
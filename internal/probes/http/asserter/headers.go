@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
 )
 
 /*
@@ -38,7 +38,7 @@ func NewHeadersAsserter() *HeadersAsserter {
 	return &HeadersAsserter{}
 }
 
-func (a *HeadersAsserter) Assert(result *http.Result, rules []Rule) (ok []bool, err error) {
+func (a *HeadersAsserter) Assert(result *probes.Result, rules []Rule) (ok []bool, err error) {
 	if len(rules) == 0 {
 		return []bool{}, nil
 	}
@@ -99,7 +99,12 @@ func (a *HeadersAsserter) IsRuleValid(rule Rule) error {
 	return nil
 }
 
-func (a *HeadersAsserter) assert(result *http.Result, rule Rule) bool {
+func (a *HeadersAsserter) assert(result *probes.Result, rule Rule) bool {
+	// Only HTTP responses have headers
+	if result.HTTP == nil {
+		return false
+	}
+
 	switch rule.Operator {
 	case "EQUAL":
 		return a.assertEqual(result, rule)
@@ -122,29 +127,29 @@ func (a *HeadersAsserter) assert(result *http.Result, rule Rule) bool {
 	}
 }
 
-func (a *HeadersAsserter) assertEqual(result *http.Result, rule Rule) bool {
-	return result.Response.Header.Get(rule.Property) == rule.Target
+func (a *HeadersAsserter) assertEqual(result *probes.Result, rule Rule) bool {
+	return result.HTTP.Header.Get(rule.Property) == rule.Target
 }
 
-func (a *HeadersAsserter) assertNotEqual(result *http.Result, rule Rule) bool {
-	return result.Response.Header.Get(rule.Property) != rule.Target
+func (a *HeadersAsserter) assertNotEqual(result *probes.Result, rule Rule) bool {
+	return result.HTTP.Header.Get(rule.Property) != rule.Target
 }
 
-func (a *HeadersAsserter) assertEmpty(result *http.Result, rule Rule) bool {
-	return result.Response.Header.Get(rule.Property) == ""
+func (a *HeadersAsserter) assertEmpty(result *probes.Result, rule Rule) bool {
+	return result.HTTP.Header.Get(rule.Property) == ""
 }
 
-func (a *HeadersAsserter) assertNotEmpty(result *http.Result, rule Rule) bool {
-	return result.Response.Header.Get(rule.Property) != ""
+func (a *HeadersAsserter) assertNotEmpty(result *probes.Result, rule Rule) bool {
+	return result.HTTP.Header.Get(rule.Property) != ""
 }
 
-func (a *HeadersAsserter) assertGreaterThan(result *http.Result, rule Rule) bool {
+func (a *HeadersAsserter) assertGreaterThan(result *probes.Result, rule Rule) bool {
 	intTarget, ok := toInt(rule.Target)
 	if !ok {
 		return false
 	}
 
-	intResult, ok := toInt(result.Response.Header.Get(rule.Property))
+	intResult, ok := toInt(result.HTTP.Header.Get(rule.Property))
 	if !ok {
 		return false
 	}
@@ -152,13 +157,13 @@ func (a *HeadersAsserter) assertGreaterThan(result *http.Result, rule Rule) bool
 	return intResult > intTarget
 }
 
-func (a *HeadersAsserter) assertLessThan(result *http.Result, rule Rule) bool {
+func (a *HeadersAsserter) assertLessThan(result *probes.Result, rule Rule) bool {
 	intTarget, ok := toInt(rule.Target)
 	if !ok {
 		return false
 	}
 
-	intResult, ok := toInt(result.Response.Header.Get(rule.Property))
+	intResult, ok := toInt(result.HTTP.Header.Get(rule.Property))
 	if !ok {
 		return false
 	}
@@ -166,10 +171,10 @@ func (a *HeadersAsserter) assertLessThan(result *http.Result, rule Rule) bool {
 	return intResult < intTarget
 }
 
-func (a *HeadersAsserter) assertContains(result *http.Result, rule Rule) bool {
-	return strings.Contains(result.Response.Header.Get(rule.Property), rule.Target)
+func (a *HeadersAsserter) assertContains(result *probes.Result, rule Rule) bool {
+	return strings.Contains(result.HTTP.Header.Get(rule.Property), rule.Target)
 }
 
-func (a *HeadersAsserter) assertNotContains(result *http.Result, rule Rule) bool {
-	return !strings.Contains(result.Response.Header.Get(rule.Property), rule.Target)
+func (a *HeadersAsserter) assertNotContains(result *probes.Result, rule Rule) bool {
+	return !strings.Contains(result.HTTP.Header.Get(rule.Property), rule.Target)
 }
@@ -3,7 +3,7 @@ package asserter
 import (
 	"testing"
 
-	"github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -238,7 +238,7 @@ func TestHeadersAsserter_assert(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
-		result *http.Result
+		result *probes.Result
 		rules  []Rule
 	}
 	tests := []struct {
@@ -251,8 +251,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "invalid source",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Content-Type": {"application/json"},
 						},
@@ -273,8 +273,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "invalid operator",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Content-Type": {"application/json"},
 						},
@@ -295,8 +295,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "multiple valid rules",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Content-Type":   {"application/json"},
 							"Content-Length": {"100"},
@@ -337,8 +337,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid equal rule true",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Content-Type": {"application/json"},
 						},
@@ -359,8 +359,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid equal rule false",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Content-Type": {"application/xml"},
 						},
@@ -382,8 +382,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid not equal rule true",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Content-Type": {"application/json"},
 						},
@@ -404,8 +404,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid not equal rule false",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Content-Type": {"application/xml"},
 						},
@@ -427,8 +427,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid empty rule true #1",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{},
 					},
 				},
@@ -447,8 +447,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid empty rule true #2",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Content-Type": {""},
 						},
@@ -469,8 +469,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid empty rule false",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Content-Type": {"application/xml"},
 						},
@@ -492,8 +492,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid not empty rule true",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Content-Type": {"application/xml"},
 						},
@@ -514,8 +514,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid not empty rule false #1",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{},
 					},
 				},
@@ -534,8 +534,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid not empty rule false #2",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Content-Type": {""},
 						},
@@ -557,8 +557,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid greater than rule true",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Content-Length": {"100"},
 						},
@@ -579,8 +579,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid greater than rule false",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Content-Length": {"100"},
 						},
@@ -602,8 +602,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid less than rule true",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Content-Length": {"100"},
 						},
@@ -624,8 +624,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid less than rule false",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Content-Length": {"100"},
 						},
@@ -647,8 +647,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid contains rule true",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Server": {"nginx/1.19.0"},
 						},
@@ -669,8 +669,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid contains rule false",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Server": {"nginx/1.19.0"},
 						},
@@ -692,8 +692,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid not contains rule true",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Server": {"nginx/1.19.0"},
 						},
@@ -714,8 +714,8 @@ func TestHeadersAsserter_assert(t *testing.T) {
 		{
 			name: "valid not contains rule false",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Header: map[string][]string{
 							"Server": {"nginx/1.19.0"},
 						},
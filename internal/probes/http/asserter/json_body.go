@@ -6,7 +6,7 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
 	"github.com/yalp/jsonpath"
 )
 
@@ -49,7 +49,7 @@ func NewJSONBodyAsserter() *JSONBodyAsserter {
 	return &JSONBodyAsserter{}
 }
 
-func (a *JSONBodyAsserter) Assert(result *http.Result, rules []Rule) (ok []bool, err error) {
+func (a *JSONBodyAsserter) Assert(result *probes.Result, rules []Rule) (ok []bool, err error) {
 	if len(rules) == 0 {
 		return []bool{}, nil
 	}
@@ -130,9 +130,14 @@ func (a *JSONBodyAsserter) isJSONPath(path string) bool {
 	return err == nil
 }
 
-func (a *JSONBodyAsserter) assert(result *http.Result, rule Rule) bool {
+func (a *JSONBodyAsserter) assert(result *probes.Result, rule Rule) bool {
+	// Only HTTP responses have a body
+	if result.HTTP == nil {
+		return false
+	}
+
 	var unmarshalData interface{}
-	err := json.Unmarshal(result.Response.Body, &unmarshalData)
+	err := json.Unmarshal(result.HTTP.Body, &unmarshalData)
 	if err != nil {
 		return false
 	}
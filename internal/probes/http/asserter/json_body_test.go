@@ -3,7 +3,7 @@ package asserter
 import (
 	"testing"
 
-	"github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -62,7 +62,7 @@ func TestJSONBodyAsserter_assert(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
-		result *http.Result
+		result *probes.Result
 		rules  []Rule
 	}
 	tests := []struct {
@@ -74,7 +74,7 @@ func TestJSONBodyAsserter_assert(t *testing.T) {
 		{
 			name: "Valid json path EQUAL",
 			args: args{
-				result: &http.Result{Response: http.Response{Body: []byte(`{"name": "test"}`)}},
+				result: &probes.Result{HTTP: &probes.HTTPResponse{Body: []byte(`{"name": "test"}`)}},
 				rules: []Rule{{Source: "JSON_BODY", Property: "$.name", Operator: "EQUAL", Target: "test"}},
 			},
 			wantOk: []bool{true},
@@ -83,7 +83,7 @@ func TestJSONBodyAsserter_assert(t *testing.T) {
 		{
 			name: "Valid json path NOT_EQUAL",
 			args: args{
-				result: &http.Result{Response: http.Response{Body: []byte(`{"name": "test2"}`)}},
+				result: &probes.Result{HTTP: &probes.HTTPResponse{Body: []byte(`{"name": "test2"}`)}},
 				rules: []Rule{{Source: "JSON_BODY", Property: "$.name", Operator: "EQUAL", Target: "test"}},
 			},
 			wantOk: []bool{false},
@@ -92,7 +92,7 @@ func TestJSONBodyAsserter_assert(t *testing.T) {
 		{
 			name: "Invalid json body",
 			args: args{
-				result: &http.Result{Response: http.Response{Body: []byte(`invalid`)}},
+				result: &probes.Result{HTTP: &probes.HTTPResponse{Body: []byte(`invalid`)}},
 				rules: []Rule{{Source: "JSON_BODY", Property: "$.name", Operator: "EQUAL", Target: "test"}},
 			},
 			wantOk: []bool{false},
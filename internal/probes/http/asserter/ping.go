@@ -0,0 +1,139 @@
+package asserter
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/opsway-io/backend/internal/probes"
+)
+
+/*
+	Assertions about the statistics of ICMP echo requests.
+	The following operators are supported:
+		- Equal
+		- Not Equal
+		- Greater than
+		- Less than
+	on the following statistics:
+		- Packet loss, in percent
+		- Min round trip time, in milliseconds
+		- Average round trip time, in milliseconds
+		- Max round trip time, in milliseconds
+		- Jitter, in milliseconds
+*/
+
+var (
+	allowedPingProperties = []string{
+		"PACKET_LOSS",
+		"MIN_RTT",
+		"AVG_RTT",
+		"MAX_RTT",
+		"JITTER",
+	}
+
+	allowedPingOperators = []string{
+		"EQUAL",
+		"NOT_EQUAL",
+		"GREATER_THAN",
+		"LESS_THAN",
+	}
+)
+
+type PingAsserter struct{}
+
+func NewPingAsserter() *PingAsserter {
+	return &PingAsserter{}
+}
+
+func (a *PingAsserter) Assert(result *probes.Result, rules []Rule) (ok []bool, err error) {
+	if len(rules) == 0 {
+		return []bool{}, nil
+	}
+
+	errs := isRulesValid(a, rules)
+	if !allErrorsNil(errs) {
+		return nil, fmt.Errorf("invalid rules: %v", errs)
+	}
+
+	ok = make([]bool, len(rules))
+
+	for i, rule := range rules {
+		ok[i] = a.assert(result, rule)
+	}
+
+	return ok, nil
+}
+
+func (a *PingAsserter) IsRuleValid(rule Rule) error {
+	// Source must be "PING"
+	if ok := rule.Source == "PING"; !ok {
+		return fmt.Errorf("invalid source: %s", rule.Source)
+	}
+
+	// Property must be one of the ping properties
+	if ok := isStringInSlice(rule.Property, allowedPingProperties); !ok {
+		return fmt.Errorf("unknown property: %s", rule.Property)
+	}
+
+	// The operator must be one of the allowed operators
+	if ok := isStringInSlice(rule.Operator, allowedPingOperators); !ok {
+		return fmt.Errorf("unknown operator: %v", rule.Operator)
+	}
+
+	// The target must be an integer for all operators
+	if ok := isInt(rule.Target); !ok {
+		return errors.New("invalid target")
+	}
+
+	return nil
+}
+
+func (a *PingAsserter) assert(result *probes.Result, rule Rule) bool {
+	// Only ICMP probes send echo requests
+	if result.Ping == nil {
+		return false
+	}
+
+	var resultValue float64
+	switch rule.Property {
+	case "PACKET_LOSS":
+		resultValue = result.Ping.PacketLoss
+	case "MIN_RTT":
+		resultValue = durationToFractionalMilliseconds(result.Ping.MinRTT)
+	case "AVG_RTT":
+		resultValue = durationToFractionalMilliseconds(result.Ping.AvgRTT)
+	case "MAX_RTT":
+		resultValue = durationToFractionalMilliseconds(result.Ping.MaxRTT)
+	case "JITTER":
+		resultValue = durationToFractionalMilliseconds(result.Ping.Jitter)
+	default:
+		return false
+	}
+
+	targetInt, ok := toInt(rule.Target)
+	if !ok {
+		return false
+	}
+
+	target := float64(targetInt)
+
+	switch rule.Operator {
+	case "EQUAL":
+		return resultValue == target
+	case "NOT_EQUAL":
+		return resultValue != target
+	case "GREATER_THAN":
+		return resultValue > target
+	case "LESS_THAN":
+		return resultValue < target
+	default:
+		return false
+	}
+}
+
+// Round trip times are often below a millisecond, which would all be 0 when
+// truncated to whole milliseconds
+func durationToFractionalMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,146 @@
+package asserter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opsway-io/backend/internal/probes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPingAsserter_IsRuleValid(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		rule Rule
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid rule",
+			args: args{
+				rule: Rule{
+					Source:   "PING",
+					Property: "PACKET_LOSS",
+					Operator: "LESS_THAN",
+					Target:   "5",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown property",
+			args: args{
+				rule: Rule{
+					Source:   "PING",
+					Property: "TTL",
+					Operator: "LESS_THAN",
+					Target:   "5",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewPingAsserter()
+			err := a.IsRuleValid(tt.args.rule)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPingAsserter_Assert(t *testing.T) {
+	t.Parallel()
+
+	result := &probes.Result{
+		Ping: &probes.PingStats{
+			Sent:       10,
+			Received:   9,
+			PacketLoss: 10,
+			AvgRTT:     500 * time.Microsecond,
+			MaxRTT:     2 * time.Millisecond,
+		},
+	}
+
+	type args struct {
+		result *probes.Result
+		rules  []Rule
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOk  []bool
+		wantErr bool
+	}{
+		{
+			name: "PACKET_LOSS LESS_THAN fails",
+			args: args{
+				result: result,
+				rules: []Rule{
+					{
+						Source:   "PING",
+						Property: "PACKET_LOSS",
+						Operator: "LESS_THAN",
+						Target:   "5",
+					},
+				},
+			},
+			wantOk:  []bool{false},
+			wantErr: false,
+		},
+		{
+			name: "AVG_RTT GREATER_THAN compares fractional milliseconds",
+			args: args{
+				result: result,
+				rules: []Rule{
+					{
+						Source:   "PING",
+						Property: "AVG_RTT",
+						Operator: "GREATER_THAN",
+						Target:   "0",
+					},
+				},
+			},
+			wantOk:  []bool{true},
+			wantErr: false,
+		},
+		{
+			name: "MAX_RTT LESS_THAN fails without ping statistics",
+			args: args{
+				result: &probes.Result{},
+				rules: []Rule{
+					{
+						Source:   "PING",
+						Property: "MAX_RTT",
+						Operator: "LESS_THAN",
+						Target:   "100",
+					},
+				},
+			},
+			wantOk:  []bool{false},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewPingAsserter()
+			gotOk, err := a.Assert(tt.args.result, tt.args.rules)
+
+			assert.Equal(t, tt.wantOk, gotOk)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package asserter
+
+import (
+	"fmt"
+
+	"github.com/opsway-io/backend/internal/probes"
+)
+
+/*
+	Assertions about whether the probe succeeded, for any protocol.
+
+	The following operators are supported:
+		- Succeeded
+		- Failed
+*/
+
+var allowedProbeOperators = []string{
+	"SUCCEEDED",
+	"FAILED",
+}
+
+type ProbeAsserter struct{}
+
+func NewProbeAsserter() *ProbeAsserter {
+	return &ProbeAsserter{}
+}
+
+func (a *ProbeAsserter) Assert(result *probes.Result, rules []Rule) (ok []bool, err error) {
+	if len(rules) == 0 {
+		return []bool{}, nil
+	}
+
+	errs := isRulesValid(a, rules)
+	if !allErrorsNil(errs) {
+		return nil, fmt.Errorf("invalid rules: %v", errs)
+	}
+
+	ok = make([]bool, len(rules))
+
+	for i, rule := range rules {
+		ok[i] = a.assert(result, rule)
+	}
+
+	return ok, nil
+}
+
+func (a *ProbeAsserter) IsRuleValid(rule Rule) error {
+	// Source must be "PROBE"
+	if ok := rule.Source == "PROBE"; !ok {
+		return fmt.Errorf("invalid source: %s", rule.Source)
+	}
+
+	// The property must be empty
+	if ok := rule.Property == ""; !ok {
+		return fmt.Errorf("property must be empty: %s", rule.Property)
+	}
+
+	// The operator must be one of the allowed operators
+	if ok := isStringInSlice(rule.Operator, allowedProbeOperators); !ok {
+		return fmt.Errorf("unknown operator: %v", rule.Operator)
+	}
+
+	// The target must be empty
+	if ok := rule.Target == ""; !ok {
+		return fmt.Errorf("target must be empty: %s", rule.Target)
+	}
+
+	return nil
+}
+
+func (a *ProbeAsserter) assert(result *probes.Result, rule Rule) bool {
+	switch rule.Operator {
+	case "SUCCEEDED":
+		return result.Success
+	case "FAILED":
+		return !result.Success
+	default:
+		return false
+	}
+}
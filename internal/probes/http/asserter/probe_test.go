@@ -0,0 +1,142 @@
+package asserter
+
+import (
+	"testing"
+
+	"github.com/opsway-io/backend/internal/probes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbeAsserter_IsRuleValid(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		rule Rule
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid rule",
+			args: args{
+				rule: Rule{
+					Source:   "PROBE",
+					Operator: "SUCCEEDED",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid operator",
+			args: args{
+				rule: Rule{
+					Source:   "PROBE",
+					Operator: "EQUAL",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "target not empty",
+			args: args{
+				rule: Rule{
+					Source:   "PROBE",
+					Operator: "FAILED",
+					Target:   "200",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewProbeAsserter()
+			err := a.IsRuleValid(tt.args.rule)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProbeAsserter_Assert(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		result *probes.Result
+		rules  []Rule
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOk  []bool
+		wantErr bool
+	}{
+		{
+			name: "SUCCEEDED passes",
+			args: args{
+				result: &probes.Result{
+					Success: true,
+				},
+				rules: []Rule{
+					{
+						Source:   "PROBE",
+						Operator: "SUCCEEDED",
+					},
+				},
+			},
+			wantOk:  []bool{true},
+			wantErr: false,
+		},
+		{
+			name: "SUCCEEDED fails",
+			args: args{
+				result: &probes.Result{
+					Success: false,
+				},
+				rules: []Rule{
+					{
+						Source:   "PROBE",
+						Operator: "SUCCEEDED",
+					},
+				},
+			},
+			wantOk:  []bool{false},
+			wantErr: false,
+		},
+		{
+			name: "FAILED passes",
+			args: args{
+				result: &probes.Result{
+					Success: false,
+				},
+				rules: []Rule{
+					{
+						Source:   "PROBE",
+						Operator: "FAILED",
+					},
+				},
+			},
+			wantOk:  []bool{true},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewProbeAsserter()
+			gotOk, err := a.Assert(tt.args.result, tt.args.rules)
+
+			assert.Equal(t, tt.wantOk, gotOk)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package asserter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/opsway-io/backend/internal/probes"
+)
+
+/*
+	Assertions about the result of the query of database probes, such as the
+	replication lag of a replica or the depth of a queue.
+
+	The property is the column of the first row to assert on, the first column
+	when empty. For Redis, the keys of INFO are the columns.
+
+	The following operators are supported:
+		- Equal
+		- Not Equal
+		- Empty, also when there is no row
+		- Not empty
+		- Greater than, comparing numbers
+		- Less than, comparing numbers
+		- Contains
+		- Not contains
+*/
+
+var allowedQueryOperators = []string{
+	"EQUAL",
+	"NOT_EQUAL",
+	"EMPTY",
+	"NOT_EMPTY",
+	"GREATER_THAN",
+	"LESS_THAN",
+	"CONTAINS",
+	"NOT_CONTAINS",
+}
+
+type QueryAsserter struct{}
+
+func NewQueryAsserter() *QueryAsserter {
+	return &QueryAsserter{}
+}
+
+func (a *QueryAsserter) Assert(result *probes.Result, rules []Rule) (ok []bool, err error) {
+	if len(rules) == 0 {
+		return []bool{}, nil
+	}
+
+	errs := isRulesValid(a, rules)
+	if !allErrorsNil(errs) {
+		return nil, fmt.Errorf("invalid rules: %v", errs)
+	}
+
+	ok = make([]bool, len(rules))
+
+	for i, rule := range rules {
+		ok[i] = a.assert(result, rule)
+	}
+
+	return ok, nil
+}
+
+func (a *QueryAsserter) IsRuleValid(rule Rule) error {
+	// Source must be "QUERY"
+	if ok := rule.Source == "QUERY"; !ok {
+		return fmt.Errorf("invalid source: %s", rule.Source)
+	}
+
+	// The operator must be one of the allowed operators
+	if ok := isStringInSlice(rule.Operator, allowedQueryOperators); !ok {
+		return fmt.Errorf("invalid operator: %s", rule.Operator)
+	}
+
+	// The target must be set for the following operators:
+	// - CONTAINS
+	// - NOT_CONTAINS
+	// Not for EQUAL and NOT_EQUAL because the target can be empty
+	if ok := rule.Operator == "CONTAINS" || rule.Operator == "NOT_CONTAINS"; ok {
+		if ok := rule.Target != ""; !ok {
+			return fmt.Errorf("target must be set for operator: %s", rule.Operator)
+		}
+	}
+
+	// The target must be empty for the following operators:
+	// - EMPTY
+	// - NOT_EMPTY
+	if ok := rule.Operator == "EMPTY" || rule.Operator == "NOT_EMPTY"; ok {
+		if ok := rule.Target == ""; !ok {
+			return fmt.Errorf("target must be empty for operator: %s", rule.Operator)
+		}
+	}
+
+	// The target must be a number for the following operators:
+	// - GREATER_THAN
+	// - LESS_THAN
+	if ok := rule.Operator == "GREATER_THAN" || rule.Operator == "LESS_THAN"; ok {
+		if _, err := strconv.ParseFloat(rule.Target, 64); err != nil {
+			return fmt.Errorf("target must be a number for operator: %s", rule.Operator)
+		}
+	}
+
+	return nil
+}
+
+func (a *QueryAsserter) assert(result *probes.Result, rule Rule) bool {
+	// Only database probes run queries
+	if result.Query == nil {
+		return false
+	}
+
+	value, found := result.Query.Value(rule.Property)
+
+	switch rule.Operator {
+	case "EQUAL":
+		return found && value == rule.Target
+	case "NOT_EQUAL":
+		return found && value != rule.Target
+	case "EMPTY":
+		return value == ""
+	case "NOT_EMPTY":
+		return value != ""
+	case "GREATER_THAN":
+		return a.assertCompare(value, rule, func(v, t float64) bool { return v > t })
+	case "LESS_THAN":
+		return a.assertCompare(value, rule, func(v, t float64) bool { return v < t })
+	case "CONTAINS":
+		return found && strings.Contains(value, rule.Target)
+	case "NOT_CONTAINS":
+		return found && !strings.Contains(value, rule.Target)
+	default:
+		return false
+	}
+}
+
+func (a *QueryAsserter) assertCompare(value string, rule Rule, compare func(value, target float64) bool) bool {
+	target, err := strconv.ParseFloat(rule.Target, 64)
+	if err != nil {
+		return false
+	}
+
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return false
+	}
+
+	return compare(v, target)
+}
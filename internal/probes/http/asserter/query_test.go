@@ -0,0 +1,179 @@
+package asserter
+
+import (
+	"testing"
+
+	"github.com/opsway-io/backend/internal/probes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryAsserter_IsRuleValid(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		rule Rule
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid greater than on a column",
+			args: args{
+				rule: Rule{
+					Source:   "QUERY",
+					Property: "lag_seconds",
+					Operator: "GREATER_THAN",
+					Target:   "2.5",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "greater than requires a number",
+			args: args{
+				rule: Rule{
+					Source:   "QUERY",
+					Operator: "GREATER_THAN",
+					Target:   "many",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty with target",
+			args: args{
+				rule: Rule{
+					Source:   "QUERY",
+					Operator: "EMPTY",
+					Target:   "0",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewQueryAsserter()
+			err := a.IsRuleValid(tt.args.rule)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestQueryAsserter_Assert(t *testing.T) {
+	t.Parallel()
+
+	result := &probes.Result{
+		Query: &probes.QueryResult{
+			Columns: []string{"count", "state"},
+			Rows: [][]string{
+				{"12", "stuck"},
+				{"3", "running"},
+			},
+		},
+	}
+
+	type args struct {
+		result *probes.Result
+		rules  []Rule
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOk  []bool
+		wantErr bool
+	}{
+		{
+			name: "compares the first column of the first row",
+			args: args{
+				result: result,
+				rules: []Rule{
+					{
+						Source:   "QUERY",
+						Operator: "LESS_THAN",
+						Target:   "10",
+					},
+					{
+						Source:   "QUERY",
+						Operator: "GREATER_THAN",
+						Target:   "9.5",
+					},
+				},
+			},
+			wantOk:  []bool{false, true},
+			wantErr: false,
+		},
+		{
+			name: "compares the named column",
+			args: args{
+				result: result,
+				rules: []Rule{
+					{
+						Source:   "QUERY",
+						Property: "state",
+						Operator: "EQUAL",
+						Target:   "stuck",
+					},
+					{
+						Source:   "QUERY",
+						Property: "missing",
+						Operator: "NOT_EQUAL",
+						Target:   "stuck",
+					},
+				},
+			},
+			wantOk:  []bool{true, false},
+			wantErr: false,
+		},
+		{
+			name: "EMPTY passes without rows",
+			args: args{
+				result: &probes.Result{
+					Query: &probes.QueryResult{Columns: []string{"id"}, Rows: [][]string{}},
+				},
+				rules: []Rule{
+					{
+						Source:   "QUERY",
+						Operator: "EMPTY",
+					},
+				},
+			},
+			wantOk:  []bool{true},
+			wantErr: false,
+		},
+		{
+			name: "EMPTY fails without query",
+			args: args{
+				result: &probes.Result{},
+				rules: []Rule{
+					{
+						Source:   "QUERY",
+						Operator: "EMPTY",
+					},
+				},
+			},
+			wantOk:  []bool{false},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewQueryAsserter()
+			gotOk, err := a.Assert(tt.args.result, tt.args.rules)
+
+			assert.Equal(t, tt.wantOk, gotOk)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
 )
 
 /*
@@ -38,7 +38,7 @@ func NewRawBodyAsserter() *RawBodyAsserter {
 	return &RawBodyAsserter{}
 }
 
-func (a *RawBodyAsserter) Assert(result *http.Result, rules []Rule) (ok []bool, err error) {
+func (a *RawBodyAsserter) Assert(result *probes.Result, rules []Rule) (ok []bool, err error) {
 	if len(rules) == 0 {
 		return []bool{}, nil
 	}
@@ -104,8 +104,13 @@ func (a *RawBodyAsserter) IsRuleValid(rule Rule) error {
 	return nil
 }
 
-func (a *RawBodyAsserter) assert(result *http.Result, rule Rule) bool {
-	bodyStr := string(result.Response.Body)
+func (a *RawBodyAsserter) assert(result *probes.Result, rule Rule) bool {
+	// Only HTTP responses have a body
+	if result.HTTP == nil {
+		return false
+	}
+
+	bodyStr := string(result.HTTP.Body)
 
 	switch rule.Operator {
 	case "EQUAL":
@@ -3,7 +3,7 @@ package asserter
 import (
 	"testing"
 
-	"github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -298,7 +298,7 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
-		result *http.Result
+		result *probes.Result
 		rules  []Rule
 	}
 	tests := []struct {
@@ -311,8 +311,8 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 		{
 			name: "Multiple rules",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Body: []byte("foobar"),
 					},
 				},
@@ -344,8 +344,8 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 		{
 			name: "valid equal true",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Body: []byte("foobar"),
 					},
 				},
@@ -364,8 +364,8 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 		{
 			name: "valid equal false",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Body: []byte("foobar"),
 					},
 				},
@@ -385,8 +385,8 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 		{
 			name: "valid not equal true",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Body: []byte("foobar"),
 					},
 				},
@@ -405,8 +405,8 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 		{
 			name: "valid not equal false",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Body: []byte("foobar"),
 					},
 				},
@@ -426,8 +426,8 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 		{
 			name: "valid empty true",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Body: []byte(""),
 					},
 				},
@@ -446,8 +446,8 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 		{
 			name: "valid empty false",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Body: []byte("foobar"),
 					},
 				},
@@ -467,8 +467,8 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 		{
 			name: "valid not empty true",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Body: []byte("foobar"),
 					},
 				},
@@ -487,8 +487,8 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 		{
 			name: "valid not empty false",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Body: []byte(""),
 					},
 				},
@@ -508,8 +508,8 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 		{
 			name: "valid greater than true",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Body: []byte("2"),
 					},
 				},
@@ -528,8 +528,8 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 		{
 			name: "valid greater than false",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Body: []byte("0"),
 					},
 				},
@@ -549,8 +549,8 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 		{
 			name: "valid less than true",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Body: []byte("0"),
 					},
 				},
@@ -569,8 +569,8 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 		{
 			name: "valid less than false",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Body: []byte("2"),
 					},
 				},
@@ -590,8 +590,8 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 		{
 			name: "valid contains true",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Body: []byte("foobar"),
 					},
 				},
@@ -610,8 +610,8 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 		{
 			name: "valid contains false",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Body: []byte("foobar"),
 					},
 				},
@@ -631,8 +631,8 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 		{
 			name: "valid not contains true",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Body: []byte("foobar"),
 					},
 				},
@@ -651,8 +651,8 @@ func TestRawBodyAsserter_assert(t *testing.T) {
 		{
 			name: "valid not contains false",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						Body: []byte("foobar"),
 					},
 				},
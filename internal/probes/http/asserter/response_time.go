@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
 )
 
 /*
@@ -48,7 +48,7 @@ func NewResponseTimeAsserter() *ResponseTimeAssertion {
 	return &ResponseTimeAssertion{}
 }
 
-func (a *ResponseTimeAssertion) Assert(result *http.Result, rules []Rule) (ok []bool, err error) {
+func (a *ResponseTimeAssertion) Assert(result *probes.Result, rules []Rule) (ok []bool, err error) {
 	if len(rules) == 0 {
 		return []bool{}, nil
 	}
@@ -91,7 +91,7 @@ func (a *ResponseTimeAssertion) IsRuleValid(rule Rule) error {
 	return nil
 }
 
-func (a *ResponseTimeAssertion) assert(result *http.Result, rule Rule) bool {
+func (a *ResponseTimeAssertion) assert(result *probes.Result, rule Rule) bool {
 	var resultValue time.Duration
 	switch rule.Property {
 	case "DNS_LOOKUP":
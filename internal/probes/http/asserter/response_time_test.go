@@ -4,7 +4,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -98,7 +98,7 @@ func TestResponseTimeAssertion_Assert(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
-		result *http.Result
+		result *probes.Result
 		rules  []Rule
 	}
 	tests := []struct {
@@ -110,7 +110,7 @@ func TestResponseTimeAssertion_Assert(t *testing.T) {
 		{
 			name: "No rules success",
 			args: args{
-				result: &http.Result{},
+				result: &probes.Result{},
 			},
 			wantOk:  []bool{},
 			wantErr: false,
@@ -118,9 +118,9 @@ func TestResponseTimeAssertion_Assert(t *testing.T) {
 		{
 			name: "Equal success",
 			args: args{
-				result: &http.Result{
-					Timing: http.Timing{
-						Phases: http.TimingPhases{
+				result: &probes.Result{
+					Timing: probes.Timing{
+						Phases: probes.TimingPhases{
 							Total: time.Millisecond * 100,
 						},
 					},
@@ -140,9 +140,9 @@ func TestResponseTimeAssertion_Assert(t *testing.T) {
 		{
 			name: "Equal failure",
 			args: args{
-				result: &http.Result{
-					Timing: http.Timing{
-						Phases: http.TimingPhases{
+				result: &probes.Result{
+					Timing: probes.Timing{
+						Phases: probes.TimingPhases{
 							Total: time.Millisecond * 100,
 						},
 					},
@@ -162,9 +162,9 @@ func TestResponseTimeAssertion_Assert(t *testing.T) {
 		{
 			name: "NotEqual success",
 			args: args{
-				result: &http.Result{
-					Timing: http.Timing{
-						Phases: http.TimingPhases{
+				result: &probes.Result{
+					Timing: probes.Timing{
+						Phases: probes.TimingPhases{
 							Total: time.Millisecond * 100,
 						},
 					},
@@ -184,9 +184,9 @@ func TestResponseTimeAssertion_Assert(t *testing.T) {
 		{
 			name: "NotEqual failure",
 			args: args{
-				result: &http.Result{
-					Timing: http.Timing{
-						Phases: http.TimingPhases{
+				result: &probes.Result{
+					Timing: probes.Timing{
+						Phases: probes.TimingPhases{
 							Total: time.Millisecond * 100,
 						},
 					},
@@ -206,9 +206,9 @@ func TestResponseTimeAssertion_Assert(t *testing.T) {
 		{
 			name: "GreaterThan success",
 			args: args{
-				result: &http.Result{
-					Timing: http.Timing{
-						Phases: http.TimingPhases{
+				result: &probes.Result{
+					Timing: probes.Timing{
+						Phases: probes.TimingPhases{
 							Total: time.Millisecond * 100,
 						},
 					},
@@ -228,9 +228,9 @@ func TestResponseTimeAssertion_Assert(t *testing.T) {
 		{
 			name: "GreaterThan failure",
 			args: args{
-				result: &http.Result{
-					Timing: http.Timing{
-						Phases: http.TimingPhases{
+				result: &probes.Result{
+					Timing: probes.Timing{
+						Phases: probes.TimingPhases{
 							Total: time.Millisecond * 100,
 						},
 					},
@@ -250,9 +250,9 @@ func TestResponseTimeAssertion_Assert(t *testing.T) {
 		{
 			name: "LessThan success",
 			args: args{
-				result: &http.Result{
-					Timing: http.Timing{
-						Phases: http.TimingPhases{
+				result: &probes.Result{
+					Timing: probes.Timing{
+						Phases: probes.TimingPhases{
 							Total: time.Millisecond * 100,
 						},
 					},
@@ -272,9 +272,9 @@ func TestResponseTimeAssertion_Assert(t *testing.T) {
 		{
 			name: "LessThan failure",
 			args: args{
-				result: &http.Result{
-					Timing: http.Timing{
-						Phases: http.TimingPhases{
+				result: &probes.Result{
+					Timing: probes.Timing{
+						Phases: probes.TimingPhases{
 							Total: time.Millisecond * 100,
 						},
 					},
@@ -294,7 +294,7 @@ func TestResponseTimeAssertion_Assert(t *testing.T) {
 		{
 			name: "Invalid rule",
 			args: args{
-				result: &http.Result{},
+				result: &probes.Result{},
 				rules: []Rule{
 					{
 						Source:   "INVALID",
@@ -310,9 +310,9 @@ func TestResponseTimeAssertion_Assert(t *testing.T) {
 		{
 			name: "Multiple rules success",
 			args: args{
-				result: &http.Result{
-					Timing: http.Timing{
-						Phases: http.TimingPhases{
+				result: &probes.Result{
+					Timing: probes.Timing{
+						Phases: probes.TimingPhases{
 							TCPConnection: time.Millisecond * 50,
 							DNSLookup:     time.Millisecond * 20,
 							TLSHandshake:  time.Millisecond * 30,
@@ -4,7 +4,7 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
 )
 
 /*
@@ -30,7 +30,7 @@ func NewStatusCodeAsserter() *StatusCodeAsserter {
 	return &StatusCodeAsserter{}
 }
 
-func (a *StatusCodeAsserter) Assert(result *http.Result, rules []Rule) (ok []bool, err error) {
+func (a *StatusCodeAsserter) Assert(result *probes.Result, rules []Rule) (ok []bool, err error) {
 	if len(rules) == 0 {
 		return []bool{}, nil
 	}
@@ -73,7 +73,12 @@ func (a *StatusCodeAsserter) IsRuleValid(rule Rule) error {
 	return nil
 }
 
-func (a *StatusCodeAsserter) assert(result *http.Result, rule Rule) bool {
+func (a *StatusCodeAsserter) assert(result *probes.Result, rule Rule) bool {
+	// Only HTTP responses have a status code
+	if result.HTTP == nil {
+		return false
+	}
+
 	targetInt, ok := toInt(rule.Target)
 	if !ok {
 		return false
@@ -81,13 +86,13 @@ func (a *StatusCodeAsserter) assert(result *http.Result, rule Rule) bool {
 
 	switch rule.Operator {
 	case "EQUAL":
-		return result.Response.StatusCode == targetInt
+		return result.HTTP.StatusCode == targetInt
 	case "NOT_EQUAL":
-		return result.Response.StatusCode != targetInt
+		return result.HTTP.StatusCode != targetInt
 	case "GREATER_THAN":
-		return result.Response.StatusCode > targetInt
+		return result.HTTP.StatusCode > targetInt
 	case "LESS_THAN":
-		return result.Response.StatusCode < targetInt
+		return result.HTTP.StatusCode < targetInt
 	default:
 		return false
 	}
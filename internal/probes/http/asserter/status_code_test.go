@@ -3,7 +3,7 @@ package asserter
 import (
 	"testing"
 
-	"github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -59,7 +59,7 @@ func TestStatusCodeAsserter_Assert(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
-		result *http.Result
+		result *probes.Result
 		rules  []Rule
 	}
 	tests := []struct {
@@ -71,8 +71,8 @@ func TestStatusCodeAsserter_Assert(t *testing.T) {
 		{
 			name: "EQUAL passes",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						StatusCode: 200,
 					},
 				},
@@ -90,8 +90,8 @@ func TestStatusCodeAsserter_Assert(t *testing.T) {
 		{
 			name: "EQUAL fails",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						StatusCode: 200,
 					},
 				},
@@ -109,8 +109,8 @@ func TestStatusCodeAsserter_Assert(t *testing.T) {
 		{
 			name: "NOT_EQUAL passes",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						StatusCode: 200,
 					},
 				},
@@ -128,8 +128,8 @@ func TestStatusCodeAsserter_Assert(t *testing.T) {
 		{
 			name: "NOT_EQUAL fails",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						StatusCode: 200,
 					},
 				},
@@ -147,8 +147,8 @@ func TestStatusCodeAsserter_Assert(t *testing.T) {
 		{
 			name: "GREATER_THAN passes",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						StatusCode: 201,
 					},
 				},
@@ -166,8 +166,8 @@ func TestStatusCodeAsserter_Assert(t *testing.T) {
 		{
 			name: "GREATER_THAN fails",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						StatusCode: 200,
 					},
 				},
@@ -185,8 +185,8 @@ func TestStatusCodeAsserter_Assert(t *testing.T) {
 		{
 			name: "LESS_THAN passes",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						StatusCode: 199,
 					},
 				},
@@ -204,8 +204,8 @@ func TestStatusCodeAsserter_Assert(t *testing.T) {
 		{
 			name: "LESS_THAN fails",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						StatusCode: 200,
 					},
 				},
@@ -223,8 +223,8 @@ func TestStatusCodeAsserter_Assert(t *testing.T) {
 		{
 			name: "multiple rules",
 			args: args{
-				result: &http.Result{
-					Response: http.Response{
+				result: &probes.Result{
+					HTTP: &probes.HTTPResponse{
 						StatusCode: 200,
 					},
 				},
@@ -254,6 +254,21 @@ func TestStatusCodeAsserter_Assert(t *testing.T) {
 			wantOk:  []bool{true, true, true, true},
 			wantErr: false,
 		},
+		{
+			name: "NOT_EQUAL fails without HTTP response",
+			args: args{
+				result: &probes.Result{},
+				rules: []Rule{
+					{
+						Source:   "STATUS_CODE",
+						Operator: "NOT_EQUAL",
+						Target:   "500",
+					},
+				},
+			},
+			wantOk:  []bool{false},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
 )
 
 /*
@@ -31,7 +31,7 @@ func NewTLSAsserter() *TLSAsserter {
 	return &TLSAsserter{}
 }
 
-func (a *TLSAsserter) Assert(result *http.Result, rules []Rule) (ok []bool, err error) {
+func (a *TLSAsserter) Assert(result *probes.Result, rules []Rule) (ok []bool, err error) {
 	if len(rules) == 0 {
 		return []bool{}, nil
 	}
@@ -87,7 +87,12 @@ func (a *TLSAsserter) IsRuleValid(rule Rule) error {
 	return nil
 }
 
-func (a *TLSAsserter) assert(result *http.Result, rule Rule) bool {
+func (a *TLSAsserter) assert(result *probes.Result, rule Rule) bool {
+	// Not every connection is secured
+	if result.TLS == nil {
+		return false
+	}
+
 	switch rule.Operator {
 	case "EXPIRED":
 		return a.assertExpired(result)
@@ -102,15 +107,15 @@ func (a *TLSAsserter) assert(result *http.Result, rule Rule) bool {
 	}
 }
 
-func (a *TLSAsserter) assertExpired(result *http.Result) bool {
+func (a *TLSAsserter) assertExpired(result *probes.Result) bool {
 	return result.TLS.Certificate.NotAfter.Before(time.Now())
 }
 
-func (a *TLSAsserter) assertNotExpired(result *http.Result) bool {
+func (a *TLSAsserter) assertNotExpired(result *probes.Result) bool {
 	return result.TLS.Certificate.NotAfter.After(time.Now())
 }
 
-func (a *TLSAsserter) assertExpiresLessThan(result *http.Result, rule Rule) bool {
+func (a *TLSAsserter) assertExpiresLessThan(result *probes.Result, rule Rule) bool {
 	target, ok := a.getTargetDeltaAsTime(rule)
 	if !ok {
 		return false
@@ -119,7 +124,7 @@ func (a *TLSAsserter) assertExpiresLessThan(result *http.Result, rule Rule) bool
 	return result.TLS.Certificate.NotAfter.Before(target)
 }
 
-func (a *TLSAsserter) assertExpiresGreaterThan(result *http.Result, rule Rule) bool {
+func (a *TLSAsserter) assertExpiresGreaterThan(result *probes.Result, rule Rule) bool {
 	target, ok := a.getTargetDeltaAsTime(rule)
 	if !ok {
 		return false
@@ -5,7 +5,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -61,7 +61,7 @@ func TestTLSAsserter_Assert(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
-		result *http.Result
+		result *probes.Result
 		rules  []Rule
 	}
 	tests := []struct {
@@ -73,9 +73,9 @@ func TestTLSAsserter_Assert(t *testing.T) {
 		{
 			name: "Certificate has not expired passes",
 			args: args{
-				result: &http.Result{
-					TLS: &http.TLS{
-						Certificate: http.Certificate{
+				result: &probes.Result{
+					TLS: &probes.TLS{
+						Certificate: probes.Certificate{
 							NotAfter: time.Now().Add(time.Hour),
 						},
 					},
@@ -93,9 +93,9 @@ func TestTLSAsserter_Assert(t *testing.T) {
 		{
 			name: "Certificate has not expired fails",
 			args: args{
-				result: &http.Result{
-					TLS: &http.TLS{
-						Certificate: http.Certificate{
+				result: &probes.Result{
+					TLS: &probes.TLS{
+						Certificate: probes.Certificate{
 							NotAfter: time.Now().Add(-time.Hour),
 						},
 					},
@@ -113,9 +113,9 @@ func TestTLSAsserter_Assert(t *testing.T) {
 		{
 			name: "Certificate has expired passes",
 			args: args{
-				result: &http.Result{
-					TLS: &http.TLS{
-						Certificate: http.Certificate{
+				result: &probes.Result{
+					TLS: &probes.TLS{
+						Certificate: probes.Certificate{
 							NotAfter: time.Now().Add(-time.Hour),
 						},
 					},
@@ -133,9 +133,9 @@ func TestTLSAsserter_Assert(t *testing.T) {
 		{
 			name: "Certificate has expired fails",
 			args: args{
-				result: &http.Result{
-					TLS: &http.TLS{
-						Certificate: http.Certificate{
+				result: &probes.Result{
+					TLS: &probes.TLS{
+						Certificate: probes.Certificate{
 							NotAfter: time.Now().Add(time.Hour),
 						},
 					},
@@ -153,9 +153,9 @@ func TestTLSAsserter_Assert(t *testing.T) {
 		{
 			name: "Certificate expires less than passes",
 			args: args{
-				result: &http.Result{
-					TLS: &http.TLS{
-						Certificate: http.Certificate{
+				result: &probes.Result{
+					TLS: &probes.TLS{
+						Certificate: probes.Certificate{
 							NotAfter: time.Now().Add(time.Minute),
 						},
 					},
@@ -174,9 +174,9 @@ func TestTLSAsserter_Assert(t *testing.T) {
 		{
 			name: "Certificate expires less than fails",
 			args: args{
-				result: &http.Result{
-					TLS: &http.TLS{
-						Certificate: http.Certificate{
+				result: &probes.Result{
+					TLS: &probes.TLS{
+						Certificate: probes.Certificate{
 							NotAfter: time.Now().Add(time.Minute),
 						},
 					},
@@ -195,9 +195,9 @@ func TestTLSAsserter_Assert(t *testing.T) {
 		{
 			name: "Certificate expires greater than passes",
 			args: args{
-				result: &http.Result{
-					TLS: &http.TLS{
-						Certificate: http.Certificate{
+				result: &probes.Result{
+					TLS: &probes.TLS{
+						Certificate: probes.Certificate{
 							NotAfter: time.Now().Add(time.Minute * 5),
 						},
 					},
@@ -216,9 +216,9 @@ func TestTLSAsserter_Assert(t *testing.T) {
 		{
 			name: "Certificate expires greater than fails",
 			args: args{
-				result: &http.Result{
-					TLS: &http.TLS{
-						Certificate: http.Certificate{
+				result: &probes.Result{
+					TLS: &probes.TLS{
+						Certificate: probes.Certificate{
 							NotAfter: time.Now().Add(time.Minute * 5),
 						},
 					},
@@ -3,7 +3,6 @@ package http
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 	"io"
 	"net"
@@ -11,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/opsway-io/backend/internal/probes"
 	"github.com/opsway-io/go-httpstat"
 	"github.com/pkg/errors"
 )
@@ -28,7 +28,7 @@ type Config struct {
 }
 
 type Service interface {
-	Probe(ctx context.Context, method, url string, headers map[string]string, body io.Reader, timeout time.Duration) (*Result, error)
+	Probe(ctx context.Context, method, url string, headers map[string]string, body io.Reader, timeout time.Duration) (*probes.Result, error)
 }
 
 type ServiceImpl struct {
@@ -41,21 +41,30 @@ func NewService(config Config) Service {
 	}
 }
 
-func (s *ServiceImpl) Probe(ctx context.Context, method, url string, headers map[string]string, body io.Reader, timeout time.Duration) (*Result, error) {
+// Probe sends the request and returns the response, responses with an error
+// status code are failed results. Only invalid requests return an error.
+func (s *ServiceImpl) Probe(ctx context.Context, method, url string, headers map[string]string, body io.Reader, timeout time.Duration) (*probes.Result, error) {
 	// Initialize the request
 	req, err := xhttp.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create request")
 	}
 
+	// Set user agent, can be overridden by the headers below
+	req.Header.Set("User-Agent", s.config.UserAgent)
+
 	// Set headers
 	for k, v := range headers {
+		// The Host header is ignored by the client, it must be set on the request
+		if strings.EqualFold(k, "Host") {
+			req.Host = v
+
+			continue
+		}
+
 		req.Header.Set(k, v)
 	}
 
-	// Set user agent
-	req.Header.Set("User-Agent", s.config.UserAgent)
-
 	// Instrument the request with httpstat
 	var result httpstat.Result
 	httpStatCtx := httpstat.WithHTTPStat(ctx, &result)
@@ -63,18 +72,29 @@ func (s *ServiceImpl) Probe(ctx context.Context, method, url string, headers map
 
 	client := s.newHttpClient(timeout)
 
+	start := time.Now()
+
 	// Send the request
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to send request")
+		meta := &probes.Result{
+			Timing: probes.Timing{
+				Phases: probes.TimingPhases{
+					DNSLookup:     result.DNSLookup,
+					TCPConnection: result.TCPConnection,
+					TLSHandshake:  result.TLSHandshake,
+					Total:         time.Since(start),
+				},
+			},
+		}
+		meta.Fail(err)
+
+		return meta, nil
 	}
 
 	// Read the response body
 	limitedReader := &io.LimitedReader{R: resp.Body, N: s.config.MaxBodyBytesReadSize}
 	bodyBytes, err := io.ReadAll(limitedReader)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to read response body")
-	}
 
 	// Close the response body to avoid leaking resources
 	_ = resp.Body.Close()
@@ -83,14 +103,15 @@ func (s *ServiceImpl) Probe(ctx context.Context, method, url string, headers map
 	result.End(time.Now())
 
 	// Create the result
-	meta := &Result{
-		Response: Response{
+	meta := &probes.Result{
+		Success: true,
+		HTTP: &probes.HTTPResponse{
 			StatusCode: resp.StatusCode,
 			Header:     resp.Header,
 			Body:       bodyBytes,
 		},
-		Timing: Timing{
-			Phases: TimingPhases{
+		Timing: probes.Timing{
+			Phases: probes.TimingPhases{
 				DNSLookup:        result.DNSLookup,
 				TCPConnection:    result.TCPConnection,
 				TLSHandshake:     result.TLSHandshake,
@@ -101,36 +122,18 @@ func (s *ServiceImpl) Probe(ctx context.Context, method, url string, headers map
 		},
 	}
 
+	switch {
+	case err != nil:
+		meta.Fail(errors.Wrap(err, "failed to read response body"))
+	case resp.StatusCode == xhttp.StatusUnauthorized || resp.StatusCode == xhttp.StatusForbidden || resp.StatusCode == xhttp.StatusProxyAuthRequired:
+		meta.FailWithKind(probes.ErrorKindAuth, fmt.Errorf("unexpected status code %d", resp.StatusCode))
+	case resp.StatusCode >= xhttp.StatusBadRequest:
+		meta.FailWithKind(probes.ErrorKindBadResponse, fmt.Errorf("unexpected status code %d", resp.StatusCode))
+	}
+
 	// Add TLS information if available
 	if resp.TLS != nil {
-		meta.TLS = &TLS{
-			Version: TLSVersionName(resp.TLS.Version),
-			Cipher:  tls.CipherSuiteName(resp.TLS.CipherSuite),
-		}
-
-		if len(resp.TLS.PeerCertificates) > 0 {
-			cert := resp.TLS.PeerCertificates[0]
-			hostname := req.URL.Hostname()
-
-			trustedCA := s.certificateTrusted(resp.TLS, hostname)
-
-			notExpired := s.certificateNotExpired(cert)
-			hostValid := s.certificateHostValid(cert, hostname)
-
-			meta.TLS.Certificate = Certificate{
-				Issuer: CertificateIssuer{
-					Organization: strings.Join(cert.Issuer.Organization, ""),
-				},
-				Subject: CertificateSubject{
-					CommonName: cert.Subject.CommonName,
-				},
-				NotBefore:  cert.NotBefore,
-				NotAfter:   cert.NotAfter,
-				NotExpired: notExpired,
-				HostValid:  hostValid,
-				TrustedCA:  trustedCA,
-			}
-		}
+		meta.TLS = probes.NewTLS(resp.TLS, req.URL.Hostname())
 	}
 
 	return meta, nil
@@ -168,25 +171,3 @@ func (s *ServiceImpl) newHttpClient(timeout time.Duration) *xhttp.Client {
 		},
 	}
 }
-
-func (s *ServiceImpl) certificateNotExpired(cert *x509.Certificate) (notExpired bool) {
-	now := time.Now()
-
-	return now.Before(cert.NotAfter) && now.After(cert.NotBefore)
-}
-
-func (s *ServiceImpl) certificateHostValid(cert *x509.Certificate, host string) (hostValid bool) {
-	return cert.VerifyHostname(host) == nil
-}
-
-func (s *ServiceImpl) certificateTrusted(state *tls.ConnectionState, host string) bool {
-	opts := x509.VerifyOptions{
-		DNSName:       host,
-		Intermediates: x509.NewCertPool(),
-	}
-	for _, cert := range state.PeerCertificates[1:] {
-		opts.Intermediates.AddCert(cert)
-	}
-	_, err := state.PeerCertificates[0].Verify(opts)
-	return err == nil
-}
@@ -2,11 +2,14 @@ package http_test
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/opsway-io/backend/internal/probes"
 	probeHttp "github.com/opsway-io/backend/internal/probes/http"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -42,11 +45,112 @@ func TestHTTPProbeService(t *testing.T) {
 
 		require.NoError(t, err)
 		assert.NotNil(t, res)
-		assert.Equal(t, 200, res.Response.StatusCode)
-		assert.Equal(t, []byte("OK"), res.Response.Body)
-		assert.Equal(t, "true", res.Response.Header.Get("X-Received"))
+		assert.Equal(t, 200, res.HTTP.StatusCode)
+		assert.Equal(t, []byte("OK"), res.HTTP.Body)
+		assert.Equal(t, "true", res.HTTP.Header.Get("X-Received"))
 		assert.Greater(t, res.Timing.Phases.Total, time.Duration(0))
 		assert.Nil(t, res.TLS) // No TLS for http://
+		assert.True(t, res.Success)
+		assert.Nil(t, res.Error)
+	})
+}
+
+func TestHTTPProbeServiceFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/unauthorized":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+
+	cfg := probeHttp.Config{
+		UserAgent:            "opsway 1.0.0",
+		DNSTimeout:           5 * time.Second,
+		MaxBodyBytesReadSize: 1048576,
+	}
+
+	svc := probeHttp.NewService(cfg)
+	ctx := context.Background()
+
+	t.Run("error status codes fail the probe", func(t *testing.T) {
+		res, err := svc.Probe(ctx, "GET", server.URL, nil, nil, 2*time.Second)
+
+		require.NoError(t, err)
+		assert.False(t, res.Success)
+		assert.Equal(t, 500, res.HTTP.StatusCode)
+		assert.Equal(t, probes.ErrorKindBadResponse, res.Error.Kind)
+	})
+
+	t.Run("unauthorized responses are auth failures", func(t *testing.T) {
+		res, err := svc.Probe(ctx, "GET", server.URL+"/unauthorized", nil, nil, 2*time.Second)
+
+		require.NoError(t, err)
+		assert.False(t, res.Success)
+		assert.Equal(t, probes.ErrorKindAuth, res.Error.Kind)
+	})
+
+	t.Run("refused connections fail the probe without a response", func(t *testing.T) {
+		url := server.URL
+		server.Close()
+
+		res, err := svc.Probe(ctx, "GET", url, nil, nil, 2*time.Second)
+
+		require.NoError(t, err)
+		assert.False(t, res.Success)
+		assert.Nil(t, res.HTTP)
+		assert.Equal(t, probes.ErrorKindConnectionRefused, res.Error.Kind)
+	})
+}
+
+func TestHTTPProbeServiceHeadersAndBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("X-Method", r.Method)
+		w.Header().Set("X-Authorization", r.Header.Get("Authorization"))
+		w.Header().Set("X-Content-Type", r.Header.Get("Content-Type"))
+		w.Header().Set("X-User-Agent", r.Header.Get("User-Agent"))
+		w.Header().Set("X-Host", r.Host)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	cfg := probeHttp.Config{
+		UserAgent:            "opsway 1.0.0",
+		DNSTimeout:           5 * time.Second,
+		MaxBodyBytesReadSize: 1048576,
+	}
+
+	svc := probeHttp.NewService(cfg)
+	ctx := context.Background()
+
+	t.Run("forwards headers and body", func(t *testing.T) {
+		headers := map[string]string{
+			"Authorization": "Bearer token",
+			"Content-Type":  "application/json",
+		}
+		res, err := svc.Probe(ctx, "POST", server.URL, headers, strings.NewReader(`{"ok":true}`), 2*time.Second)
+
+		require.NoError(t, err)
+		assert.Equal(t, "POST", res.HTTP.Header.Get("X-Method"))
+		assert.Equal(t, "Bearer token", res.HTTP.Header.Get("X-Authorization"))
+		assert.Equal(t, "application/json", res.HTTP.Header.Get("X-Content-Type"))
+		assert.Equal(t, []byte(`{"ok":true}`), res.HTTP.Body)
+	})
+
+	t.Run("headers override user agent and host", func(t *testing.T) {
+		headers := map[string]string{
+			"User-Agent": "custom",
+			"Host":       "example.com",
+		}
+		res, err := svc.Probe(ctx, "GET", server.URL, headers, nil, 2*time.Second)
+
+		require.NoError(t, err)
+		assert.Equal(t, "custom", res.HTTP.Header.Get("X-User-Agent"))
+		assert.Equal(t, "example.com", res.HTTP.Header.Get("X-Host"))
 	})
 }
 
@@ -72,7 +176,7 @@ func TestTLSProbeService(t *testing.T) {
 
 		require.NoError(t, err)
 		assert.NotNil(t, res)
-		assert.Equal(t, 200, res.Response.StatusCode)
+		assert.Equal(t, 200, res.HTTP.StatusCode)
 		assert.NotNil(t, res.TLS)
 
 		// Assert TLS fields
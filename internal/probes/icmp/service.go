@@ -1,50 +1,258 @@
 package icmp
 
 import (
+	"bytes"
 	"context"
-	"os/exec"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"os"
 	"time"
 
-	probeHttp "github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
+	xicmp "golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
 )
 
+type Config struct {
+	// Number of echo requests sent when the monitor does not say
+	PacketCount int `mapstructure:"packet_count" default:"5"`
+	// Minimum time between two echo requests
+	Interval time.Duration `mapstructure:"interval" default:"100ms"`
+	// Use raw sockets, which requires root or CAP_NET_RAW. Datagram sockets
+	// are used otherwise, which requires the group of the prober to be in
+	// the net.ipv4.ping_group_range sysctl.
+	Privileged bool `mapstructure:"privileged" default:"false"`
+}
+
+type Options struct {
+	PacketCount int
+}
+
 type Service interface {
-	Probe(ctx context.Context, target string, timeout time.Duration) (*probeHttp.Result, error)
+	Probe(ctx context.Context, target string, opts Options, timeout time.Duration) (*probes.Result, error)
 }
 
-type ServiceImpl struct{}
+type ServiceImpl struct {
+	config Config
+}
 
-func NewService() Service {
-	return &ServiceImpl{}
+func NewService(config Config) Service {
+	return &ServiceImpl{
+		config: config,
+	}
 }
 
-func (s *ServiceImpl) Probe(ctx context.Context, target string, timeout time.Duration) (*probeHttp.Result, error) {
-	start := time.Now()
+// Probe sends echo requests to the target and reports the round trip times of
+// the replies, it fails when none of them were answered. Only failing to open
+// the socket returns an error.
+func (s *ServiceImpl) Probe(ctx context.Context, target string, opts Options, timeout time.Duration) (*probes.Result, error) {
+	count := opts.PacketCount
+	if count <= 0 {
+		count = s.config.PacketCount
+	}
+
+	if count <= 0 {
+		count = 1
+	}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(timeoutCtx, "ping", "-c", "1", target)
-	err := cmd.Run()
-	
-	duration := time.Since(start)
+	start := time.Now()
 
-	res := &probeHttp.Result{
-		Timing: probeHttp.Timing{
-			Phases: probeHttp.TimingPhases{
-				Total: duration,
-			},
-		},
-		Response: probeHttp.Response{
-			StatusCode: 200,
-			Body:       []byte("OK"),
-		},
+	res := &probes.Result{}
+
+	ip, err := resolve(timeoutCtx, target)
+
+	res.Timing.Phases.DNSLookup = time.Since(start)
+
+	if err != nil {
+		res.Timing.Phases.Total = time.Since(start)
+		res.Fail(err)
+
+		return res, nil
+	}
+
+	res.Metadata = map[string]string{
+		"address": ip.String(),
 	}
 
+	p, err := s.newPinger(ip)
 	if err != nil {
-		res.Response.StatusCode = 503
-		res.Response.Body = []byte(err.Error())
+		return nil, err
 	}
+	defer p.conn.Close()
+
+	// Every echo request gets an equal share of the time left
+	wait := time.Until(start.Add(timeout)) / time.Duration(count)
+
+	// Fewer echo requests are sent than asked for if the timeout is reached
+	// first, the loss is only reported over the ones sent
+	sent := 0
+
+	rtts := []time.Duration{}
+	for seq := 0; seq < count && timeoutCtx.Err() == nil; seq++ {
+		sentAt := time.Now()
+		sent++
+
+		rtt, err := p.ping(seq, sentAt.Add(wait))
+		if err == nil {
+			rtts = append(rtts, rtt)
+		} else if !isTimeout(err) {
+			res.Timing.Phases.Total = time.Since(start)
+			res.Ping = probes.NewPingStats(sent, rtts)
+			res.Fail(err)
+
+			return res, nil
+		}
+
+		if seq < count-1 {
+			time.Sleep(time.Until(sentAt.Add(s.config.Interval)))
+		}
+	}
+
+	res.Timing.Phases.Total = time.Since(start)
+	res.Ping = probes.NewPingStats(sent, rtts)
+
+	if len(rtts) == 0 {
+		res.FailWithKind(probes.ErrorKindTimeout, fmt.Errorf("no reply from %s to %d echo requests", ip, sent))
+
+		return res, nil
+	}
+
+	res.Success = true
 
 	return res, nil
 }
+
+func resolve(ctx context.Context, target string) (net.IP, error) {
+	if ip := net.ParseIP(target); ip != nil {
+		return ip, nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prefer IPv4, which every prober can reach
+	for _, addr := range addrs {
+		if addr.IP.To4() != nil {
+			return addr.IP, nil
+		}
+	}
+
+	if len(addrs) == 0 {
+		return nil, &net.DNSError{Err: "no addresses found", Name: target, IsNotFound: true}
+	}
+
+	return addrs[0].IP, nil
+}
+
+type pinger struct {
+	conn       *xicmp.PacketConn
+	addr       net.Addr
+	protocol   int
+	echoType   xicmp.Type
+	replyType  xicmp.Type
+	id         int
+	token      []byte
+	privileged bool
+}
+
+func (s *ServiceImpl) newPinger(ip net.IP) (*pinger, error) {
+	p := &pinger{
+		id:         os.Getpid() & 0xffff,
+		token:      make([]byte, 16),
+		privileged: s.config.Privileged,
+	}
+
+	// Replies are told apart from the replies to other probes by the token
+	if _, err := rand.Read(p.token); err != nil {
+		return nil, err
+	}
+
+	var network, address string
+	if ip.To4() != nil {
+		p.protocol, p.echoType, p.replyType = 1, ipv4.ICMPTypeEcho, ipv4.ICMPTypeEchoReply
+		network, address = "udp4", "0.0.0.0"
+		if p.privileged {
+			network = "ip4:icmp"
+		}
+	} else {
+		p.protocol, p.echoType, p.replyType = 58, ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply
+		network, address = "udp6", "::"
+		if p.privileged {
+			network = "ip6:ipv6-icmp"
+		}
+	}
+
+	conn, err := xicmp.ListenPacket(network, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ICMP socket: %w", err)
+	}
+
+	p.conn = conn
+	p.addr = &net.UDPAddr{IP: ip}
+	if p.privileged {
+		p.addr = &net.IPAddr{IP: ip}
+	}
+
+	return p, nil
+}
+
+// ping sends an echo request and waits for its reply until the deadline
+func (p *pinger) ping(seq int, deadline time.Time) (time.Duration, error) {
+	msg := xicmp.Message{
+		Type: p.echoType,
+		Body: &xicmp.Echo{
+			ID:   p.id,
+			Seq:  seq,
+			Data: p.token,
+		},
+	}
+
+	b, err := msg.Marshal(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := p.conn.SetReadDeadline(deadline); err != nil {
+		return 0, err
+	}
+
+	sentAt := time.Now()
+	if _, err := p.conn.WriteTo(b, p.addr); err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		n, _, err := p.conn.ReadFrom(buf)
+		if err != nil {
+			return 0, err
+		}
+
+		reply, err := xicmp.ParseMessage(p.protocol, buf[:n])
+		if err != nil || reply.Type != p.replyType {
+			continue
+		}
+
+		// The kernel sets the ID of echo requests sent over datagram sockets
+		echo, ok := reply.Body.(*xicmp.Echo)
+		if !ok || echo.Seq != seq || !bytes.Equal(echo.Data, p.token) || (p.privileged && echo.ID != p.id) {
+			continue
+		}
+
+		return time.Since(sentAt), nil
+	}
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+
+	return errors.Is(err, os.ErrDeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+}
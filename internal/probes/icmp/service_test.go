@@ -0,0 +1,72 @@
+package icmp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/opsway-io/backend/internal/probes/icmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestICMPProbeService(t *testing.T) {
+	t.Parallel()
+
+	for _, privileged := range []bool{false, true} {
+		svc := icmp.NewService(icmp.Config{
+			PacketCount: 3,
+			Interval:    10 * time.Millisecond,
+			Privileged:  privileged,
+		})
+
+		res, err := svc.Probe(context.Background(), "127.0.0.1", icmp.Options{}, 2*time.Second)
+		if err != nil {
+			// Opening ICMP sockets depends on the privileges of the test
+			t.Logf("privileged=%v: %v", privileged, err)
+
+			continue
+		}
+
+		require.NotNil(t, res.Ping)
+		assert.True(t, res.Success)
+		assert.Equal(t, 3, res.Ping.Sent)
+		assert.Equal(t, 3, res.Ping.Received)
+		assert.Zero(t, res.Ping.PacketLoss)
+		assert.LessOrEqual(t, res.Ping.MinRTT, res.Ping.MaxRTT)
+		assert.Equal(t, "127.0.0.1", res.Metadata["address"])
+
+		return
+	}
+
+	t.Skip("ICMP sockets are not permitted")
+}
+
+func TestICMPProbeService_Timeout(t *testing.T) {
+	t.Parallel()
+
+	for _, privileged := range []bool{false, true} {
+		svc := icmp.NewService(icmp.Config{
+			Interval:   100 * time.Millisecond,
+			Privileged: privileged,
+		})
+
+		// Only a few of the echo requests fit in the timeout
+		res, err := svc.Probe(context.Background(), "127.0.0.1", icmp.Options{PacketCount: 10}, 250*time.Millisecond)
+		if err != nil {
+			t.Logf("privileged=%v: %v", privileged, err)
+
+			continue
+		}
+
+		require.NotNil(t, res.Ping)
+		assert.True(t, res.Success)
+		assert.Less(t, res.Ping.Sent, 10)
+		assert.Equal(t, res.Ping.Sent, res.Ping.Received)
+		assert.Zero(t, res.Ping.PacketLoss)
+
+		return
+	}
+
+	t.Skip("ICMP sockets are not permitted")
+}
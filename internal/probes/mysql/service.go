@@ -3,17 +3,24 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql"
-	probeHttp "github.com/opsway-io/backend/internal/probes/http"
+	"github.com/go-sql-driver/mysql"
+	"github.com/opsway-io/backend/internal/probes"
 )
 
+// Options of a probe, the zero value only pings the server
+type Options struct {
+	// Query run once connected, the values it returns are asserted on
+	Query string
+}
+
 type Service interface {
-	Probe(ctx context.Context, target string, timeout time.Duration) (*probeHttp.Result, error)
+	Probe(ctx context.Context, target string, opts Options, timeout time.Duration) (*probes.Result, error)
 }
 
 type ServiceImpl struct{}
@@ -55,40 +62,62 @@ func urlToDSN(target string) string {
 	return dsn
 }
 
-func (s *ServiceImpl) Probe(ctx context.Context, target string, timeout time.Duration) (*probeHttp.Result, error) {
+func (s *ServiceImpl) Probe(ctx context.Context, target string, opts Options, timeout time.Duration) (*probes.Result, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	start := time.Now()
 
+	res := &probes.Result{}
+
 	dsn := urlToDSN(target)
 
 	db, err := sql.Open("mysql", dsn)
 	if err == nil {
 		defer db.Close()
-		
-		ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
-		defer cancel()
 
 		err = db.PingContext(ctxWithTimeout)
 	}
 
-	duration := time.Since(start)
-
-	res := &probeHttp.Result{
-		Timing: probeHttp.Timing{
-			Phases: probeHttp.TimingPhases{
-				TCPConnection: duration,
-				Total:         duration,
-			},
-		},
-		Response: probeHttp.Response{
-			StatusCode: 200,
-			Body:       []byte("OK"),
-		},
+	res.Timing.Phases.TCPConnection = time.Since(start)
+
+	if err == nil && opts.Query != "" {
+		queryStart := time.Now()
+
+		res.Query, err = probes.QuerySQL(ctxWithTimeout, db, opts.Query)
+
+		res.Timing.Phases.ServerProcessing = time.Since(queryStart)
 	}
 
+	res.Timing.Phases.Total = time.Since(start)
+
 	if err != nil {
-		res.Response.StatusCode = 503
-		res.Response.Body = []byte(err.Error())
+		if isAuthError(err) {
+			res.FailWithKind(probes.ErrorKindAuth, err)
+		} else {
+			res.Fail(err)
+		}
+
+		return res, nil
 	}
 
+	res.Success = true
+
 	return res, nil
 }
+
+// isAuthError tells whether the server rejected the credentials or the access
+// to the database
+func isAuthError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+
+	switch mysqlErr.Number {
+	case 1044, 1045, 1698: // ER_DBACCESS_DENIED_ERROR, ER_ACCESS_DENIED_ERROR, ER_ACCESS_DENIED_NO_PASSWORD_ERROR
+		return true
+	default:
+		return false
+	}
+}
@@ -3,14 +3,21 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
-	_ "github.com/lib/pq"
-	probeHttp "github.com/opsway-io/backend/internal/probes/http"
+	"github.com/lib/pq"
+	"github.com/opsway-io/backend/internal/probes"
 )
 
+// Options of a probe, the zero value only pings the server
+type Options struct {
+	// Query run once connected, the values it returns are asserted on
+	Query string
+}
+
 type Service interface {
-	Probe(ctx context.Context, target string, timeout time.Duration) (*probeHttp.Result, error)
+	Probe(ctx context.Context, target string, opts Options, timeout time.Duration) (*probes.Result, error)
 }
 
 type ServiceImpl struct{}
@@ -19,38 +26,55 @@ func NewService() Service {
 	return &ServiceImpl{}
 }
 
-func (s *ServiceImpl) Probe(ctx context.Context, target string, timeout time.Duration) (*probeHttp.Result, error) {
+func (s *ServiceImpl) Probe(ctx context.Context, target string, opts Options, timeout time.Duration) (*probes.Result, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	start := time.Now()
 
+	res := &probes.Result{}
+
 	db, err := sql.Open("postgres", target)
 	if err == nil {
 		defer db.Close()
-		
-		ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
-		defer cancel()
 
 		err = db.PingContext(ctxWithTimeout)
 	}
 
-	duration := time.Since(start)
-
-	res := &probeHttp.Result{
-		Timing: probeHttp.Timing{
-			Phases: probeHttp.TimingPhases{
-				TCPConnection: duration,
-				Total:         duration,
-			},
-		},
-		Response: probeHttp.Response{
-			StatusCode: 200,
-			Body:       []byte("OK"),
-		},
+	res.Timing.Phases.TCPConnection = time.Since(start)
+
+	if err == nil && opts.Query != "" {
+		queryStart := time.Now()
+
+		res.Query, err = probes.QuerySQL(ctxWithTimeout, db, opts.Query)
+
+		res.Timing.Phases.ServerProcessing = time.Since(queryStart)
 	}
 
+	res.Timing.Phases.Total = time.Since(start)
+
 	if err != nil {
-		res.Response.StatusCode = 503
-		res.Response.Body = []byte(err.Error())
+		if isAuthError(err) {
+			res.FailWithKind(probes.ErrorKindAuth, err)
+		} else {
+			res.Fail(err)
+		}
+
+		return res, nil
 	}
 
+	res.Success = true
+
 	return res, nil
 }
+
+// isAuthError tells whether the server rejected the credentials or the access
+// to the database, which are the errors of class 28
+func isAuthError(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	return pqErr.Code.Class() == "28"
+}
@@ -2,14 +2,46 @@ package redis
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
-	probeHttp "github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
 	"github.com/redis/go-redis/v9"
 )
 
+// Commands a monitor may run, which only read
+var allowedCommands = []string{
+	"PING", "INFO", "DBSIZE", "TIME", "ROLE", "LASTSAVE",
+	"EXISTS", "TYPE", "TTL", "PTTL", "GET", "STRLEN",
+	"LLEN", "LINDEX", "LRANGE", "SCARD", "SISMEMBER",
+	"ZCARD", "ZCOUNT", "ZSCORE", "HLEN", "HGET", "HEXISTS", "HGETALL",
+	"XLEN", "PFCOUNT",
+}
+
+var ErrCommandNotAllowed = errors.New("command not allowed")
+
+// ValidateQuery checks that the query only runs one of the allowed commands
+func ValidateQuery(query string) error {
+	args := strings.Fields(query)
+	if len(args) > 0 && !slices.Contains(allowedCommands, strings.ToUpper(args[0])) {
+		return fmt.Errorf("%w: %s", ErrCommandNotAllowed, args[0])
+	}
+
+	return nil
+}
+
+// Options of a probe, the zero value only pings the server
+type Options struct {
+	// Command run once connected, with its arguments separated by spaces,
+	// such as INFO replication or LLEN queue
+	Query string
+}
+
 type Service interface {
-	Probe(ctx context.Context, target string, timeout time.Duration) (*probeHttp.Result, error)
+	Probe(ctx context.Context, target string, opts Options, timeout time.Duration) (*probes.Result, error)
 }
 
 type ServiceImpl struct{}
@@ -18,42 +50,164 @@ func NewService() Service {
 	return &ServiceImpl{}
 }
 
-func (s *ServiceImpl) Probe(ctx context.Context, target string, timeout time.Duration) (*probeHttp.Result, error) {
+func (s *ServiceImpl) Probe(ctx context.Context, target string, opts Options, timeout time.Duration) (*probes.Result, error) {
+	if err := ValidateQuery(opts.Query); err != nil {
+		return nil, err
+	}
+
+	args := strings.Fields(opts.Query)
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	start := time.Now()
 
+	res := &probes.Result{}
+
 	opt, err := redis.ParseURL(target)
 	if err == nil {
 		opt.DialTimeout = timeout
 		opt.ReadTimeout = timeout
-		
+
 		client := redis.NewClient(opt)
 		defer client.Close()
-		
-		ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
-		defer cancel()
 
 		err = client.Ping(ctxWithTimeout).Err()
-	}
 
-	duration := time.Since(start)
+		res.Timing.Phases.TCPConnection = time.Since(start)
+
+		if err == nil && len(args) > 0 {
+			queryStart := time.Now()
+
+			res.Query, err = query(ctxWithTimeout, client, args)
 
-	res := &probeHttp.Result{
-		Timing: probeHttp.Timing{
-			Phases: probeHttp.TimingPhases{
-				TCPConnection: duration,
-				Total:         duration,
-			},
-		},
-		Response: probeHttp.Response{
-			StatusCode: 200,
-			Body:       []byte("OK"),
-		},
+			res.Timing.Phases.ServerProcessing = time.Since(queryStart)
+		}
 	}
 
+	res.Timing.Phases.Total = time.Since(start)
+
 	if err != nil {
-		res.Response.StatusCode = 503
-		res.Response.Body = []byte(err.Error())
+		if isAuthError(err) {
+			res.FailWithKind(probes.ErrorKindAuth, err)
+		} else {
+			res.Fail(err)
+		}
+
+		return res, nil
 	}
 
+	res.Success = true
+
 	return res, nil
 }
+
+// query runs the command and turns its reply into rows. Lists are a row per
+// element, maps and the sections of INFO a single row with a column per key,
+// and other replies a single value.
+func query(ctx context.Context, client *redis.Client, args []string) (*probes.QueryResult, error) {
+	cmdArgs := make([]any, len(args))
+	for i, arg := range args {
+		cmdArgs[i] = arg
+	}
+
+	reply, err := client.Do(ctx, cmdArgs...).Result()
+	if errors.Is(err, redis.Nil) {
+		return &probes.QueryResult{Columns: []string{"value"}, Rows: [][]string{}}, nil
+	}
+
+	if err != nil {
+		var redisErr redis.Error
+		if errors.As(err, &redisErr) && !isAuthError(err) {
+			return nil, &probes.Error{Kind: probes.ErrorKindBadResponse, Message: err.Error()}
+		}
+
+		return nil, err
+	}
+
+	if strings.EqualFold(args[0], "INFO") {
+		if info, ok := reply.(string); ok {
+			return parseInfo(info), nil
+		}
+	}
+
+	switch v := reply.(type) {
+	case []any:
+		res := &probes.QueryResult{Columns: []string{"value"}, Rows: [][]string{}}
+		for _, e := range v {
+			if len(res.Rows) == probes.MaxQueryRows {
+				break
+			}
+
+			res.Rows = append(res.Rows, []string{formatValue(e)})
+		}
+
+		return res, nil
+	case map[any]any:
+		values := make(map[string]string, len(v))
+		res := &probes.QueryResult{Columns: make([]string, 0, len(v))}
+		for k, e := range v {
+			key := formatValue(k)
+			values[key] = formatValue(e)
+			res.Columns = append(res.Columns, key)
+		}
+
+		slices.Sort(res.Columns)
+
+		row := make([]string, len(res.Columns))
+		for i, c := range res.Columns {
+			row[i] = values[c]
+		}
+
+		res.Rows = [][]string{row}
+
+		return res, nil
+	default:
+		return &probes.QueryResult{Columns: []string{"value"}, Rows: [][]string{{formatValue(v)}}}, nil
+	}
+}
+
+// parseInfo turns the key:value lines of INFO into a row, such as the
+// master_link_status and master_last_io_seconds_ago of a replica
+func parseInfo(info string) *probes.QueryResult {
+	res := &probes.QueryResult{Columns: []string{}}
+
+	row := []string{}
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+
+		res.Columns = append(res.Columns, key)
+		row = append(row, value)
+	}
+
+	res.Rows = [][]string{row}
+
+	return res
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// isAuthError tells whether the server rejected the credentials, or requires
+// them when none were given
+func isAuthError(err error) bool {
+	msg := err.Error()
+
+	return strings.HasPrefix(msg, "NOAUTH") || strings.HasPrefix(msg, "WRONGPASS")
+}
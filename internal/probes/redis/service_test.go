@@ -0,0 +1,33 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInfo(t *testing.T) {
+	t.Parallel()
+
+	res := parseInfo("# Replication\r\nrole:slave\r\nmaster_link_status:up\r\nmaster_last_io_seconds_ago:2\r\n")
+
+	assert.Equal(t, []string{"role", "master_link_status", "master_last_io_seconds_ago"}, res.Columns)
+	assert.Equal(t, [][]string{{"slave", "up", "2"}}, res.Rows)
+}
+
+func TestProbe_RejectsWritingCommands(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewService().Probe(context.Background(), "redis://localhost:6379", Options{Query: "FLUSHALL"}, time.Second)
+	assert.ErrorIs(t, err, ErrCommandNotAllowed)
+}
+
+func TestValidateQuery(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, ValidateQuery(""))
+	assert.NoError(t, ValidateQuery("llen queue"))
+	assert.ErrorIs(t, ValidateQuery("CONFIG SET dir /tmp"), ErrCommandNotAllowed)
+}
@@ -0,0 +1,204 @@
+package probes
+
+import (
+	"net/http"
+	"time"
+)
+
+// Result is the outcome of probing a target, the same for all protocols.
+// Details only some protocols have are set by the probes of those protocols.
+type Result struct {
+	// Whether the target responded as a healthy target of its protocol does
+	Success bool
+	// Why the probe did not succeed, nil on success
+	Error *Error
+
+	Timing Timing
+
+	// Set by the HTTP and browser probes when a response was received
+	HTTP *HTTPResponse
+	// Set by the DNS probe when the nameserver answered
+	DNS *DNSResponse
+	// Set by the ICMP probe once the echo requests were sent
+	Ping *PingStats
+	// Set by the TCP probe when it read from the connection
+	TCP *TCPResponse
+	// Set by the database probes when they ran a query
+	Query *QueryResult
+	// Set when the connection to the target is secured
+	TLS *TLS
+
+	// Protocol specific details about the target, such as the records found
+	// by a DNS lookup
+	Metadata map[string]string
+}
+
+type HTTPResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+type DNSResponse struct {
+	// Response code of the nameserver, such as NOERROR or NXDOMAIN
+	RCode string
+	// Records of the answer section, including the CNAME records followed
+	// to get to the records of the requested type
+	Records []DNSRecord
+}
+
+type DNSRecord struct {
+	Name string
+	Type string
+	TTL  uint32
+	// Presentation format of the record data without the trailing dot of
+	// names, such as "10 mail.example.com" for an MX record
+	Value string
+}
+
+// DNSRecordTypes are the types of records the DNS probe can look up
+var DNSRecordTypes = []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SOA", "CAA", "SRV"}
+
+// Max number of rows of a query result, the rows after are left out
+const MaxQueryRows = 100
+
+type QueryResult struct {
+	Columns []string
+	// Values of the rows in the order of the columns, formatted as strings.
+	// NULL values are empty strings.
+	Rows [][]string
+}
+
+// Value returns the value of the column of the first row, or of its first
+// column when the column is empty. It is false when there is no such value.
+func (q *QueryResult) Value(column string) (string, bool) {
+	if len(q.Rows) == 0 || len(q.Columns) == 0 {
+		return "", false
+	}
+
+	if column == "" {
+		return q.Rows[0][0], true
+	}
+
+	for i, c := range q.Columns {
+		if c == column {
+			return q.Rows[0][i], true
+		}
+	}
+
+	return "", false
+}
+
+type TCPResponse struct {
+	// Data read from the connection, the banner of the service or its
+	// response to the payload sent
+	Data []byte
+}
+
+type PingStats struct {
+	Sent     int
+	Received int
+	// Percentage of the echo requests that were not answered
+	PacketLoss float64
+
+	// Round trip times of the answered echo requests
+	MinRTT time.Duration
+	AvgRTT time.Duration
+	MaxRTT time.Duration
+	// Mean difference between the round trip times of consecutive replies
+	Jitter time.Duration
+}
+
+// NewPingStats computes the statistics of the round trip times of the replies
+// to the sent echo requests
+func NewPingStats(sent int, rtts []time.Duration) *PingStats {
+	stats := &PingStats{
+		Sent:     sent,
+		Received: len(rtts),
+	}
+
+	if sent > 0 {
+		stats.PacketLoss = float64(sent-len(rtts)) / float64(sent) * 100
+	}
+
+	if len(rtts) == 0 {
+		return stats
+	}
+
+	var total, deltas time.Duration
+	stats.MinRTT = rtts[0]
+	for i, rtt := range rtts {
+		total += rtt
+		stats.MinRTT = min(stats.MinRTT, rtt)
+		stats.MaxRTT = max(stats.MaxRTT, rtt)
+
+		if i > 0 {
+			delta := rtt - rtts[i-1]
+			if delta < 0 {
+				delta = -delta
+			}
+
+			deltas += delta
+		}
+	}
+
+	stats.AvgRTT = total / time.Duration(len(rtts))
+
+	if len(rtts) > 1 {
+		stats.Jitter = deltas / time.Duration(len(rtts)-1)
+	}
+
+	return stats
+}
+
+type Timing struct {
+	Phases TimingPhases
+}
+
+type TimingPhases struct {
+	DNSLookup        time.Duration
+	TCPConnection    time.Duration
+	TLSHandshake     time.Duration
+	ServerProcessing time.Duration
+	ContentTransfer  time.Duration
+	Total            time.Duration
+}
+
+type TLS struct {
+	Version     string
+	Cipher      string
+	Certificate Certificate
+}
+
+type Certificate struct {
+	Issuer     CertificateIssuer
+	Subject    CertificateSubject
+	NotBefore  time.Time
+	NotAfter   time.Time
+	NotExpired bool
+	HostValid  bool
+	TrustedCA  bool
+}
+
+type CertificateSubject struct {
+	CommonName string
+}
+
+type CertificateIssuer struct {
+	Organization string
+}
+
+// Fail marks the result as failed with the error, classified by ClassifyError
+func (r *Result) Fail(err error) {
+	r.FailWithKind(ClassifyError(err), err)
+}
+
+// FailWithKind marks the result as failed with the error of the given kind, for
+// errors that only the probe of a protocol can classify
+func (r *Result) FailWithKind(kind ErrorKind, err error) {
+	r.Success = false
+	r.Error = &Error{
+		Kind:    kind,
+		Message: err.Error(),
+	}
+}
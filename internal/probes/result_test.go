@@ -0,0 +1,61 @@
+package probes_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opsway-io/backend/internal/probes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPingStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("computes the round trip statistics", func(t *testing.T) {
+		stats := probes.NewPingStats(4, []time.Duration{
+			10 * time.Millisecond,
+			14 * time.Millisecond,
+			12 * time.Millisecond,
+		})
+
+		assert.Equal(t, &probes.PingStats{
+			Sent:       4,
+			Received:   3,
+			PacketLoss: 25,
+			MinRTT:     10 * time.Millisecond,
+			AvgRTT:     12 * time.Millisecond,
+			MaxRTT:     14 * time.Millisecond,
+			Jitter:     3 * time.Millisecond,
+		}, stats)
+	})
+
+	t.Run("reports full loss without replies", func(t *testing.T) {
+		stats := probes.NewPingStats(3, nil)
+
+		assert.Equal(t, 100.0, stats.PacketLoss)
+		assert.Zero(t, stats.AvgRTT)
+	})
+}
+
+func TestQueryResult_Value(t *testing.T) {
+	t.Parallel()
+
+	q := &probes.QueryResult{
+		Columns: []string{"count", "state"},
+		Rows:    [][]string{{"3", "stuck"}, {"1", "running"}},
+	}
+
+	value, ok := q.Value("")
+	assert.True(t, ok)
+	assert.Equal(t, "3", value)
+
+	value, ok = q.Value("state")
+	assert.True(t, ok)
+	assert.Equal(t, "stuck", value)
+
+	_, ok = q.Value("missing")
+	assert.False(t, ok)
+
+	_, ok = (&probes.QueryResult{Columns: []string{"count"}}).Value("")
+	assert.False(t, ok)
+}
@@ -0,0 +1,70 @@
+package probes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// QuerySQL runs the query in a read only transaction, so that a monitor cannot
+// change the data of the database it watches. Errors of the query itself are
+// of ErrorKindBadResponse, the database being reachable but not healthy.
+func QuerySQL(ctx context.Context, db *sql.DB, query string) (*QueryResult, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, queryError(ctx, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &QueryResult{
+		Columns: columns,
+		Rows:    [][]string{},
+	}
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	for len(res.Rows) < MaxQueryRows && rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, queryError(ctx, err)
+		}
+
+		row := make([]string, len(values))
+		for i, v := range values {
+			row[i] = v.String
+		}
+
+		res.Rows = append(res.Rows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, err)
+	}
+
+	return res, nil
+}
+
+func queryError(ctx context.Context, err error) error {
+	if ctx.Err() != nil || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	return &Error{
+		Kind:    ErrorKindBadResponse,
+		Message: err.Error(),
+	}
+}
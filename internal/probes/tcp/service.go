@@ -2,49 +2,220 @@ package tcp
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
 	"net"
+	"regexp"
 	"time"
 
-	probeHttp "github.com/opsway-io/backend/internal/probes/http"
+	"github.com/opsway-io/backend/internal/probes"
 )
 
+type Config struct {
+	// Max number of bytes read from the connection, defaults to 64KB
+	MaxReadBytes int `mapstructure:"max_read_bytes" default:"65536"`
+}
+
+// Options of a probe, the zero value only connects to the target
+type Options struct {
+	// Payload written to the connection once connected
+	Send []byte
+	// Regular expression the data read must match, reading stops once it does
+	Expect string
+	// Number of bytes to read, reading stops once as many were read
+	ReadBytes int
+	// Do a TLS handshake once connected
+	TLS bool
+}
+
+// reads tells whether the probe reads from the connection
+func (o Options) reads() bool {
+	return len(o.Send) > 0 || o.Expect != "" || o.ReadBytes > 0
+}
+
 type Service interface {
-	Probe(ctx context.Context, target string, timeout time.Duration) (*probeHttp.Result, error)
+	Probe(ctx context.Context, target string, opts Options, timeout time.Duration) (*probes.Result, error)
 }
 
-type ServiceImpl struct{}
+type ServiceImpl struct {
+	config Config
+}
 
-func NewService() Service {
-	return &ServiceImpl{}
+func NewService(config Config) Service {
+	return &ServiceImpl{
+		config: config,
+	}
 }
 
-func (s *ServiceImpl) Probe(ctx context.Context, target string, timeout time.Duration) (*probeHttp.Result, error) {
+// Probe connects to the target and, if told to, sends the payload and reads
+// the response. Responses that do not match the expectation are failed
+// results. Only invalid options return an error.
+//
+// Without an expectation or byte count, reading stops after the first data
+// the target sends, which is the banner of most line based protocols.
+func (s *ServiceImpl) Probe(ctx context.Context, target string, opts Options, timeout time.Duration) (*probes.Result, error) {
+	var expect *regexp.Regexp
+	if opts.Expect != "" {
+		var err error
+		if expect, err = regexp.Compile(opts.Expect); err != nil {
+			return nil, fmt.Errorf("invalid expectation: %w", err)
+		}
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	res := &probes.Result{}
+
 	start := time.Now()
 
-	dialer := net.Dialer{Timeout: timeout}
-	conn, err := dialer.DialContext(ctx, "tcp", target)
-	
-	duration := time.Since(start)
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(timeoutCtx, "tcp", target)
 
-	res := &probeHttp.Result{
-		Timing: probeHttp.Timing{
-			Phases: probeHttp.TimingPhases{
-				TCPConnection: duration,
-				Total:         duration,
-			},
-		},
-		Response: probeHttp.Response{
-			StatusCode: 200,
-			Body:       []byte("OK"),
-		},
-	}
+	res.Timing.Phases.TCPConnection = time.Since(start)
 
 	if err != nil {
-		res.Response.StatusCode = 503
-		res.Response.Body = []byte(err.Error())
-	} else {
-		conn.Close()
+		res.Timing.Phases.Total = time.Since(start)
+		res.Fail(err)
+
+		return res, nil
+	}
+	defer conn.Close()
+
+	res.Metadata = map[string]string{
+		"remote_address": conn.RemoteAddr().String(),
+	}
+
+	if deadline, ok := timeoutCtx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if opts.TLS {
+		handshakeStart := time.Now()
+
+		host, _, _ := net.SplitHostPort(target)
+
+		// The certificate is verified by the TLS assertions, so that it can be
+		// described even when it is not valid
+		tlsConn := tls.Client(conn, &tls.Config{
+			ServerName:         host,
+			InsecureSkipVerify: true, // nolint:gosec
+		})
+
+		err := tlsConn.HandshakeContext(timeoutCtx)
+
+		res.Timing.Phases.TLSHandshake = time.Since(handshakeStart)
+
+		if err != nil {
+			res.Timing.Phases.Total = time.Since(start)
+			res.Fail(err)
+
+			return res, nil
+		}
+
+		state := tlsConn.ConnectionState()
+		res.TLS = probes.NewTLS(&state, host)
+
+		conn = tlsConn
+	}
+
+	if !opts.reads() {
+		res.Timing.Phases.Total = time.Since(start)
+		res.Success = true
+
+		return res, nil
+	}
+
+	if len(opts.Send) > 0 {
+		if _, err := conn.Write(opts.Send); err != nil {
+			res.Timing.Phases.Total = time.Since(start)
+			res.Fail(err)
+
+			return res, nil
+		}
+	}
+
+	readStart := time.Now()
+
+	data, firstByteAt, err := s.read(conn, opts, expect)
+
+	if !firstByteAt.IsZero() {
+		res.Timing.Phases.ServerProcessing = firstByteAt.Sub(readStart)
+		res.Timing.Phases.ContentTransfer = time.Since(firstByteAt)
+	}
+
+	res.Timing.Phases.Total = time.Since(start)
+	res.TCP = &probes.TCPResponse{
+		Data: data,
+	}
+
+	// No more than the maximum is read, which is enough to succeed
+	readBytes := min(opts.ReadBytes, s.maxReadBytes())
+
+	switch {
+	case expect != nil && !expect.Match(data):
+		if err != nil && !errors.Is(err, io.EOF) {
+			res.Fail(fmt.Errorf("no response matching %q: %w", opts.Expect, err))
+		} else {
+			res.FailWithKind(probes.ErrorKindBadResponse, fmt.Errorf("response does not match %q", opts.Expect))
+		}
+	case readBytes > 0 && len(data) < readBytes:
+		if err != nil && !errors.Is(err, io.EOF) {
+			res.Fail(fmt.Errorf("read %d of %d bytes: %w", len(data), readBytes, err))
+		} else {
+			res.FailWithKind(probes.ErrorKindBadResponse, fmt.Errorf("connection closed after %d of %d bytes", len(data), readBytes))
+		}
+	case len(data) == 0 && err != nil:
+		res.Fail(fmt.Errorf("no response: %w", err))
+	default:
+		res.Success = true
 	}
 
 	return res, nil
 }
+
+// read reads from the connection until the data matches the expectation, the
+// number of bytes to read were read, or the connection is closed. Without
+// either it stops after the first read.
+func (s *ServiceImpl) read(conn net.Conn, opts Options, expect *regexp.Regexp) (data []byte, firstByteAt time.Time, err error) {
+	limit := s.maxReadBytes()
+	if opts.ReadBytes > 0 && opts.ReadBytes < limit {
+		limit = opts.ReadBytes
+	}
+
+	buf := make([]byte, 4096)
+	for len(data) < limit {
+		n, err := conn.Read(buf[:min(len(buf), limit-len(data))])
+		if n > 0 {
+			if firstByteAt.IsZero() {
+				firstByteAt = time.Now()
+			}
+
+			data = append(data, buf[:n]...)
+		}
+
+		if err != nil {
+			return data, firstByteAt, err
+		}
+
+		if expect != nil && expect.Match(data) {
+			break
+		}
+
+		if expect == nil && opts.ReadBytes == 0 {
+			break
+		}
+	}
+
+	return data, firstByteAt, nil
+}
+
+func (s *ServiceImpl) maxReadBytes() int {
+	if s.config.MaxReadBytes <= 0 {
+		return 64 * 1024
+	}
+
+	return s.config.MaxReadBytes
+}
@@ -0,0 +1,152 @@
+package tcp_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/opsway-io/backend/internal/probes"
+	"github.com/opsway-io/backend/internal/probes/tcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serve accepts connections, greets them with a banner and echoes the lines
+// it receives in upper case
+func serve(t *testing.T, l net.Listener) string {
+	t.Helper()
+
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				_, _ = conn.Write([]byte("220 mail.example.com ESMTP\r\n"))
+
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					if scanner.Text() == "QUIT" {
+						_, _ = conn.Write([]byte("221 Bye\r\n"))
+
+						return
+					}
+
+					_, _ = conn.Write([]byte("250 OK\r\n"))
+				}
+			}()
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+func TestTCPProbeService(t *testing.T) {
+	t.Parallel()
+
+	svc := tcp.NewService(tcp.Config{})
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	addr := serve(t, l)
+
+	t.Run("connects without reading", func(t *testing.T) {
+		res, err := svc.Probe(context.Background(), addr, tcp.Options{}, time.Second)
+		require.NoError(t, err)
+
+		assert.True(t, res.Success)
+		assert.Nil(t, res.TCP)
+	})
+
+	t.Run("reads the banner", func(t *testing.T) {
+		res, err := svc.Probe(context.Background(), addr, tcp.Options{Expect: `^220 `}, time.Second)
+		require.NoError(t, err)
+
+		assert.True(t, res.Success)
+		assert.Equal(t, "220 mail.example.com ESMTP\r\n", string(res.TCP.Data))
+	})
+
+	t.Run("sends the payload and reads until the expectation is met", func(t *testing.T) {
+		res, err := svc.Probe(context.Background(), addr, tcp.Options{Send: []byte("QUIT\r\n"), Expect: `221 Bye`}, time.Second)
+		require.NoError(t, err)
+
+		assert.True(t, res.Success)
+		assert.Contains(t, string(res.TCP.Data), "221 Bye")
+	})
+
+	t.Run("reads the number of bytes", func(t *testing.T) {
+		res, err := svc.Probe(context.Background(), addr, tcp.Options{ReadBytes: 3}, time.Second)
+		require.NoError(t, err)
+
+		assert.True(t, res.Success)
+		assert.Equal(t, "220", string(res.TCP.Data))
+	})
+
+	t.Run("reads no more than the maximum", func(t *testing.T) {
+		capped := tcp.NewService(tcp.Config{MaxReadBytes: 3})
+
+		res, err := capped.Probe(context.Background(), addr, tcp.Options{ReadBytes: 10}, time.Second)
+		require.NoError(t, err)
+
+		assert.True(t, res.Success)
+		assert.Equal(t, "220", string(res.TCP.Data))
+	})
+
+	t.Run("fails when the connection closes before the expectation is met", func(t *testing.T) {
+		res, err := svc.Probe(context.Background(), addr, tcp.Options{Send: []byte("QUIT\r\n"), Expect: `^SSH-`}, time.Second)
+		require.NoError(t, err)
+
+		assert.False(t, res.Success)
+		assert.Equal(t, probes.ErrorKindBadResponse, res.Error.Kind)
+	})
+
+	t.Run("times out when the expectation is never met", func(t *testing.T) {
+		res, err := svc.Probe(context.Background(), addr, tcp.Options{Expect: `^SSH-`}, 200*time.Millisecond)
+		require.NoError(t, err)
+
+		assert.False(t, res.Success)
+		assert.Equal(t, probes.ErrorKindTimeout, res.Error.Kind)
+	})
+
+	t.Run("rejects invalid expectations", func(t *testing.T) {
+		_, err := svc.Probe(context.Background(), addr, tcp.Options{Expect: `(`}, time.Second)
+		assert.Error(t, err)
+	})
+}
+
+func TestTCPProbeService_TLS(t *testing.T) {
+	t.Parallel()
+
+	svc := tcp.NewService(tcp.Config{})
+
+	// Borrow the self signed certificate of the HTTP test server
+	srv := httptest.NewUnstartedServer(nil)
+	srv.StartTLS()
+	cert := srv.TLS.Certificates
+	srv.Close()
+
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: cert})
+	require.NoError(t, err)
+
+	addr := serve(t, l)
+
+	res, err := svc.Probe(context.Background(), addr, tcp.Options{Expect: `^220 `, TLS: true}, time.Second)
+	require.NoError(t, err)
+
+	assert.True(t, res.Success)
+	assert.Equal(t, "220 mail.example.com ESMTP\r\n", string(res.TCP.Data))
+	require.NotNil(t, res.TLS)
+	assert.True(t, res.TLS.Certificate.HostValid)
+	assert.False(t, res.TLS.Certificate.TrustedCA)
+}
@@ -0,0 +1,74 @@
+package probes
+
+import (
+	btls "crypto/tls"
+	"crypto/x509"
+	"strings"
+	"time"
+)
+
+//nolint:gochecknoglobals
+var versions = map[uint16]string{
+	btls.VersionTLS10: "TLS 1.0",
+	btls.VersionTLS11: "TLS 1.1",
+	btls.VersionTLS12: "TLS 1.2",
+	btls.VersionTLS13: "TLS 1.3",
+}
+
+func TLSVersionName(version uint16) string {
+	return versions[version]
+}
+
+// NewTLS describes the secured connection to the host. The certificate is
+// described even when it is expired or not valid for the host, which is why
+// probes do the handshake without verifying it.
+func NewTLS(state *btls.ConnectionState, host string) *TLS {
+	t := &TLS{
+		Version: TLSVersionName(state.Version),
+		Cipher:  btls.CipherSuiteName(state.CipherSuite),
+	}
+
+	if len(state.PeerCertificates) == 0 {
+		return t
+	}
+
+	cert := state.PeerCertificates[0]
+
+	t.Certificate = Certificate{
+		Issuer: CertificateIssuer{
+			Organization: strings.Join(cert.Issuer.Organization, ""),
+		},
+		Subject: CertificateSubject{
+			CommonName: cert.Subject.CommonName,
+		},
+		NotBefore:  cert.NotBefore,
+		NotAfter:   cert.NotAfter,
+		NotExpired: certificateNotExpired(cert),
+		HostValid:  certificateHostValid(cert, host),
+		TrustedCA:  certificateTrusted(state, host),
+	}
+
+	return t
+}
+
+func certificateNotExpired(cert *x509.Certificate) (notExpired bool) {
+	now := time.Now()
+
+	return now.Before(cert.NotAfter) && now.After(cert.NotBefore)
+}
+
+func certificateHostValid(cert *x509.Certificate, host string) (hostValid bool) {
+	return cert.VerifyHostname(host) == nil
+}
+
+func certificateTrusted(state *btls.ConnectionState, host string) bool {
+	opts := x509.VerifyOptions{
+		DNSName:       host,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range state.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(opts)
+	return err == nil
+}
@@ -1,6 +1,7 @@
 package alerting
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -81,11 +82,11 @@ func (h *Handlers) GetAlertRule(c handlers.AuthenticatedContext) error {
 }
 
 type PostAlertRuleRequest struct {
-	TeamID    uint   `param:"teamId" validate:"required,numeric,gte=0"`
-	Name      string `json:"name" validate:"required"`
-	Condition string `json:"condition" validate:"required"`
-	Channels  string `json:"channels" validate:"required"`
-	Enabled   bool   `json:"enabled"`
+	TeamID     uint                          `param:"teamId" validate:"required,numeric,gte=0"`
+	Name       string                        `json:"name" validate:"required"`
+	Conditions *entities.AlertRuleConditions `json:"conditions" validate:"required"`
+	Channels   string                        `json:"channels" validate:"required"`
+	Enabled    bool                          `json:"enabled"`
 }
 
 func (h *Handlers) PostAlertRule(c handlers.AuthenticatedContext) error {
@@ -96,15 +97,20 @@ func (h *Handlers) PostAlertRule(c handlers.AuthenticatedContext) error {
 	}
 
 	rule := &entities.AlertRule{
-		TeamID:    req.TeamID,
-		Name:      req.Name,
-		Condition: req.Condition,
-		Channels:  req.Channels,
-		Enabled:   req.Enabled,
+		TeamID:     req.TeamID,
+		Name:       req.Name,
+		Conditions: req.Conditions,
+		Channels:   req.Channels,
+		Enabled:    req.Enabled,
 	}
 
 	err = h.AlertingService.Create(c.Request().Context(), rule)
 	if err != nil {
+		if errors.Is(err, alerting.ErrInvalidRule) {
+			c.Log.WithError(err).Debug("invalid alert rule")
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
 		c.Log.WithError(err).Error("failed to create alert rule")
 		return echo.ErrInternalServerError
 	}
@@ -113,12 +119,12 @@ func (h *Handlers) PostAlertRule(c handlers.AuthenticatedContext) error {
 }
 
 type PutAlertRuleRequest struct {
-	TeamID    uint   `param:"teamId" validate:"required,numeric,gte=0"`
-	RuleID    uint   `param:"ruleId" validate:"required,numeric,gte=0"`
-	Name      string `json:"name" validate:"required"`
-	Condition string `json:"condition" validate:"required"`
-	Channels  string `json:"channels" validate:"required"`
-	Enabled   bool   `json:"enabled"`
+	TeamID     uint                          `param:"teamId" validate:"required,numeric,gte=0"`
+	RuleID     uint                          `param:"ruleId" validate:"required,numeric,gte=0"`
+	Name       string                        `json:"name" validate:"required"`
+	Conditions *entities.AlertRuleConditions `json:"conditions" validate:"required"`
+	Channels   string                        `json:"channels" validate:"required"`
+	Enabled    bool                          `json:"enabled"`
 }
 
 func (h *Handlers) PutAlertRule(c handlers.AuthenticatedContext) error {
@@ -135,12 +141,18 @@ func (h *Handlers) PutAlertRule(c handlers.AuthenticatedContext) error {
 	}
 
 	rule.Name = req.Name
-	rule.Condition = req.Condition
+	rule.Condition = ""
+	rule.Conditions = req.Conditions
 	rule.Channels = req.Channels
 	rule.Enabled = req.Enabled
 
 	err = h.AlertingService.Update(c.Request().Context(), rule)
 	if err != nil {
+		if errors.Is(err, alerting.ErrInvalidRule) {
+			c.Log.WithError(err).Debug("invalid alert rule")
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
 		c.Log.WithError(err).Error("failed to update alert rule")
 		return echo.ErrInternalServerError
 	}
@@ -0,0 +1,61 @@
+package incidents
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/incident"
+	hs "github.com/opsway-io/backend/internal/rest/handlers"
+	"github.com/opsway-io/backend/internal/rest/helpers"
+)
+
+// Time range of the analytics when the request does not say
+const defaultAnalyticsRange = 30 * 24 * time.Hour
+
+type GetIncidentAnalyticsRequest struct {
+	TeamID        uint       `param:"teamId" validate:"required,numeric,gte=0"`
+	MonitorID     *uint      `query:"monitorId" validate:"omitempty,numeric,gte=0"`
+	Start         *time.Time `query:"start"`
+	End           *time.Time `query:"end"`
+	NoisyMonitors int        `query:"noisyMonitors" validate:"omitempty,numeric,gte=0,max=100"`
+}
+
+// GetIncidentAnalytics returns the mean times to acknowledge and resolve the
+// incidents of the team opened in a time range, the last 30 days by default,
+// along with their breakdowns by kind, severity, hour and monitor.
+func (h *Handlers) GetIncidentAnalytics(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[GetIncidentAnalyticsRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind GetIncidentAnalyticsRequest")
+		return echo.ErrBadRequest
+	}
+
+	filter := incident.AnalyticsFilter{
+		Start:         time.Now().Add(-defaultAnalyticsRange),
+		End:           time.Now(),
+		MonitorID:     req.MonitorID,
+		NoisyMonitors: req.NoisyMonitors,
+	}
+
+	if req.Start != nil {
+		filter.Start = *req.Start
+	}
+
+	if req.End != nil {
+		filter.End = *req.End
+	}
+
+	analytics, err := h.IncidentService.GetAnalytics(c.Request().Context(), req.TeamID, filter)
+	if err != nil {
+		if errors.Is(err, incident.ErrInvalidTimeRange) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		c.Log.WithError(err).Error("failed to get incident analytics")
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, analytics)
+}
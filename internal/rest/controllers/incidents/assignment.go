@@ -0,0 +1,215 @@
+package incidents
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/incident"
+	hs "github.com/opsway-io/backend/internal/rest/handlers"
+	"github.com/opsway-io/backend/internal/rest/helpers"
+)
+
+type PutIncidentAssigneeRequest struct {
+	TeamID     uint  `param:"teamId" validate:"required,numeric,gte=0"`
+	IncidentID uint  `param:"incidentId" validate:"required,numeric,gte=0"`
+	UserID     *uint `json:"userId"`
+}
+
+// PutIncidentAssignee assigns the incident to a team member, a null user
+// unassigns it.
+func (h *Handlers) PutIncidentAssignee(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[PutIncidentAssigneeRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind PutIncidentAssigneeRequest")
+		return echo.ErrBadRequest
+	}
+
+	ctx := c.Request().Context()
+	in, err := h.IncidentService.GetByID(ctx, req.IncidentID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get incident")
+		return echo.ErrInternalServerError
+	}
+
+	// Verify it belongs to the team
+	if in.TeamID != req.TeamID {
+		return echo.ErrForbidden
+	}
+
+	if req.UserID != nil && !h.isTeamMember(c, req.TeamID, *req.UserID) {
+		return echo.NewHTTPError(http.StatusBadRequest, "user is not a member of the team")
+	}
+
+	if err := h.IncidentService.Assign(ctx, in, req.UserID, c.UserID); err != nil {
+		c.Log.WithError(err).Error("failed to assign incident")
+		return echo.ErrInternalServerError
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+type PutIncidentCommanderRequest struct {
+	TeamID     uint  `param:"teamId" validate:"required,numeric,gte=0"`
+	IncidentID uint  `param:"incidentId" validate:"required,numeric,gte=0"`
+	UserID     *uint `json:"userId"`
+}
+
+func (h *Handlers) PutIncidentCommander(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[PutIncidentCommanderRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind PutIncidentCommanderRequest")
+		return echo.ErrBadRequest
+	}
+
+	ctx := c.Request().Context()
+	in, err := h.IncidentService.GetByID(ctx, req.IncidentID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get incident")
+		return echo.ErrInternalServerError
+	}
+
+	// Verify it belongs to the team
+	if in.TeamID != req.TeamID {
+		return echo.ErrForbidden
+	}
+
+	if req.UserID != nil && !h.isTeamMember(c, req.TeamID, *req.UserID) {
+		return echo.NewHTTPError(http.StatusBadRequest, "user is not a member of the team")
+	}
+
+	if err := h.IncidentService.SetCommander(ctx, in, req.UserID); err != nil {
+		c.Log.WithError(err).Error("failed to set incident commander")
+		return echo.ErrInternalServerError
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+type GetIncidentRespondersResponse struct {
+	Responders []GetIncidentRespondersResponseResponder `json:"responders"`
+}
+
+type GetIncidentRespondersResponseResponder struct {
+	UserID    uint   `json:"userId"`
+	CreatedAt string `json:"createdAt"`
+}
+
+func (h *Handlers) GetIncidentResponders(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[GetIncidentRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind GetIncidentRequest")
+		return echo.ErrBadRequest
+	}
+
+	ctx := c.Request().Context()
+	in, err := h.IncidentService.GetByID(ctx, req.IncidentID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get incident")
+		return echo.ErrInternalServerError
+	}
+
+	// Verify it belongs to the team
+	if in.TeamID != req.TeamID {
+		return echo.ErrForbidden
+	}
+
+	responders, err := h.IncidentService.GetResponders(ctx, in.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get incident responders")
+		return echo.ErrInternalServerError
+	}
+
+	resp := &GetIncidentRespondersResponse{
+		Responders: make([]GetIncidentRespondersResponseResponder, len(responders)),
+	}
+
+	for i, r := range responders {
+		resp.Responders[i] = GetIncidentRespondersResponseResponder{
+			UserID:    r.UserID,
+			CreatedAt: r.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+type PostIncidentResponderRequest struct {
+	TeamID     uint `param:"teamId" validate:"required,numeric,gte=0"`
+	IncidentID uint `param:"incidentId" validate:"required,numeric,gte=0"`
+	UserID     uint `json:"userId" validate:"required,numeric,gt=0"`
+}
+
+func (h *Handlers) PostIncidentResponder(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[PostIncidentResponderRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind PostIncidentResponderRequest")
+		return echo.ErrBadRequest
+	}
+
+	ctx := c.Request().Context()
+	in, err := h.IncidentService.GetByID(ctx, req.IncidentID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get incident")
+		return echo.ErrInternalServerError
+	}
+
+	// Verify it belongs to the team
+	if in.TeamID != req.TeamID {
+		return echo.ErrForbidden
+	}
+
+	if !h.isTeamMember(c, req.TeamID, req.UserID) {
+		return echo.NewHTTPError(http.StatusBadRequest, "user is not a member of the team")
+	}
+
+	if err := h.IncidentService.AddResponder(ctx, in.ID, req.UserID); err != nil {
+		c.Log.WithError(err).Error("failed to add incident responder")
+		return echo.ErrInternalServerError
+	}
+
+	return c.NoContent(http.StatusCreated)
+}
+
+type DeleteIncidentResponderRequest struct {
+	TeamID     uint `param:"teamId" validate:"required,numeric,gte=0"`
+	IncidentID uint `param:"incidentId" validate:"required,numeric,gte=0"`
+	UserID     uint `param:"userId" validate:"required,numeric,gt=0"`
+}
+
+func (h *Handlers) DeleteIncidentResponder(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[DeleteIncidentResponderRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind DeleteIncidentResponderRequest")
+		return echo.ErrBadRequest
+	}
+
+	ctx := c.Request().Context()
+	in, err := h.IncidentService.GetByID(ctx, req.IncidentID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get incident")
+		return echo.ErrInternalServerError
+	}
+
+	// Verify it belongs to the team
+	if in.TeamID != req.TeamID {
+		return echo.ErrForbidden
+	}
+
+	if err := h.IncidentService.RemoveResponder(ctx, in.ID, req.UserID); err != nil {
+		if errors.Is(err, incident.ErrNotFound) {
+			return echo.ErrNotFound
+		}
+
+		c.Log.WithError(err).Error("failed to remove incident responder")
+		return echo.ErrInternalServerError
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *Handlers) isTeamMember(c hs.AuthenticatedContext, teamID, userID uint) bool {
+	role, err := h.TeamService.GetUserRole(c.Request().Context(), teamID, userID)
+
+	return err == nil && role != nil
+}
@@ -15,6 +15,8 @@ type GetIncidentsRequest struct {
 	TeamID uint `param:"teamId" validate:"required,numeric,gte=0"`
 	Offset *int `query:"offset" validate:"omitempty,numeric,gte=0"`
 	Limit  *int `query:"limit" validate:"omitempty,numeric,gte=0,max=255"`
+	// mine: assigned to, commanded or responded to by the user, assigned: assigned to the user
+	Filter string `query:"filter" validate:"omitempty,oneof=mine assigned unassigned"`
 }
 
 type GetIncidentsResponse struct {
@@ -28,9 +30,48 @@ type GetIncidentsResponseIncident struct {
 	HeartbeatID *uint   `json:"heartbeatId"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
+	Status      string `json:"status"`
+	Severity    string `json:"severity"`
+	Kind        string `json:"kind"`
+	AssigneeID  *uint  `json:"assigneeId"`
+	CommanderID *uint  `json:"commanderId"`
+	FailedLocations []string `json:"failedLocations"`
+	Children    []IncidentChild `json:"children"`
+	SuppressedReason *string `json:"suppressedReason"`
 	CreatedAt   string `json:"createdAt"`
 }
 
+// IncidentChild is an incident grouped under a parent incident
+type IncidentChild struct {
+	ID          uint   `json:"id"`
+	MonitorID   *uint  `json:"monitorId"`
+	HeartbeatID *uint  `json:"heartbeatId"`
+	Title       string `json:"title"`
+	Status      string `json:"status"`
+	Severity    string `json:"severity"`
+	Resolved    bool   `json:"resolved"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+func newIncidentChildren(children []entities.Incident) []IncidentChild {
+	res := make([]IncidentChild, len(children))
+
+	for i, child := range children {
+		res[i] = IncidentChild{
+			ID:          child.ID,
+			MonitorID:   child.MonitorID,
+			HeartbeatID: child.HeartbeatID,
+			Title:       child.Title,
+			Status:      string(child.Status),
+			Severity:    string(child.Severity),
+			Resolved:    child.Resolved,
+			CreatedAt:   child.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+
+	return res
+}
+
 func (h *Handlers) GetIncidents(c hs.AuthenticatedContext) error {
 	req, err := helpers.Bind[GetIncidentsRequest](c)
 	if err != nil {
@@ -41,9 +82,24 @@ func (h *Handlers) GetIncidents(c hs.AuthenticatedContext) error {
 
 	ctx := c.Request().Context()
 
+	var filter incident.Filter
+	switch req.Filter {
+	case "mine":
+		filter.InvolvedUserID = &c.UserID
+	case "assigned":
+		filter.AssigneeID = &c.UserID
+	case "unassigned":
+		filter.Unassigned = true
+	default:
+		// Grouped incidents are listed under their parent, unless the user
+		// is looking for their own incidents
+		filter.TopLevel = true
+	}
+
 	incidents, err := h.IncidentService.GetByTeamIDPaginated(
 		ctx,
 		req.TeamID,
+		filter,
 		req.Offset,
 		req.Limit)
 	if err != nil {
@@ -70,6 +126,14 @@ func (h *Handlers) newGetIncidentResponse(incidents *[]entities.Incident) *GetIn
 			HeartbeatID: in.HeartbeatID,
 			Title:       in.Title,
 			Description: *in.Description,
+			Status:      string(in.Status),
+			Severity:    string(in.Severity),
+			Kind:        string(in.Kind),
+			AssigneeID:  in.AssigneeID,
+			CommanderID: in.CommanderID,
+			FailedLocations: in.FailedLocations,
+			Children:    newIncidentChildren(in.Children),
+			SuppressedReason: in.SuppressedReason,
 			CreatedAt:   in.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		}
 	}
@@ -109,6 +173,7 @@ func (h *Handlers) GetIncidentOverview(c hs.AuthenticatedContext) error {
 	incidents, err := h.IncidentService.GetByTeamIDPaginated(
 		ctx,
 		req.TeamID,
+		incident.Filter{},
 		req.Offset,
 		req.Limit)
 	if err != nil {
@@ -160,6 +225,7 @@ type GetMonitorIncidentsResponseIncident struct {
 	HeartbeatID *uint   `json:"heartbeatId"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
+	FailedLocations []string `json:"failedLocations"`
 	CreatedAt   string `json:"createdAt"`
 	UpdatedAt   string `json:"updatedAt"`
 	Property    string `json:"property"`
@@ -221,6 +287,7 @@ func (h *Handlers) GetMonitorIncidentsResponse(incidents *[]incident.IncidentAnd
 			HeartbeatID: in.HeartbeatID,
 			Title:       in.Title,
 			Description: *in.Description,
+			FailedLocations: in.FailedLocations,
 			CreatedAt:   in.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 			UpdatedAt:   in.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 			Property:    property,
@@ -244,6 +311,17 @@ type GetIncidentResponse struct {
 	HeartbeatID *uint   `json:"heartbeatId"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
+	Status      string `json:"status"`
+	Severity    string `json:"severity"`
+	Kind        string `json:"kind"`
+	AssigneeID  *uint  `json:"assigneeId"`
+	CommanderID *uint  `json:"commanderId"`
+	FailedLocations []string `json:"failedLocations"`
+	ParentID    *uint  `json:"parentId"`
+	MergedIntoID *uint `json:"mergedIntoId"`
+	SplitFromID *uint  `json:"splitFromId"`
+	Children    []IncidentChild `json:"children"`
+	SuppressedReason *string `json:"suppressedReason"`
 	Resolved    bool   `json:"resolved"`
 	Acknowledged bool  `json:"acknowledged"`
 	AcknowledgedAt *string `json:"acknowledgedAt,omitempty"`
@@ -278,10 +356,28 @@ func (h *Handlers) GetIncident(c hs.AuthenticatedContext) error {
 		HeartbeatID: in.HeartbeatID,
 		Title:       in.Title,
 		Description: *in.Description,
+		Status:      string(in.Status),
+		Severity:    string(in.Severity),
+		Kind:        string(in.Kind),
+		AssigneeID:  in.AssigneeID,
+		CommanderID: in.CommanderID,
+		FailedLocations: in.FailedLocations,
+		ParentID:    in.ParentID,
+		MergedIntoID: in.MergedIntoID,
+		SplitFromID: in.SplitFromID,
+		SuppressedReason: in.SuppressedReason,
 		Resolved:    in.Resolved,
 		Acknowledged: in.Acknowledged,
 	}
 
+	children, err := h.IncidentService.GetChildren(ctx, in.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get child incidents")
+		return echo.ErrInternalServerError
+	}
+
+	resp.Children = newIncidentChildren(children)
+
 	if in.AcknowledgedAt != nil {
 		ackAt := in.AcknowledgedAt.Format("2006-01-02T15:04:05Z07:00")
 		resp.AcknowledgedAt = &ackAt
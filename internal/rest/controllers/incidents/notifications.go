@@ -0,0 +1,77 @@
+package incidents
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/incident"
+	hs "github.com/opsway-io/backend/internal/rest/handlers"
+	"github.com/opsway-io/backend/internal/rest/helpers"
+)
+
+type GetIncidentNotificationsResponse struct {
+	Notifications []GetIncidentNotificationsResponseNotification `json:"notifications"`
+}
+
+type GetIncidentNotificationsResponseNotification struct {
+	ID           uint    `json:"id"`
+	AlertRuleID  *uint   `json:"alertRuleId"`
+	Channel      string  `json:"channel"`
+	Recipient    string  `json:"recipient"`
+	Attempt      int     `json:"attempt"`
+	Status       string  `json:"status"`
+	ResponseCode *int    `json:"responseCode,omitempty"`
+	Error        *string `json:"error,omitempty"`
+	CreatedAt    string  `json:"createdAt"`
+}
+
+func (h *Handlers) GetIncidentNotifications(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[GetIncidentRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind GetIncidentRequest")
+		return echo.ErrBadRequest
+	}
+
+	ctx := c.Request().Context()
+	in, err := h.IncidentService.GetByID(ctx, req.IncidentID)
+	if err != nil {
+		if errors.Is(err, incident.ErrNotFound) {
+			return echo.ErrNotFound
+		}
+
+		c.Log.WithError(err).Error("failed to get incident")
+		return echo.ErrInternalServerError
+	}
+
+	// Verify it belongs to the team
+	if in.TeamID != req.TeamID {
+		return echo.ErrForbidden
+	}
+
+	attempts, err := h.DeliveryService.GetByIncidentID(ctx, in.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get notification attempts")
+		return echo.ErrInternalServerError
+	}
+
+	resp := &GetIncidentNotificationsResponse{
+		Notifications: make([]GetIncidentNotificationsResponseNotification, len(attempts)),
+	}
+
+	for i, a := range attempts {
+		resp.Notifications[i] = GetIncidentNotificationsResponseNotification{
+			ID:           a.ID,
+			AlertRuleID:  a.AlertRuleID,
+			Channel:      a.Channel,
+			Recipient:    a.Recipient,
+			Attempt:      a.Attempt,
+			Status:       string(a.Status),
+			ResponseCode: a.ResponseCode,
+			Error:        a.Error,
+			CreatedAt:    a.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
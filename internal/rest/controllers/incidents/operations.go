@@ -0,0 +1,170 @@
+package incidents
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/entities"
+	"github.com/opsway-io/backend/internal/incident"
+	hs "github.com/opsway-io/backend/internal/rest/handlers"
+	"github.com/opsway-io/backend/internal/rest/helpers"
+)
+
+type PatchMergeIncidentRequest struct {
+	TeamID           uint `param:"teamId" validate:"required,numeric,gte=0"`
+	IncidentID       uint `param:"incidentId" validate:"required,numeric,gte=0"`
+	TargetIncidentID uint `json:"targetIncidentId" validate:"required,numeric,gte=0"`
+}
+
+// PatchMergeIncident merges a duplicate incident into the target incident,
+// which takes over its comments, acknowledgement and notifications.
+func (h *Handlers) PatchMergeIncident(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[PatchMergeIncidentRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind PatchMergeIncidentRequest")
+		return echo.ErrBadRequest
+	}
+
+	ctx := c.Request().Context()
+	in, err := h.IncidentService.GetByID(ctx, req.IncidentID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get incident")
+		return echo.ErrInternalServerError
+	}
+
+	// Verify it belongs to the team
+	if in.TeamID != req.TeamID {
+		return echo.ErrForbidden
+	}
+
+	target, err := h.IncidentService.GetByID(ctx, req.TargetIncidentID)
+	if err != nil {
+		if errors.Is(err, incident.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusBadRequest, "target incident not found")
+		}
+
+		c.Log.WithError(err).Error("failed to get target incident")
+		return echo.ErrInternalServerError
+	}
+
+	if err := h.IncidentService.Merge(ctx, in, target, c.UserID); err != nil {
+		if errors.Is(err, incident.ErrInvalidMerge) || errors.Is(err, incident.ErrIncidentMerged) || errors.Is(err, incident.ErrIncidentResolved) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		c.Log.WithError(err).Error("failed to merge incident")
+		return echo.ErrInternalServerError
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+type PostSplitIncidentRequest struct {
+	TeamID      uint    `param:"teamId" validate:"required,numeric,gte=0"`
+	IncidentID  uint    `param:"incidentId" validate:"required,numeric,gte=0"`
+	Title       string  `json:"title" validate:"required,max=255"`
+	Description *string `json:"description" validate:"omitempty,max=10000"`
+	Severity    *string `json:"severity"`
+	CommentIDs  []uint  `json:"commentIds"`
+}
+
+type PostSplitIncidentResponse struct {
+	ID uint `json:"id"`
+}
+
+// PostSplitIncident opens a new incident for a problem that turned out to be
+// covered by the incident, the given comments are moved to the new incident.
+func (h *Handlers) PostSplitIncident(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[PostSplitIncidentRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind PostSplitIncidentRequest")
+		return echo.ErrBadRequest
+	}
+
+	ctx := c.Request().Context()
+	in, err := h.IncidentService.GetByID(ctx, req.IncidentID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get incident")
+		return echo.ErrInternalServerError
+	}
+
+	// Verify it belongs to the team
+	if in.TeamID != req.TeamID {
+		return echo.ErrForbidden
+	}
+
+	split := &entities.Incident{
+		Title:       req.Title,
+		Description: req.Description,
+		MonitorID:   in.MonitorID,
+		HeartbeatID: in.HeartbeatID,
+	}
+
+	if req.Severity != nil {
+		split.Severity = entities.IncidentSeverity(*req.Severity)
+		if !split.Severity.IsValid() {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid incident severity")
+		}
+	}
+
+	if err := h.IncidentService.Split(ctx, in, split, req.CommentIDs, c.UserID); err != nil {
+		if errors.Is(err, incident.ErrIncidentMerged) || errors.Is(err, incident.ErrCommentNotFound) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		c.Log.WithError(err).Error("failed to split incident")
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusCreated, PostSplitIncidentResponse{
+		ID: split.ID,
+	})
+}
+
+type PatchReopenIncidentRequest struct {
+	TeamID     uint   `param:"teamId" validate:"required,numeric,gte=0"`
+	IncidentID uint   `param:"incidentId" validate:"required,numeric,gte=0"`
+	Message    string `json:"message" validate:"max=10000"`
+}
+
+// PatchReopenIncident reopens a resolved incident, responders are paged again.
+func (h *Handlers) PatchReopenIncident(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[PatchReopenIncidentRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind PatchReopenIncidentRequest")
+		return echo.ErrBadRequest
+	}
+
+	ctx := c.Request().Context()
+	in, err := h.IncidentService.GetByID(ctx, req.IncidentID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get incident")
+		return echo.ErrInternalServerError
+	}
+
+	// Verify it belongs to the team
+	if in.TeamID != req.TeamID {
+		return echo.ErrForbidden
+	}
+
+	message := req.Message
+	if message == "" {
+		message = "Reopened"
+	}
+
+	if err := h.IncidentService.Reopen(ctx, in, &c.UserID, message); err != nil {
+		if errors.Is(err, incident.ErrIncidentNotResolved) || errors.Is(err, incident.ErrIncidentMerged) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		if errors.Is(err, incident.ErrIncidentOpen) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+
+		c.Log.WithError(err).Error("failed to reopen incident")
+		return echo.ErrInternalServerError
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,370 @@
+package incidents
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/entities"
+	"github.com/opsway-io/backend/internal/incident"
+	"github.com/opsway-io/backend/internal/postmortem"
+	hs "github.com/opsway-io/backend/internal/rest/handlers"
+	"github.com/opsway-io/backend/internal/rest/helpers"
+)
+
+type PostmortemResponse struct {
+	ID                  uint                              `json:"id"`
+	IncidentID          uint                              `json:"incidentId"`
+	Title               string                            `json:"title"`
+	Summary             *string                           `json:"summary"`
+	RootCause           *string                           `json:"rootCause"`
+	ContributingFactors []string                          `json:"contributingFactors"`
+	ImpactStartedAt     time.Time                         `json:"impactStartedAt"`
+	ImpactEndedAt       *time.Time                        `json:"impactEndedAt"`
+	Timeline            []PostmortemTimelineEntryResponse `json:"timeline"`
+	ActionItems         []PostmortemActionItemResponse    `json:"actionItems"`
+	Published           bool                              `json:"published"`
+	PublishedAt         *time.Time                        `json:"publishedAt"`
+	CreatedAt           time.Time                         `json:"createdAt"`
+	UpdatedAt           time.Time                         `json:"updatedAt"`
+}
+
+type PostmortemTimelineEntryResponse struct {
+	At          time.Time `json:"at"`
+	Kind        string    `json:"kind"`
+	Description string    `json:"description"`
+	Internal    bool      `json:"internal"`
+}
+
+type PostmortemActionItemResponse struct {
+	ID          uint       `json:"id"`
+	Description string     `json:"description"`
+	OwnerUserID *uint      `json:"ownerUserId"`
+	DueAt       *time.Time `json:"dueAt"`
+	Completed   bool       `json:"completed"`
+}
+
+func newPostmortemResponse(p *entities.Postmortem) *PostmortemResponse {
+	resp := &PostmortemResponse{
+		ID:                  p.ID,
+		IncidentID:          p.IncidentID,
+		Title:               p.Title,
+		Summary:             p.Summary,
+		RootCause:           p.RootCause,
+		ContributingFactors: p.ContributingFactors,
+		ImpactStartedAt:     p.ImpactStartedAt,
+		ImpactEndedAt:       p.ImpactEndedAt,
+		Timeline:            make([]PostmortemTimelineEntryResponse, len(p.Timeline)),
+		ActionItems:         make([]PostmortemActionItemResponse, len(p.ActionItems)),
+		Published:           p.Published,
+		PublishedAt:         p.PublishedAt,
+		CreatedAt:           p.CreatedAt,
+		UpdatedAt:           p.UpdatedAt,
+	}
+
+	if resp.ContributingFactors == nil {
+		resp.ContributingFactors = []string{}
+	}
+
+	for i, e := range p.Timeline {
+		resp.Timeline[i] = PostmortemTimelineEntryResponse{
+			At:          e.At,
+			Kind:        string(e.Kind),
+			Description: e.Description,
+			Internal:    e.IsInternal(),
+		}
+	}
+
+	for i, item := range p.ActionItems {
+		resp.ActionItems[i] = PostmortemActionItemResponse{
+			ID:          item.ID,
+			Description: item.Description,
+			OwnerUserID: item.OwnerUserID,
+			DueAt:       item.DueAt,
+			Completed:   item.Completed,
+		}
+	}
+
+	return resp
+}
+
+func (h *Handlers) GetIncidentPostmortem(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[GetIncidentRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind GetIncidentRequest")
+		return echo.ErrBadRequest
+	}
+
+	p, err := h.getPostmortem(c, req.TeamID, req.IncidentID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, newPostmortemResponse(p))
+}
+
+type PostIncidentPostmortemRequest struct {
+	TeamID       uint `param:"teamId" validate:"required,numeric,gte=0"`
+	IncidentID   uint `param:"incidentId" validate:"required,numeric,gte=0"`
+	DraftSummary bool `json:"draftSummary"`
+}
+
+// PostIncidentPostmortem creates a postmortem for a resolved incident with the
+// timeline prefilled, and optionally a summary drafted by the LLM.
+func (h *Handlers) PostIncidentPostmortem(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[PostIncidentPostmortemRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind PostIncidentPostmortemRequest")
+		return echo.ErrBadRequest
+	}
+
+	ctx := c.Request().Context()
+	in, err := h.IncidentService.GetByID(ctx, req.IncidentID)
+	if err != nil {
+		if errors.Is(err, incident.ErrNotFound) {
+			return echo.ErrNotFound
+		}
+
+		c.Log.WithError(err).Error("failed to get incident")
+		return echo.ErrInternalServerError
+	}
+
+	// Verify it belongs to the team
+	if in.TeamID != req.TeamID {
+		return echo.ErrForbidden
+	}
+
+	p, err := h.PostmortemService.Create(ctx, in)
+	if err != nil {
+		if errors.Is(err, postmortem.ErrIncidentNotResolved) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		if errors.Is(err, postmortem.ErrAlreadyExists) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+
+		c.Log.WithError(err).Error("failed to create postmortem")
+		return echo.ErrInternalServerError
+	}
+
+	if req.DraftSummary {
+		// The postmortem is still usable without a summary
+		if err := h.PostmortemService.DraftSummary(ctx, p); err != nil {
+			c.Log.WithError(err).Warn("failed to draft postmortem summary")
+		}
+	}
+
+	return c.JSON(http.StatusCreated, newPostmortemResponse(p))
+}
+
+type PutIncidentPostmortemRequest struct {
+	TeamID              uint                                 `param:"teamId" validate:"required,numeric,gte=0"`
+	IncidentID          uint                                 `param:"incidentId" validate:"required,numeric,gte=0"`
+	Title               string                               `json:"title" validate:"required,max=255"`
+	Summary             *string                              `json:"summary" validate:"omitempty,max=20000"`
+	RootCause           *string                              `json:"rootCause" validate:"omitempty,max=20000"`
+	ContributingFactors []string                             `json:"contributingFactors" validate:"max=50,dive,max=1000"`
+	ImpactStartedAt     time.Time                            `json:"impactStartedAt" validate:"required"`
+	ImpactEndedAt       *time.Time                           `json:"impactEndedAt"`
+	Timeline            []PutIncidentPostmortemRequestEntry  `json:"timeline" validate:"max=500,dive"`
+	ActionItems         []PutIncidentPostmortemRequestAction `json:"actionItems" validate:"max=100,dive"`
+}
+
+type PutIncidentPostmortemRequestEntry struct {
+	At          time.Time `json:"at" validate:"required"`
+	Kind        string    `json:"kind" validate:"omitempty,oneof=OPENED FAILED_CHECK ACKNOWLEDGED UPDATE COMMENT RESOLVED NOTE"`
+	Description string    `json:"description" validate:"required,max=5000"`
+	Internal    bool      `json:"internal"`
+}
+
+type PutIncidentPostmortemRequestAction struct {
+	ID          uint       `json:"id"`
+	Description string     `json:"description" validate:"required,max=1000"`
+	OwnerUserID *uint      `json:"ownerUserId"`
+	DueAt       *time.Time `json:"dueAt"`
+	Completed   bool       `json:"completed"`
+}
+
+func (h *Handlers) PutIncidentPostmortem(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[PutIncidentPostmortemRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind PutIncidentPostmortemRequest")
+		return echo.ErrBadRequest
+	}
+
+	if req.ImpactEndedAt != nil && req.ImpactEndedAt.Before(req.ImpactStartedAt) {
+		return echo.NewHTTPError(http.StatusBadRequest, "impact cannot end before it started")
+	}
+
+	p, err := h.getPostmortem(c, req.TeamID, req.IncidentID)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+
+	p.Title = req.Title
+	p.Summary = req.Summary
+	p.RootCause = req.RootCause
+	p.ContributingFactors = req.ContributingFactors
+	p.ImpactStartedAt = req.ImpactStartedAt
+	p.ImpactEndedAt = req.ImpactEndedAt
+
+	p.Timeline = make([]entities.PostmortemTimelineEntry, len(req.Timeline))
+	for i, e := range req.Timeline {
+		kind := entities.PostmortemTimelineEntryKind(e.Kind)
+		if kind == "" {
+			kind = entities.PostmortemTimelineEntryKindNote
+		}
+
+		p.Timeline[i] = entities.PostmortemTimelineEntry{
+			At:          e.At,
+			Kind:        kind,
+			Description: e.Description,
+			Internal:    e.Internal,
+		}
+	}
+
+	p.ActionItems = make([]entities.PostmortemActionItem, len(req.ActionItems))
+	for i, item := range req.ActionItems {
+		// Owners must be members of the team
+		if item.OwnerUserID != nil {
+			if !h.isTeamMember(c, req.TeamID, *item.OwnerUserID) {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("owner %d is not a member of the team", *item.OwnerUserID))
+			}
+		}
+
+		p.ActionItems[i] = entities.PostmortemActionItem{
+			ID:          item.ID,
+			Description: item.Description,
+			OwnerUserID: item.OwnerUserID,
+			DueAt:       item.DueAt,
+			Completed:   item.Completed,
+		}
+	}
+
+	if err := h.PostmortemService.Update(ctx, p); err != nil {
+		if errors.Is(err, postmortem.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusBadRequest, "unknown action item")
+		}
+
+		c.Log.WithError(err).Error("failed to update postmortem")
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, newPostmortemResponse(p))
+}
+
+func (h *Handlers) DeleteIncidentPostmortem(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[GetIncidentRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind GetIncidentRequest")
+		return echo.ErrBadRequest
+	}
+
+	if err := h.PostmortemService.Delete(c.Request().Context(), req.TeamID, req.IncidentID); err != nil {
+		if errors.Is(err, postmortem.ErrNotFound) {
+			return echo.ErrNotFound
+		}
+
+		c.Log.WithError(err).Error("failed to delete postmortem")
+		return echo.ErrInternalServerError
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PostIncidentPostmortemPublish shows the postmortem on the status pages of
+// the monitor of the incident.
+func (h *Handlers) PostIncidentPostmortemPublish(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[GetIncidentRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind GetIncidentRequest")
+		return echo.ErrBadRequest
+	}
+
+	p, err := h.getPostmortem(c, req.TeamID, req.IncidentID)
+	if err != nil {
+		return err
+	}
+
+	if err := h.PostmortemService.Publish(c.Request().Context(), p); err != nil {
+		c.Log.WithError(err).Error("failed to publish postmortem")
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, newPostmortemResponse(p))
+}
+
+func (h *Handlers) PostIncidentPostmortemUnpublish(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[GetIncidentRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind GetIncidentRequest")
+		return echo.ErrBadRequest
+	}
+
+	p, err := h.getPostmortem(c, req.TeamID, req.IncidentID)
+	if err != nil {
+		return err
+	}
+
+	if err := h.PostmortemService.Unpublish(c.Request().Context(), p); err != nil {
+		c.Log.WithError(err).Error("failed to unpublish postmortem")
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, newPostmortemResponse(p))
+}
+
+func (h *Handlers) PostIncidentPostmortemSummary(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[GetIncidentRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind GetIncidentRequest")
+		return echo.ErrBadRequest
+	}
+
+	p, err := h.getPostmortem(c, req.TeamID, req.IncidentID)
+	if err != nil {
+		return err
+	}
+
+	if err := h.PostmortemService.DraftSummary(c.Request().Context(), p); err != nil {
+		c.Log.WithError(err).Error("failed to draft postmortem summary")
+		return echo.NewHTTPError(http.StatusBadGateway, "failed to draft summary")
+	}
+
+	return c.JSON(http.StatusOK, newPostmortemResponse(p))
+}
+
+func (h *Handlers) GetIncidentPostmortemMarkdown(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[GetIncidentRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind GetIncidentRequest")
+		return echo.ErrBadRequest
+	}
+
+	p, err := h.getPostmortem(c, req.TeamID, req.IncidentID)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"postmortem-incident-%d.md\"", p.IncidentID))
+
+	return c.Blob(http.StatusOK, "text/markdown; charset=utf-8", []byte(postmortem.Markdown(p)))
+}
+
+func (h *Handlers) getPostmortem(c hs.AuthenticatedContext, teamID, incidentID uint) (*entities.Postmortem, error) {
+	p, err := h.PostmortemService.GetByIncidentID(c.Request().Context(), teamID, incidentID)
+	if err != nil {
+		if errors.Is(err, postmortem.ErrNotFound) {
+			return nil, echo.ErrNotFound
+		}
+
+		c.Log.WithError(err).Error("failed to get postmortem")
+		return nil, echo.ErrInternalServerError
+	}
+
+	return p, nil
+}
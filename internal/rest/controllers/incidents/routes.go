@@ -4,6 +4,8 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/opsway-io/backend/internal/authentication"
 	"github.com/opsway-io/backend/internal/incident"
+	"github.com/opsway-io/backend/internal/notification/delivery"
+	"github.com/opsway-io/backend/internal/postmortem"
 	"github.com/opsway-io/backend/internal/rest/handlers"
 	mw "github.com/opsway-io/backend/internal/rest/middleware"
 	"github.com/opsway-io/backend/internal/team"
@@ -14,6 +16,8 @@ type Handlers struct {
 	AuthenticationService authentication.Service
 	TeamService           team.Service
 	IncidentService       incident.Service
+	DeliveryService       delivery.Service
+	PostmortemService     postmortem.Service
 }
 
 func Register(
@@ -21,9 +25,14 @@ func Register(
 	logger *logrus.Entry,
 	teamService team.Service,
 	incidentService incident.Service,
+	deliveryService delivery.Service,
+	postmortemService postmortem.Service,
 ) {
 	h := &Handlers{
-		IncidentService: incidentService,
+		TeamService:       teamService,
+		IncidentService:   incidentService,
+		DeliveryService:   deliveryService,
+		PostmortemService: postmortemService,
 	}
 
 	TeamGuard := mw.TeamGuardFactory(logger, teamService)
@@ -37,8 +46,28 @@ func Register(
 
 	monitorsGroup.GET("", AuthHandler(h.GetIncidents))
 	monitorsGroup.GET("/overview", AuthHandler(h.GetIncidents))
+	monitorsGroup.GET("/analytics", AuthHandler(h.GetIncidentAnalytics))
 	monitorsGroup.GET("/monitor/:monitorId", AuthHandler(h.GetMonitorIncidents))
 	monitorsGroup.GET("/:incidentId", AuthHandler(h.GetIncident))
+	monitorsGroup.GET("/:incidentId/notifications", AuthHandler(h.GetIncidentNotifications))
+	monitorsGroup.PUT("/:incidentId/assignee", AuthHandler(h.PutIncidentAssignee))
+	monitorsGroup.PUT("/:incidentId/commander", AuthHandler(h.PutIncidentCommander))
+	monitorsGroup.GET("/:incidentId/responders", AuthHandler(h.GetIncidentResponders))
+	monitorsGroup.POST("/:incidentId/responders", AuthHandler(h.PostIncidentResponder))
+	monitorsGroup.DELETE("/:incidentId/responders/:userId", AuthHandler(h.DeleteIncidentResponder))
+	monitorsGroup.GET("/:incidentId/updates", AuthHandler(h.GetIncidentUpdates))
+	monitorsGroup.POST("/:incidentId/updates", AuthHandler(h.PostIncidentUpdate))
+	monitorsGroup.GET("/:incidentId/postmortem", AuthHandler(h.GetIncidentPostmortem))
+	monitorsGroup.POST("/:incidentId/postmortem", AuthHandler(h.PostIncidentPostmortem))
+	monitorsGroup.PUT("/:incidentId/postmortem", AuthHandler(h.PutIncidentPostmortem))
+	monitorsGroup.DELETE("/:incidentId/postmortem", AuthHandler(h.DeleteIncidentPostmortem))
+	monitorsGroup.POST("/:incidentId/postmortem/publish", AuthHandler(h.PostIncidentPostmortemPublish))
+	monitorsGroup.POST("/:incidentId/postmortem/unpublish", AuthHandler(h.PostIncidentPostmortemUnpublish))
+	monitorsGroup.POST("/:incidentId/postmortem/summary", AuthHandler(h.PostIncidentPostmortemSummary))
+	monitorsGroup.GET("/:incidentId/postmortem/markdown", AuthHandler(h.GetIncidentPostmortemMarkdown))
 	monitorsGroup.PATCH("/:incidentId/resolved", AuthHandler(h.PatchSolveIncident))
 	monitorsGroup.PATCH("/:incidentId/acknowledge", AuthHandler(h.PatchAcknowledgeIncident))
+	monitorsGroup.PATCH("/:incidentId/reopen", AuthHandler(h.PatchReopenIncident))
+	monitorsGroup.PATCH("/:incidentId/merge", AuthHandler(h.PatchMergeIncident))
+	monitorsGroup.POST("/:incidentId/split", AuthHandler(h.PostSplitIncident))
 }
@@ -0,0 +1,138 @@
+package incidents
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/entities"
+	"github.com/opsway-io/backend/internal/incident"
+	hs "github.com/opsway-io/backend/internal/rest/handlers"
+	"github.com/opsway-io/backend/internal/rest/helpers"
+)
+
+type GetIncidentUpdatesResponse struct {
+	Updates []IncidentUpdateResponse `json:"updates"`
+}
+
+type IncidentUpdateResponse struct {
+	ID        uint   `json:"id"`
+	UserID    *uint  `json:"userId"`
+	Status    string `json:"status"`
+	Message   string `json:"message"`
+	Public    bool   `json:"public"`
+	CreatedAt string `json:"createdAt"`
+}
+
+func (h *Handlers) GetIncidentUpdates(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[GetIncidentRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind GetIncidentRequest")
+		return echo.ErrBadRequest
+	}
+
+	ctx := c.Request().Context()
+	in, err := h.IncidentService.GetByID(ctx, req.IncidentID)
+	if err != nil {
+		if errors.Is(err, incident.ErrNotFound) {
+			return echo.ErrNotFound
+		}
+
+		c.Log.WithError(err).Error("failed to get incident")
+		return echo.ErrInternalServerError
+	}
+
+	// Verify it belongs to the team
+	if in.TeamID != req.TeamID {
+		return echo.ErrForbidden
+	}
+
+	updates, err := h.IncidentService.GetUpdates(ctx, in.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get incident updates")
+		return echo.ErrInternalServerError
+	}
+
+	resp := &GetIncidentUpdatesResponse{
+		Updates: make([]IncidentUpdateResponse, len(updates)),
+	}
+
+	for i, u := range updates {
+		resp.Updates[i] = newIncidentUpdateResponse(&u)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+type PostIncidentUpdateRequest struct {
+	TeamID     uint    `param:"teamId" validate:"required,numeric,gte=0"`
+	IncidentID uint    `param:"incidentId" validate:"required,numeric,gte=0"`
+	Status     string  `json:"status" validate:"required"`
+	Message    string  `json:"message" validate:"required,max=10000"`
+	Public     bool    `json:"public"`
+	Severity   *string `json:"severity"`
+}
+
+// PostIncidentUpdate moves the incident to a new status and adds the update to
+// its timeline, public updates are also shown on status pages.
+func (h *Handlers) PostIncidentUpdate(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[PostIncidentUpdateRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind PostIncidentUpdateRequest")
+		return echo.ErrBadRequest
+	}
+
+	ctx := c.Request().Context()
+	in, err := h.IncidentService.GetByID(ctx, req.IncidentID)
+	if err != nil {
+		if errors.Is(err, incident.ErrNotFound) {
+			return echo.ErrNotFound
+		}
+
+		c.Log.WithError(err).Error("failed to get incident")
+		return echo.ErrInternalServerError
+	}
+
+	// Verify it belongs to the team
+	if in.TeamID != req.TeamID {
+		return echo.ErrForbidden
+	}
+
+	if req.Severity != nil {
+		severity := entities.IncidentSeverity(*req.Severity)
+		if !severity.IsValid() {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid incident severity")
+		}
+
+		in.Severity = severity
+	}
+
+	update := &entities.IncidentUpdate{
+		UserID:  &c.UserID,
+		Status:  entities.IncidentStatus(req.Status),
+		Message: req.Message,
+		Public:  req.Public,
+	}
+
+	if err := h.IncidentService.AddUpdate(ctx, in, update); err != nil {
+		if errors.Is(err, incident.ErrInvalidStatus) || errors.Is(err, incident.ErrIncidentResolved) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		c.Log.WithError(err).Error("failed to add incident update")
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusCreated, newIncidentUpdateResponse(update))
+}
+
+func newIncidentUpdateResponse(u *entities.IncidentUpdate) IncidentUpdateResponse {
+	return IncidentUpdateResponse{
+		ID:        u.ID,
+		UserID:    u.UserID,
+		Status:    string(u.Status),
+		Message:   u.Message,
+		Public:    u.Public,
+		CreatedAt: u.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
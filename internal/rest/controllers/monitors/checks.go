@@ -105,15 +105,22 @@ type GetMonitorChecksResponse struct {
 }
 
 type GetMonitorChecksResponseCheck struct {
-	ID         uuid.UUID                      `json:"id"`
-	StatusCode uint64                         `json:"statusCode"`
-	Method     string                         `json:"method"`
-	URL        string                         `json:"url"`
-	Location   string                         `json:"location"`
-	Timing     GetMonitorChecksResponseTiming `json:"timing"`
-	TLS        *GetMonitorChecksResponseTLS   `json:"tls,omitempty"`
-	CreatedAt  string                         `json:"createdAt"`
-	Anomaly    bool                           `json:"anomaly"`
+	ID         uuid.UUID                           `json:"id"`
+	StatusCode uint64                              `json:"statusCode"`
+	Success    bool                                `json:"success"`
+	ErrorKind  string                              `json:"errorKind,omitempty"`
+	Error      string                              `json:"error,omitempty"`
+	Method     string                              `json:"method"`
+	URL        string                              `json:"url"`
+	Location   string                              `json:"location"`
+	Timing     GetMonitorChecksResponseTiming      `json:"timing"`
+	TLS        *GetMonitorChecksResponseTLS        `json:"tls,omitempty"`
+	DNSRecords []GetMonitorChecksResponseDNSRecord `json:"dnsRecords,omitempty"`
+	Query      *GetMonitorChecksResponseQuery      `json:"query,omitempty"`
+	Ping       *GetMonitorChecksResponsePing       `json:"ping,omitempty"`
+	Banner     string                              `json:"banner,omitempty"`
+	CreatedAt  string                              `json:"createdAt"`
+	Anomaly    bool                                `json:"anomaly"`
 }
 
 type GetMonitorChecksResponseTiming struct {
@@ -125,6 +132,28 @@ type GetMonitorChecksResponseTiming struct {
 	Total            time.Duration `json:"total"`
 }
 
+type GetMonitorChecksResponsePing struct {
+	Sent       uint64        `json:"sent"`
+	Received   uint64        `json:"received"`
+	PacketLoss float64       `json:"packetLoss"`
+	MinRTT     time.Duration `json:"minRtt"`
+	AvgRTT     time.Duration `json:"avgRtt"`
+	MaxRTT     time.Duration `json:"maxRtt"`
+	Jitter     time.Duration `json:"jitter"`
+}
+
+type GetMonitorChecksResponseDNSRecord struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	TTL   uint32 `json:"ttl"`
+	Value string `json:"value"`
+}
+
+type GetMonitorChecksResponseQuery struct {
+	Columns []string   `json:"columns"`
+	Rows    [][]string `json:"rows"`
+}
+
 type GetMonitorChecksResponseTLS struct {
 	Version   string    `json:"version"`
 	Cipher    string    `json:"cipher"`
@@ -187,6 +216,9 @@ func (h *Handlers) newGetMonitorCheckResponse(check check.Check, anomaly bool) G
 	c := GetMonitorChecksResponseCheck{
 		ID:         check.ID,
 		StatusCode: check.StatusCode,
+		Success:    check.Success,
+		ErrorKind:  check.ErrorKind,
+		Error:      check.Error,
 		Method:     check.Method,
 		URL:        check.URL,
 		Location:   check.Location,
@@ -198,6 +230,7 @@ func (h *Handlers) newGetMonitorCheckResponse(check check.Check, anomaly bool) G
 			ContentTransfer:  check.Timing.ContentTransfer,
 			Total:            check.Timing.Total,
 		},
+		Banner:    check.Banner,
 		CreatedAt: check.CreatedAt.Format(time.UnixDate),
 		Anomaly:   anomaly,
 	}
@@ -213,6 +246,34 @@ func (h *Handlers) newGetMonitorCheckResponse(check check.Check, anomaly bool) G
 		}
 	}
 
+	if check.Ping != nil {
+		c.Ping = &GetMonitorChecksResponsePing{
+			Sent:       check.Ping.Sent,
+			Received:   check.Ping.Received,
+			PacketLoss: check.Ping.PacketLoss,
+			MinRTT:     check.Ping.MinRTT,
+			AvgRTT:     check.Ping.AvgRTT,
+			MaxRTT:     check.Ping.MaxRTT,
+			Jitter:     check.Ping.Jitter,
+		}
+	}
+
+	if check.Query != nil {
+		c.Query = &GetMonitorChecksResponseQuery{
+			Columns: check.Query.Columns,
+			Rows:    check.Query.Rows,
+		}
+	}
+
+	for _, r := range check.DNSRecords {
+		c.DNSRecords = append(c.DNSRecords, GetMonitorChecksResponseDNSRecord{
+			Name:  r.Name,
+			Type:  r.Type,
+			TTL:   r.TTL,
+			Value: r.Value,
+		})
+	}
+
 	return c
 }
 
@@ -0,0 +1,99 @@
+package monitors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/monitor"
+	hs "github.com/opsway-io/backend/internal/rest/handlers"
+	"github.com/opsway-io/backend/internal/rest/helpers"
+)
+
+type GetMonitorDependenciesRequest struct {
+	TeamID    uint `param:"teamId" validate:"required,numeric,gte=0"`
+	MonitorID uint `param:"monitorId" validate:"required,numeric,gte=0"`
+}
+
+type GetMonitorDependenciesResponse struct {
+	// Monitors the monitor directly depends on
+	DependsOn []uint `json:"dependsOn"`
+	// Monitors the monitor depends on, directly or through other monitors
+	Upstream []uint `json:"upstream"`
+}
+
+func (h *Handlers) GetMonitorDependencies(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[GetMonitorDependenciesRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind GetMonitorDependenciesRequest")
+
+		return echo.ErrBadRequest
+	}
+
+	ctx := c.Request().Context()
+
+	dependsOn, err := h.MonitorService.GetDependencies(ctx, req.TeamID, req.MonitorID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get monitor dependencies")
+
+		return echo.ErrInternalServerError
+	}
+
+	upstream, err := h.MonitorService.GetUpstreamIDs(ctx, req.TeamID, req.MonitorID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get upstream monitors")
+
+		return echo.ErrInternalServerError
+	}
+
+	resp := GetMonitorDependenciesResponse{
+		DependsOn: dependsOn,
+		Upstream:  upstream,
+	}
+
+	if resp.DependsOn == nil {
+		resp.DependsOn = []uint{}
+	}
+
+	if resp.Upstream == nil {
+		resp.Upstream = []uint{}
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+type PutMonitorDependenciesRequest struct {
+	TeamID    uint   `param:"teamId" validate:"required,numeric,gte=0"`
+	MonitorID uint   `param:"monitorId" validate:"required,numeric,gte=0"`
+	DependsOn []uint `json:"dependsOn" validate:"max=50"`
+}
+
+func (h *Handlers) PutMonitorDependencies(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[PutMonitorDependenciesRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind PutMonitorDependenciesRequest")
+
+		return echo.ErrBadRequest
+	}
+
+	if err := h.MonitorService.SetDependencies(
+		c.Request().Context(),
+		req.TeamID,
+		req.MonitorID,
+		req.DependsOn,
+	); err != nil {
+		if errors.Is(err, monitor.ErrNotFound) {
+			return echo.ErrNotFound
+		}
+
+		if errors.Is(err, monitor.ErrInvalidDependency) || errors.Is(err, monitor.ErrDependencyCycle) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		c.Log.WithError(err).Error("failed to set monitor dependencies")
+
+		return echo.ErrInternalServerError
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
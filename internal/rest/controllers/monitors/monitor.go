@@ -12,6 +12,7 @@ import (
 	"github.com/opsway-io/backend/internal/monitor"
 	hs "github.com/opsway-io/backend/internal/rest/handlers"
 	"github.com/opsway-io/backend/internal/rest/helpers"
+	"github.com/opsway-io/backend/internal/secret"
 )
 
 /*
@@ -22,8 +23,12 @@ type Monitor struct {
 	ID         uint               `json:"id"`
 	State      string             `json:"state" validate:"required,monitorState"`
 	Name       string             `json:"name" validate:"required,max=255"`
+	Tags       []string           `json:"tags" validate:"omitempty,max=50,dive,required,max=64"`
 	Settings   MonitorSettings    `json:"settings" validate:"required,dive"`
 	Assertions []MonitorAssertion `json:"assertions" validate:"required,monitorAssertions"`
+	// Read only, set while the monitor oscillates between passing and failing
+	Flapping      bool       `json:"flapping"`
+	FlappingSince *time.Time `json:"flappingSince"`
 	CreatedAt  time.Time          `json:"createdAt"`
 	UpdatedAt  time.Time          `json:"updatedAt"`
 }
@@ -32,10 +37,16 @@ type MonitorSettings struct {
 	Method           string                  `json:"method" validate:"required,monitorMethod"`
 	URL              string                  `json:"url" validate:"required,max=2048"`
 	FrequencySeconds uint64                  `json:"frequencySeconds" validate:"required,monitorFrequency"`
+	TimeoutSeconds   uint64                  `json:"timeoutSeconds" validate:"omitempty,max=60"`
 	Headers          []MonitorSettingsHeader `json:"headers" validate:"dive"`
 	Body             MonitorSettingsBody     `json:"body" validate:"required,dive"`
 	TLS              MonitorSettingsTLS      `json:"tls" validate:"required,dive"`
+	DNS              MonitorSettingsDNS      `json:"dns"`
+	ICMP             MonitorSettingsICMP     `json:"icmp"`
+	TCP              MonitorSettingsTCP      `json:"tcp"`
+	Database         MonitorSettingsDatabase `json:"database"`
 	Locations        []string                `json:"locations" validate:"omitempty,dive,required,max=255"`
+	IncidentPolicy   MonitorSettingsIncidentPolicy `json:"incidentPolicy"`
 }
 
 type MonitorAssertion struct {
@@ -62,6 +73,38 @@ type MonitorSettingsTLS struct {
 	ExpirationThresholdDays *uint `json:"expirationThresholdDays"`
 }
 
+type MonitorSettingsDNS struct {
+	RecordType string `json:"recordType" validate:"omitempty,oneof=A AAAA CNAME MX TXT NS SOA CAA SRV"`
+	Nameserver string `json:"nameserver" validate:"omitempty,max=2048"`
+	Protocol   string `json:"protocol" validate:"omitempty,oneof=UDP TCP DOT DOH"`
+}
+
+type MonitorSettingsICMP struct {
+	PacketCount uint `json:"packetCount" validate:"omitempty,max=20"`
+}
+
+type MonitorSettingsTCP struct {
+	Send      string `json:"send" validate:"omitempty,max=4096"`
+	Expect    string `json:"expect" validate:"omitempty,max=1024"`
+	ReadBytes uint   `json:"readBytes" validate:"omitempty,max=65536"`
+	TLS       bool   `json:"tls"`
+}
+
+// The connection string of database monitors is their URL, which is returned
+// with the password redacted
+type MonitorSettingsDatabase struct {
+	Query string `json:"query" validate:"omitempty,max=4096"`
+}
+
+type MonitorSettingsIncidentPolicy struct {
+	FailureThreshold  uint `json:"failureThreshold" validate:"omitempty,max=100"`
+	RecoveryThreshold uint `json:"recoveryThreshold" validate:"omitempty,max=100"`
+	WindowSize        uint `json:"windowSize" validate:"omitempty,max=100,incidentWindow"`
+	LocationQuorum    uint `json:"locationQuorum" validate:"omitempty,max=100"`
+	FlapWindow        uint `json:"flapWindow" validate:"omitempty,min=3,max=100"`
+	FlapThreshold     uint `json:"flapThreshold" validate:"omitempty,min=1,max=100"`
+}
+
 /*
 	Handlers
 */
@@ -154,12 +197,16 @@ func newGetMonitorsResponse(monitors *[]monitor.MonitorWithTotalCount, stats *[]
 				ID:        m.ID,
 				State:     m.GetStateString(),
 				Name:      m.Name,
+				Tags:      m.Tags,
+				Flapping:      m.Flapping,
+				FlappingSince: m.FlappingSince,
 				CreatedAt: m.CreatedAt,
 				UpdatedAt: m.UpdatedAt,
 				Settings: MonitorSettings{
 					Method:           m.Settings.Method,
 					URL:              m.Settings.URL,
 					FrequencySeconds: m.Settings.GetFrequencySeconds(),
+					TimeoutSeconds:   m.Settings.GetTimeoutSeconds(),
 					Headers:          headers,
 					Body: MonitorSettingsBody{
 						Type:    m.Settings.Body.Type,
@@ -171,7 +218,32 @@ func newGetMonitorsResponse(monitors *[]monitor.MonitorWithTotalCount, stats *[]
 						CheckExpiration:         m.Settings.TLS.CheckExpiration,
 						ExpirationThresholdDays: m.Settings.TLS.ExpirationThresholdDays,
 					},
+					DNS: MonitorSettingsDNS{
+						RecordType: m.Settings.DNS.RecordType,
+						Nameserver: m.Settings.DNS.Nameserver,
+						Protocol:   m.Settings.DNS.Protocol,
+					},
+					ICMP: MonitorSettingsICMP{
+						PacketCount: m.Settings.ICMP.PacketCount,
+					},
+					TCP: MonitorSettingsTCP{
+						Send:      m.Settings.TCP.Send,
+						Expect:    m.Settings.TCP.Expect,
+						ReadBytes: m.Settings.TCP.ReadBytes,
+						TLS:       m.Settings.TCP.TLS,
+					},
+					Database: MonitorSettingsDatabase{
+						Query: m.Settings.Database.Query,
+					},
 					Locations: m.Settings.Locations,
+					IncidentPolicy: MonitorSettingsIncidentPolicy{
+						FailureThreshold:  m.Settings.IncidentPolicy.GetFailureThreshold(),
+						RecoveryThreshold: m.Settings.IncidentPolicy.GetRecoveryThreshold(),
+						WindowSize:        m.Settings.IncidentPolicy.WindowSize,
+						LocationQuorum:    m.Settings.IncidentPolicy.LocationQuorum,
+						FlapWindow:        m.Settings.IncidentPolicy.FlapWindow,
+						FlapThreshold:     m.Settings.IncidentPolicy.GetFlapThreshold(),
+					},
 				},
 				Assertions: assertions,
 			},
@@ -273,12 +345,16 @@ func newGetMonitorResponse(m *entities.Monitor, stats *check.MonitorStats) (*Get
 			ID:        m.ID,
 			State:     m.GetStateString(),
 			Name:      m.Name,
+			Tags:      m.Tags,
+			Flapping:      m.Flapping,
+			FlappingSince: m.FlappingSince,
 			CreatedAt: m.CreatedAt,
 			UpdatedAt: m.UpdatedAt,
 			Settings: MonitorSettings{
 				Method:           m.Settings.Method,
 				URL:              m.Settings.URL,
 				FrequencySeconds: m.Settings.GetFrequencySeconds(),
+				TimeoutSeconds:   m.Settings.GetTimeoutSeconds(),
 				Headers:          headers,
 				Body: MonitorSettingsBody{
 					Type:    m.Settings.Body.Type,
@@ -290,7 +366,32 @@ func newGetMonitorResponse(m *entities.Monitor, stats *check.MonitorStats) (*Get
 					CheckExpiration:         m.Settings.TLS.CheckExpiration,
 					ExpirationThresholdDays: m.Settings.TLS.ExpirationThresholdDays,
 				},
+				DNS: MonitorSettingsDNS{
+					RecordType: m.Settings.DNS.RecordType,
+					Nameserver: m.Settings.DNS.Nameserver,
+					Protocol:   m.Settings.DNS.Protocol,
+				},
+				ICMP: MonitorSettingsICMP{
+					PacketCount: m.Settings.ICMP.PacketCount,
+				},
+				TCP: MonitorSettingsTCP{
+					Send:      m.Settings.TCP.Send,
+					Expect:    m.Settings.TCP.Expect,
+					ReadBytes: m.Settings.TCP.ReadBytes,
+					TLS:       m.Settings.TCP.TLS,
+				},
+				Database: MonitorSettingsDatabase{
+					Query: m.Settings.Database.Query,
+				},
 				Locations: m.Settings.Locations,
+				IncidentPolicy: MonitorSettingsIncidentPolicy{
+					FailureThreshold:  m.Settings.IncidentPolicy.GetFailureThreshold(),
+					RecoveryThreshold: m.Settings.IncidentPolicy.GetRecoveryThreshold(),
+					WindowSize:        m.Settings.IncidentPolicy.WindowSize,
+					LocationQuorum:    m.Settings.IncidentPolicy.LocationQuorum,
+					FlapWindow:        m.Settings.IncidentPolicy.FlapWindow,
+					FlapThreshold:     m.Settings.IncidentPolicy.GetFlapThreshold(),
+				},
 			},
 			Assertions: assertions,
 		},
@@ -338,6 +439,7 @@ func (h *Handlers) DeleteMonitor(c hs.AuthenticatedContext) error {
 type PostMonitorRequest struct {
 	TeamID     uint               `param:"teamId" validate:"required,numeric,gte=0"`
 	Name       string             `json:"name" validate:"required,max=255"`
+	Tags       []string           `json:"tags" validate:"omitempty,max=50,dive,required,max=64"`
 	Settings   MonitorSettings    `json:"settings" validate:"required,dive"`
 	Assertions []MonitorAssertion `json:"assertions" validate:"required,dive"`
 }
@@ -390,6 +492,7 @@ func (h *Handlers) PostMonitor(c hs.AuthenticatedContext) error {
 	m := &entities.Monitor{
 		TeamID: req.TeamID,
 		Name:   req.Name,
+		Tags:   req.Tags,
 		Settings: entities.MonitorSettings{
 			Method:  req.Settings.Method,
 			URL:     req.Settings.URL,
@@ -403,15 +506,49 @@ func (h *Handlers) PostMonitor(c hs.AuthenticatedContext) error {
 				CheckExpiration:         req.Settings.TLS.CheckExpiration,
 				ExpirationThresholdDays: req.Settings.TLS.ExpirationThresholdDays,
 			},
+			DNS: entities.MonitorSettingsDNS{
+				RecordType: req.Settings.DNS.RecordType,
+				Nameserver: req.Settings.DNS.Nameserver,
+				Protocol:   req.Settings.DNS.Protocol,
+			},
+			ICMP: entities.MonitorSettingsICMP{
+				PacketCount: req.Settings.ICMP.PacketCount,
+			},
+			TCP: entities.MonitorSettingsTCP{
+				Send:      req.Settings.TCP.Send,
+				Expect:    req.Settings.TCP.Expect,
+				ReadBytes: req.Settings.TCP.ReadBytes,
+				TLS:       req.Settings.TCP.TLS,
+			},
+			Database: entities.MonitorSettingsDatabase{
+				Query: req.Settings.Database.Query,
+			},
 			Locations: req.Settings.Locations,
+			IncidentPolicy: entities.MonitorSettingsIncidentPolicy{
+				FailureThreshold:  req.Settings.IncidentPolicy.FailureThreshold,
+				RecoveryThreshold: req.Settings.IncidentPolicy.RecoveryThreshold,
+				WindowSize:        req.Settings.IncidentPolicy.WindowSize,
+				LocationQuorum:    req.Settings.IncidentPolicy.LocationQuorum,
+				FlapWindow:        req.Settings.IncidentPolicy.FlapWindow,
+				FlapThreshold:     req.Settings.IncidentPolicy.FlapThreshold,
+			},
 		},
 		Assertions: assertions,
 	}
 
 	m.Settings.SetFrequencySeconds(req.Settings.FrequencySeconds)
+	m.Settings.SetTimeoutSeconds(req.Settings.TimeoutSeconds)
 	m.Settings.Body.SetContentString(req.Settings.Body.Content)
 
 	if err := h.MonitorService.Create(c.Request().Context(), m); err != nil {
+		if errors.Is(err, monitor.ErrInvalidQuery) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		if errors.Is(err, secret.ErrMissingKey) {
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "database monitors cannot be saved until a secrets key is configured")
+		}
+
 		c.Log.WithError(err).Error("failed to create monitor")
 
 		return echo.ErrInternalServerError
@@ -511,6 +648,7 @@ type PutMonitorRequest struct {
 	MonitorID  uint               `param:"monitorId" validate:"required,numeric,gte=0"`
 	Name       string             `json:"name" validate:"required,max=255"`
 	State      string             `json:"state" validate:"required,monitorState"`
+	Tags       []string           `json:"tags" validate:"omitempty,max=50,dive,required,max=64"`
 	Settings   MonitorSettings    `json:"settings" validate:"required,dive"`
 	Assertions []MonitorAssertion `json:"assertions" validate:"required,dive"`
 }
@@ -546,6 +684,7 @@ func (h *Handlers) PutMonitor(c hs.AuthenticatedContext) error {
 	m := &entities.Monitor{
 		TeamID: req.TeamID,
 		Name:   req.Name,
+		Tags:   req.Tags,
 		Settings: entities.MonitorSettings{
 			Method:  req.Settings.Method,
 			URL:     req.Settings.URL,
@@ -559,13 +698,39 @@ func (h *Handlers) PutMonitor(c hs.AuthenticatedContext) error {
 				CheckExpiration:         req.Settings.TLS.CheckExpiration,
 				ExpirationThresholdDays: req.Settings.TLS.ExpirationThresholdDays,
 			},
+			DNS: entities.MonitorSettingsDNS{
+				RecordType: req.Settings.DNS.RecordType,
+				Nameserver: req.Settings.DNS.Nameserver,
+				Protocol:   req.Settings.DNS.Protocol,
+			},
+			ICMP: entities.MonitorSettingsICMP{
+				PacketCount: req.Settings.ICMP.PacketCount,
+			},
+			TCP: entities.MonitorSettingsTCP{
+				Send:      req.Settings.TCP.Send,
+				Expect:    req.Settings.TCP.Expect,
+				ReadBytes: req.Settings.TCP.ReadBytes,
+				TLS:       req.Settings.TCP.TLS,
+			},
+			Database: entities.MonitorSettingsDatabase{
+				Query: req.Settings.Database.Query,
+			},
 			Locations: req.Settings.Locations,
+			IncidentPolicy: entities.MonitorSettingsIncidentPolicy{
+				FailureThreshold:  req.Settings.IncidentPolicy.FailureThreshold,
+				RecoveryThreshold: req.Settings.IncidentPolicy.RecoveryThreshold,
+				WindowSize:        req.Settings.IncidentPolicy.WindowSize,
+				LocationQuorum:    req.Settings.IncidentPolicy.LocationQuorum,
+				FlapWindow:        req.Settings.IncidentPolicy.FlapWindow,
+				FlapThreshold:     req.Settings.IncidentPolicy.FlapThreshold,
+			},
 		},
 		Assertions: assertions,
 	}
 
 	m.SetStateString(req.State)
 	m.Settings.SetFrequencySeconds(req.Settings.FrequencySeconds)
+	m.Settings.SetTimeoutSeconds(req.Settings.TimeoutSeconds)
 	m.Settings.Body.SetContentString(req.Settings.Body.Content)
 
 	if req.State == "ACTIVE" {
@@ -605,6 +770,14 @@ func (h *Handlers) PutMonitor(c hs.AuthenticatedContext) error {
 			return echo.ErrNotFound
 		}
 
+		if errors.Is(err, monitor.ErrInvalidQuery) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		if errors.Is(err, secret.ErrMissingKey) {
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "database monitors cannot be saved until a secrets key is configured")
+		}
+
 		c.Log.WithError(err).Error("failed to update monitor")
 
 		return echo.ErrInternalServerError
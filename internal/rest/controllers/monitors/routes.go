@@ -56,6 +56,9 @@ func Register(
 
 	monitorsGroup.PUT("/:monitorId/state", AuthHandler(h.PutMonitorState), AllowedRoles(mw.UserRoleOwner, mw.UserRoleAdmin))
 
+	monitorsGroup.GET("/:monitorId/dependencies", AuthHandler(h.GetMonitorDependencies))
+	monitorsGroup.PUT("/:monitorId/dependencies", AuthHandler(h.PutMonitorDependencies), AllowedRoles(mw.UserRoleOwner, mw.UserRoleAdmin))
+
 	monitorsGroup.GET("/:monitorId/checks", AuthHandler(h.GetMonitorChecks))
 	monitorsGroup.GET("/:monitorId/checks/failed/:monitorAssertionId", AuthHandler(h.GetFailedMonitorChecks))
 	monitorsGroup.GET("/:monitorId/checks/:checkId", AuthHandler(h.GetMonitorCheck))
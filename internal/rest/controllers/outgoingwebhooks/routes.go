@@ -0,0 +1,42 @@
+package outgoingwebhooks
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/rest/handlers"
+	mw "github.com/opsway-io/backend/internal/rest/middleware"
+	"github.com/opsway-io/backend/internal/team"
+	"github.com/opsway-io/backend/internal/webhook"
+	"github.com/sirupsen/logrus"
+)
+
+type Handlers struct {
+	WebhookService webhook.Service
+}
+
+func Register(
+	e *echo.Group,
+	logger *logrus.Entry,
+	teamService team.Service,
+	webhookService webhook.Service,
+) {
+	h := &Handlers{
+		WebhookService: webhookService,
+	}
+
+	TeamGuard := mw.TeamGuardFactory(logger, teamService)
+	AllowedRoles := mw.RoleGuardFactory(logger, teamService)
+	AuthHandler := handlers.AuthenticatedHandlerFactory(logger)
+
+	webhooksGroup := e.Group(
+		"/teams/:teamId/webhooks",
+		TeamGuard(),
+		AllowedRoles(mw.UserRoleOwner, mw.UserRoleAdmin),
+	)
+
+	webhooksGroup.GET("", AuthHandler(h.GetWebhooks))
+	webhooksGroup.POST("", AuthHandler(h.PostWebhook))
+	webhooksGroup.GET("/:webhookId", AuthHandler(h.GetWebhook))
+	webhooksGroup.PUT("/:webhookId", AuthHandler(h.PutWebhook))
+	webhooksGroup.DELETE("/:webhookId", AuthHandler(h.DeleteWebhook))
+	webhooksGroup.POST("/:webhookId/secret", AuthHandler(h.PostWebhookSecret))
+}
@@ -0,0 +1,228 @@
+package outgoingwebhooks
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/entities"
+	hs "github.com/opsway-io/backend/internal/rest/handlers"
+	"github.com/opsway-io/backend/internal/rest/helpers"
+	"github.com/opsway-io/backend/internal/webhook"
+)
+
+type Webhook struct {
+	ID           uint     `json:"id"`
+	Name         string   `json:"name"`
+	URL          string   `json:"url"`
+	Events       []string `json:"events"`
+	BodyTemplate *string  `json:"bodyTemplate"`
+	Enabled      bool     `json:"enabled"`
+	CreatedAt    string   `json:"createdAt"`
+	UpdatedAt    string   `json:"updatedAt"`
+}
+
+func newWebhook(e *entities.WebhookEndpoint) Webhook {
+	events := []string(e.Events)
+	if events == nil {
+		events = []string{}
+	}
+
+	return Webhook{
+		ID:           e.ID,
+		Name:         e.Name,
+		URL:          e.URL,
+		Events:       events,
+		BodyTemplate: e.BodyTemplate,
+		Enabled:      e.Enabled,
+		CreatedAt:    e.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:    e.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+type GetWebhooksRequest struct {
+	TeamID uint `param:"teamId" validate:"required,numeric,gte=0"`
+}
+
+type GetWebhooksResponse struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+func (h *Handlers) GetWebhooks(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[GetWebhooksRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind GetWebhooksRequest")
+		return echo.ErrBadRequest
+	}
+
+	endpoints, err := h.WebhookService.GetByTeamID(c.Request().Context(), req.TeamID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get webhooks")
+		return echo.ErrInternalServerError
+	}
+
+	resp := GetWebhooksResponse{
+		Webhooks: make([]Webhook, len(*endpoints)),
+	}
+
+	for i, e := range *endpoints {
+		resp.Webhooks[i] = newWebhook(&e)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+type GetWebhookRequest struct {
+	TeamID    uint `param:"teamId" validate:"required,numeric,gte=0"`
+	WebhookID uint `param:"webhookId" validate:"required,numeric,gte=0"`
+}
+
+func (h *Handlers) GetWebhook(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[GetWebhookRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind GetWebhookRequest")
+		return echo.ErrBadRequest
+	}
+
+	endpoint, err := h.WebhookService.GetByIDAndTeamID(c.Request().Context(), req.TeamID, req.WebhookID)
+	if err != nil {
+		if errors.Is(err, webhook.ErrNotFound) {
+			return echo.ErrNotFound
+		}
+
+		c.Log.WithError(err).Error("failed to get webhook")
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, newWebhook(endpoint))
+}
+
+type PostWebhookRequest struct {
+	TeamID       uint     `param:"teamId" validate:"required,numeric,gte=0"`
+	Name         string   `json:"name" validate:"required,max=255"`
+	URL          string   `json:"url" validate:"required,url,max=2048"`
+	Events       []string `json:"events" validate:"omitempty,dive,required"`
+	BodyTemplate *string  `json:"bodyTemplate" validate:"omitempty,max=65535"`
+	Enabled      *bool    `json:"enabled"`
+}
+
+type PostWebhookResponse struct {
+	Webhook
+	// Only returned on creation and when rotated
+	Secret string `json:"secret"`
+}
+
+func (h *Handlers) PostWebhook(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[PostWebhookRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind PostWebhookRequest")
+		return echo.ErrBadRequest
+	}
+
+	endpoint := &entities.WebhookEndpoint{
+		TeamID:       req.TeamID,
+		Name:         req.Name,
+		URL:          req.URL,
+		Events:       req.Events,
+		BodyTemplate: req.BodyTemplate,
+		Enabled:      req.Enabled == nil || *req.Enabled,
+	}
+
+	if err := h.WebhookService.Create(c.Request().Context(), endpoint); err != nil {
+		if errors.Is(err, webhook.ErrInvalidEvent) || errors.Is(err, webhook.ErrInvalidTemplate) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		c.Log.WithError(err).Error("failed to create webhook")
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusCreated, PostWebhookResponse{
+		Webhook: newWebhook(endpoint),
+		Secret:  endpoint.Secret,
+	})
+}
+
+type PutWebhookRequest struct {
+	TeamID       uint     `param:"teamId" validate:"required,numeric,gte=0"`
+	WebhookID    uint     `param:"webhookId" validate:"required,numeric,gte=0"`
+	Name         string   `json:"name" validate:"required,max=255"`
+	URL          string   `json:"url" validate:"required,url,max=2048"`
+	Events       []string `json:"events" validate:"omitempty,dive,required"`
+	BodyTemplate *string  `json:"bodyTemplate" validate:"omitempty,max=65535"`
+	Enabled      bool     `json:"enabled"`
+}
+
+func (h *Handlers) PutWebhook(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[PutWebhookRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind PutWebhookRequest")
+		return echo.ErrBadRequest
+	}
+
+	endpoint := &entities.WebhookEndpoint{
+		ID:           req.WebhookID,
+		TeamID:       req.TeamID,
+		Name:         req.Name,
+		URL:          req.URL,
+		Events:       req.Events,
+		BodyTemplate: req.BodyTemplate,
+		Enabled:      req.Enabled,
+	}
+
+	if err := h.WebhookService.Update(c.Request().Context(), endpoint); err != nil {
+		if errors.Is(err, webhook.ErrNotFound) {
+			return echo.ErrNotFound
+		}
+
+		if errors.Is(err, webhook.ErrInvalidEvent) || errors.Is(err, webhook.ErrInvalidTemplate) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		c.Log.WithError(err).Error("failed to update webhook")
+		return echo.ErrInternalServerError
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handlers) DeleteWebhook(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[GetWebhookRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind GetWebhookRequest")
+		return echo.ErrBadRequest
+	}
+
+	if err := h.WebhookService.Delete(c.Request().Context(), req.TeamID, req.WebhookID); err != nil {
+		c.Log.WithError(err).Error("failed to delete webhook")
+		return echo.ErrInternalServerError
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+type PostWebhookSecretResponse struct {
+	Secret string `json:"secret"`
+}
+
+func (h *Handlers) PostWebhookSecret(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[GetWebhookRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind GetWebhookRequest")
+		return echo.ErrBadRequest
+	}
+
+	secret, err := h.WebhookService.RotateSecret(c.Request().Context(), req.TeamID, req.WebhookID)
+	if err != nil {
+		if errors.Is(err, webhook.ErrNotFound) {
+			return echo.ErrNotFound
+		}
+
+		c.Log.WithError(err).Error("failed to rotate webhook secret")
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, PostWebhookSecretResponse{
+		Secret: secret,
+	})
+}
@@ -115,7 +115,7 @@ func (h *Handlers) newGetReportResponse(reports *[]entities.Report) *GetReportsR
 
 type PostReportsRequest struct {
 	TeamID     uint   `param:"teamId" validate:"required,numeric,gte=0"`
-	ReportType string `json:"reportType" validate:"required,oneof=UPTIME PERFORMANCE INCIDENT ALL CUSTOM"`
+	ReportType string `json:"reportType" validate:"required,oneof=UPTIME PERFORMANCE INCIDENT INCIDENT_ANALYTICS ALL CUSTOM"`
 	Start      string `json:"start" validate:"required"`
 	End        string `json:"end" validate:"required"`
 }
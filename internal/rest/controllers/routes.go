@@ -14,7 +14,10 @@ import (
 	"github.com/opsway-io/backend/internal/incident"
 	"github.com/opsway-io/backend/internal/maintenance"
 	"github.com/opsway-io/backend/internal/monitor"
+	"github.com/opsway-io/backend/internal/notification/delivery"
 	"github.com/opsway-io/backend/internal/notification/email"
+	"github.com/opsway-io/backend/internal/notification/phone"
+	"github.com/opsway-io/backend/internal/postmortem"
 	"github.com/opsway-io/backend/internal/report"
 	alertingController "github.com/opsway-io/backend/internal/rest/controllers/alerting"
 	"github.com/opsway-io/backend/internal/rest/controllers/authentication"
@@ -22,6 +25,7 @@ import (
 	"github.com/opsway-io/backend/internal/rest/controllers/healthz"
 	heartbeatsController "github.com/opsway-io/backend/internal/rest/controllers/heartbeats"
 	"github.com/opsway-io/backend/internal/rest/controllers/incidents"
+	"github.com/opsway-io/backend/internal/rest/controllers/outgoingwebhooks"
 	maintenanceController "github.com/opsway-io/backend/internal/rest/controllers/maintenance"
 	"github.com/opsway-io/backend/internal/rest/controllers/metrics"
 	"github.com/opsway-io/backend/internal/rest/controllers/monitors"
@@ -38,6 +42,7 @@ import (
 	"github.com/opsway-io/backend/internal/statuspage"
 	"github.com/opsway-io/backend/internal/team"
 	"github.com/opsway-io/backend/internal/user"
+	"github.com/opsway-io/backend/internal/webhook"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
@@ -62,6 +67,9 @@ func Register(
 	reportsService report.Service,
 	statusPageService statuspage.Service,
 	escalationService escalation.Service,
+	deliveryService delivery.Service,
+	webhookService webhook.Service,
+	postmortemService postmortem.Service,
 	eventService event.Service,
 	apiKeyService apikey.Service,
 	emailSender email.Sender,
@@ -69,6 +77,8 @@ func Register(
 	db *gorm.DB,
 	ch *gorm.DB,
 	statusPageBaseURL string,
+	phoneConfig phone.Config,
+	slackConfig webhooks.SlackConfig,
 ) {
 	AuthGuard := middleware.AuthGuardFactory(logger, authenticationService)
 
@@ -89,6 +99,8 @@ func Register(
 		billingService,
 		teamService,
 		incidentService,
+		phoneConfig,
+		slackConfig,
 	)
 
 	// Healthz
@@ -123,7 +135,10 @@ func Register(
 	alertingController.Register(authRoot, logger, teamService, alertingService)
 
 	// Incidents
-	incidents.Register(authRoot, logger, teamService, incidentService)
+	incidents.Register(authRoot, logger, teamService, incidentService, deliveryService, postmortemService)
+
+	// Outgoing webhooks
+	outgoingwebhooks.Register(authRoot, logger, teamService, webhookService)
 
 	// Heartbeats
 	heartbeatsController.Register(authRoot, logger, teamService, heartbeatService)
@@ -135,7 +150,7 @@ func Register(
 	reports.Register(authRoot, logger, teamService, reportsService, checkService, eventService)
 
 	statuspages.Register(authRoot, logger, teamService, statusPageService)
-	statuspages.RegisterPublic(root, logger, statusPageBaseURL, statusPageService, incidentService, maintenanceService, emailSender)
+	statuspages.RegisterPublic(root, logger, statusPageBaseURL, statusPageService, incidentService, maintenanceService, postmortemService, emailSender)
 
 	// Prober
 	prober.Register(root, logger, availableLocations)
@@ -8,10 +8,12 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/entities"
 	"github.com/opsway-io/backend/internal/incident"
 	"github.com/opsway-io/backend/internal/maintenance"
 	"github.com/opsway-io/backend/internal/notification/email"
 	"github.com/opsway-io/backend/internal/notification/email/templates"
+	"github.com/opsway-io/backend/internal/postmortem"
 	"github.com/opsway-io/backend/internal/rest/helpers"
 	"github.com/opsway-io/backend/internal/statuspage"
 	"github.com/sirupsen/logrus"
@@ -23,6 +25,7 @@ type PublicHandlers struct {
 	StatusPageService  statuspage.Service
 	IncidentService    incident.Service
 	MaintenanceService maintenance.Service
+	PostmortemService  postmortem.Service
 	EmailSender        email.Sender
 }
 
@@ -33,6 +36,7 @@ func RegisterPublic(
 	statusPageService statuspage.Service,
 	incidentService incident.Service,
 	maintenanceService maintenance.Service,
+	postmortemService postmortem.Service,
 	emailSender email.Sender,
 ) {
 	h := &PublicHandlers{
@@ -40,6 +44,7 @@ func RegisterPublic(
 		StatusPageService:  statusPageService,
 		IncidentService:    incidentService,
 		MaintenanceService: maintenanceService,
+		PostmortemService:  postmortemService,
 		EmailSender:        emailSender,
 	}
 
@@ -60,10 +65,32 @@ type PublicMonitor struct {
 }
 
 type PublicIncident struct {
-	ID          uint   `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	MonitorID   *uint  `json:"monitorId"`
+	ID          uint                   `json:"id"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description"`
+	MonitorID   *uint                  `json:"monitorId"`
+	Status      string                 `json:"status"`
+	Severity    string                 `json:"severity"`
+	Updates     []PublicIncidentUpdate `json:"updates"`
+	CreatedAt   time.Time              `json:"createdAt"`
+}
+
+type PublicIncidentUpdate struct {
+	Status    string    `json:"status"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type PublicPostmortem struct {
+	IncidentID          uint                               `json:"incidentId"`
+	Title               string                             `json:"title"`
+	Summary             string                             `json:"summary"`
+	RootCause           string                             `json:"rootCause"`
+	ContributingFactors []string                           `json:"contributingFactors"`
+	ImpactStartedAt     time.Time                          `json:"impactStartedAt"`
+	ImpactEndedAt       *time.Time                         `json:"impactEndedAt"`
+	Timeline            []entities.PostmortemTimelineEntry `json:"timeline"`
+	PublishedAt         *time.Time                         `json:"publishedAt"`
 }
 
 type PublicMaintenance struct {
@@ -90,6 +117,7 @@ type GetPublicStatusPageResponse struct {
 	ActiveIncidents      []PublicIncident    `json:"activeIncidents"`
 	ActiveMaintenance    []PublicMaintenance `json:"activeMaintenance"`
 	MaintenanceEvents    []PublicMaintenance `json:"maintenanceEvents"`
+	Postmortems          []PublicPostmortem  `json:"postmortems"`
 }
 
 func (h *PublicHandlers) GetPublicStatusPage(c echo.Context) error {
@@ -144,16 +172,43 @@ func (h *PublicHandlers) GetPublicStatusPage(c echo.Context) error {
 	activeIncidents := []PublicIncident{}
 	incidents, err := h.IncidentService.GetActiveByMonitorIDs(c.Request().Context(), monitorIDs)
 	if err == nil {
+		incidentIDs := make([]uint, len(incidents))
+		for i, inc := range incidents {
+			incidentIDs[i] = inc.ID
+		}
+
+		// Only updates marked as public are shown, the rest is internal to the team
+		updatesByIncident := map[uint][]PublicIncidentUpdate{}
+		updates, err := h.IncidentService.GetPublicUpdatesByIncidentIDs(c.Request().Context(), incidentIDs)
+		if err != nil {
+			logrus.WithError(err).Error("failed to get public incident updates")
+		}
+		for _, u := range updates {
+			updatesByIncident[u.IncidentID] = append(updatesByIncident[u.IncidentID], PublicIncidentUpdate{
+				Status:    string(u.Status),
+				Message:   u.Message,
+				CreatedAt: u.CreatedAt,
+			})
+		}
+
 		for _, inc := range incidents {
 			desc := ""
 			if inc.Description != nil {
 				desc = *inc.Description
 			}
+			incidentUpdates := updatesByIncident[inc.ID]
+			if incidentUpdates == nil {
+				incidentUpdates = []PublicIncidentUpdate{}
+			}
 			activeIncidents = append(activeIncidents, PublicIncident{
 				ID:          inc.ID,
 				Title:       inc.Title,
 				Description: desc,
 				MonitorID:   inc.MonitorID,
+				Status:      string(inc.Status),
+				Severity:    string(inc.Severity),
+				Updates:     incidentUpdates,
+				CreatedAt:   inc.CreatedAt,
 			})
 		}
 	}
@@ -194,6 +249,14 @@ func (h *PublicHandlers) GetPublicStatusPage(c echo.Context) error {
 		}
 	}
 
+	publicPostmortems := []PublicPostmortem{}
+	postmortems, err := h.PostmortemService.GetPublishedByMonitorIDs(c.Request().Context(), monitorIDs, publicPostmortemLimit)
+	if err == nil {
+		for _, pm := range postmortems {
+			publicPostmortems = append(publicPostmortems, newPublicPostmortem(&pm))
+		}
+	}
+
 	return c.JSON(http.StatusOK, GetPublicStatusPageResponse{
 		Name:                 sp.Name,
 		LogoURL:              sp.LogoURL,
@@ -210,9 +273,47 @@ func (h *PublicHandlers) GetPublicStatusPage(c echo.Context) error {
 		ActiveIncidents:      activeIncidents,
 		ActiveMaintenance:    activeMaintenance,
 		MaintenanceEvents:    maintenanceEvents,
+		Postmortems:          publicPostmortems,
 	})
 }
 
+// Number of most recently published postmortems shown on a status page
+const publicPostmortemLimit = 10
+
+// newPublicPostmortem leaves out the action items and the internal entries of
+// the timeline, they are only meant for the team
+func newPublicPostmortem(p *entities.Postmortem) PublicPostmortem {
+	pm := PublicPostmortem{
+		IncidentID:          p.IncidentID,
+		Title:               p.Title,
+		ContributingFactors: p.ContributingFactors,
+		ImpactStartedAt:     p.ImpactStartedAt,
+		ImpactEndedAt:       p.ImpactEndedAt,
+		PublishedAt:         p.PublishedAt,
+	}
+
+	for _, e := range p.Timeline {
+		if !e.IsInternal() {
+			pm.Timeline = append(pm.Timeline, e)
+		}
+	}
+
+	if p.Summary != nil {
+		pm.Summary = *p.Summary
+	}
+	if p.RootCause != nil {
+		pm.RootCause = *p.RootCause
+	}
+	if pm.ContributingFactors == nil {
+		pm.ContributingFactors = []string{}
+	}
+	if pm.Timeline == nil {
+		pm.Timeline = []entities.PostmortemTimelineEntry{}
+	}
+
+	return pm
+}
+
 type SubscribeRequest struct {
 	Domain string `param:"domain" validate:"required"`
 	Email  string `json:"email" validate:"required,email"`
@@ -23,6 +23,8 @@ type OnCallRotationResponse struct {
 type EscalationPolicyResponse struct {
 	Name                     string                   `json:"name"`
 	EscalationTimeoutMinutes int                      `json:"escalationTimeoutMinutes"`
+	RepeatUntilAcknowledged  bool                     `json:"repeatUntilAcknowledged"`
+	MaxRepeats               int                      `json:"maxRepeats"`
 	Rotations                []OnCallRotationResponse `json:"rotations"`
 }
 
@@ -65,6 +67,8 @@ func (h *Handlers) GetEscalationPolicy(c hs.AuthenticatedContext) error {
 	return c.JSON(http.StatusOK, EscalationPolicyResponse{
 		Name: policy.Name,
 		EscalationTimeoutMinutes: policy.EscalationTimeoutMinutes,
+		RepeatUntilAcknowledged: policy.RepeatUntilAcknowledged,
+		MaxRepeats: policy.MaxRepeats,
 		Rotations: rotationResps,
 	})
 }
@@ -73,6 +77,8 @@ type PutEscalationPolicyRequest struct {
 	TeamID                   uint                     `param:"teamId" validate:"required,numeric,gte=0"`
 	Name                     string                   `json:"name" validate:"required"`
 	EscalationTimeoutMinutes int                      `json:"escalationTimeoutMinutes" validate:"required,min=1"`
+	RepeatUntilAcknowledged  bool                     `json:"repeatUntilAcknowledged"`
+	MaxRepeats               int                      `json:"maxRepeats" validate:"omitempty,min=0,max=100"`
 	Rotations                []OnCallRotationResponse `json:"rotations"`
 }
 
@@ -96,6 +102,8 @@ func (h *Handlers) PutEscalationPolicy(c hs.AuthenticatedContext) error {
 			TeamID: req.TeamID,
 			Name: req.Name,
 			EscalationTimeoutMinutes: req.EscalationTimeoutMinutes,
+			RepeatUntilAcknowledged: req.RepeatUntilAcknowledged,
+			MaxRepeats: req.MaxRepeats,
 		}
 		if err := h.EscalationService.CreatePolicy(ctx, policy); err != nil {
 			c.Log.WithError(err).Error("failed to create escalation policy")
@@ -104,6 +112,8 @@ func (h *Handlers) PutEscalationPolicy(c hs.AuthenticatedContext) error {
 	} else {
 		policy.Name = req.Name
 		policy.EscalationTimeoutMinutes = req.EscalationTimeoutMinutes
+		policy.RepeatUntilAcknowledged = req.RepeatUntilAcknowledged
+		policy.MaxRepeats = req.MaxRepeats
 		if err := h.EscalationService.UpdatePolicy(ctx, policy); err != nil {
 			c.Log.WithError(err).Error("failed to update escalation policy")
 			return echo.ErrInternalServerError
@@ -0,0 +1,39 @@
+package teams
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	hs "github.com/opsway-io/backend/internal/rest/handlers"
+	"github.com/opsway-io/backend/internal/rest/helpers"
+)
+
+type PostTeamIngestionTokenRequest struct {
+	TeamID uint `param:"teamId" validate:"required,numeric,gt=0"`
+}
+
+type PostTeamIngestionTokenResponse struct {
+	Token string `json:"token"`
+}
+
+// PostTeamIngestionToken generates a new token for the Datadog and New Relic
+// webhooks, replacing the previous one. The token is only returned once.
+func (h *Handlers) PostTeamIngestionToken(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[PostTeamIngestionTokenRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind PostTeamIngestionTokenRequest")
+
+		return echo.ErrBadRequest
+	}
+
+	token, err := h.TeamService.RotateIngestionToken(c.Request().Context(), req.TeamID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to rotate ingestion token")
+
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, PostTeamIngestionTokenResponse{
+		Token: token,
+	})
+}
@@ -0,0 +1,43 @@
+package teams
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	hs "github.com/opsway-io/backend/internal/rest/handlers"
+	"github.com/opsway-io/backend/internal/rest/helpers"
+)
+
+type GetTeamIntegrationsRequest struct {
+	TeamID uint `param:"teamId" validate:"required,numeric,gt=0"`
+}
+
+type GetTeamIntegrationsResponse struct {
+	PagerDutyWebhookSecret *string `json:"pagerDutyWebhookSecret"`
+	OpsgenieAPIKey         *string `json:"opsgenieApiKey"`
+	OpsgenieWebhookToken   *string `json:"opsgenieWebhookToken"`
+}
+
+// GetTeamIntegrations returns the secrets of the PagerDuty and Opsgenie
+// integrations, which GetTeam leaves out as every member can read it.
+func (h *Handlers) GetTeamIntegrations(c hs.AuthenticatedContext) error {
+	req, err := helpers.Bind[GetTeamIntegrationsRequest](c)
+	if err != nil {
+		c.Log.WithError(err).Debug("failed to bind GetTeamIntegrationsRequest")
+
+		return echo.ErrBadRequest
+	}
+
+	team, err := h.TeamService.GetByID(c.Request().Context(), req.TeamID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get team")
+
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusOK, GetTeamIntegrationsResponse{
+		PagerDutyWebhookSecret: team.PagerDutyWebhookSecret,
+		OpsgenieAPIKey:         team.OpsgenieAPIKey,
+		OpsgenieWebhookToken:   team.OpsgenieWebhookToken,
+	})
+}
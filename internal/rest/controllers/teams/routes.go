@@ -70,6 +70,9 @@ func Register(
 	teamsGroup.GET("/customer-session", AuthHandler(h.GetCustomerSession), AllowedRoles(mw.UserRoleOwner, mw.UserRoleAdmin))
 	teamsGroup.GET("/products", AuthHandler(h.GetProducts), AllowedRoles(mw.UserRoleOwner, mw.UserRoleAdmin))
 
+	teamsGroup.POST("/ingestion-token", AuthHandler(h.PostTeamIngestionToken), AllowedRoles(mw.UserRoleOwner, mw.UserRoleAdmin))
+	teamsGroup.GET("/integrations", AuthHandler(h.GetTeamIntegrations), AllowedRoles(mw.UserRoleOwner, mw.UserRoleAdmin))
+
 	teamsGroup.GET("/escalation", AuthHandler(h.GetEscalationPolicy), AllowedRoles(mw.UserRoleOwner, mw.UserRoleAdmin))
 	teamsGroup.PUT("/escalation", AuthHandler(h.PutEscalationPolicy), AllowedRoles(mw.UserRoleOwner, mw.UserRoleAdmin))
 }
@@ -17,18 +17,31 @@ type GetTeamRequest struct {
 }
 
 type GetTeamResponse struct {
-	ID                 uint      `json:"id"`
-	Name               string    `json:"name"`
-	DisplayName        *string   `json:"displayName"`
-	PaymentPlan        string    `json:"paymentPlan"`
-	AvatarURL          *string   `json:"avatarUrl"`
-	SlackWebhookURL    *string   `json:"slackWebhookUrl"`
-	DiscordWebhookURL  *string   `json:"discordWebhookUrl"`
-	TelegramChatID     *string   `json:"telegramChatId"`
-	DatadogWebhookURL  *string   `json:"datadogWebhookUrl"`
-	NewRelicWebhookURL *string   `json:"newRelicWebhookUrl"`
-	CreatedAt          time.Time `json:"createdAt"`
-	UpdatedAt          time.Time `json:"updatedAt"`
+	ID                   uint    `json:"id"`
+	Name                 string  `json:"name"`
+	DisplayName          *string `json:"displayName"`
+	PaymentPlan          string  `json:"paymentPlan"`
+	AvatarURL            *string `json:"avatarUrl"`
+	SlackWebhookURL      *string `json:"slackWebhookUrl"`
+	SlackWorkspaceID     *string `json:"slackWorkspaceId"`
+	DiscordWebhookURL    *string `json:"discordWebhookUrl"`
+	TelegramChatID       *string `json:"telegramChatId"`
+	DatadogWebhookURL    *string `json:"datadogWebhookUrl"`
+	NewRelicWebhookURL   *string `json:"newRelicWebhookUrl"`
+	MSTeamsWebhookURL    *string `json:"msTeamsWebhookUrl"`
+	MattermostWebhookURL *string `json:"mattermostWebhookUrl"`
+	GoogleChatWebhookURL *string `json:"googleChatWebhookUrl"`
+	PagerDutyRoutingKey  *string `json:"pagerDutyRoutingKey"`
+	// The secrets themselves are only returned by GetTeamIntegrations
+	HasPagerDutyWebhookSecret bool      `json:"hasPagerDutyWebhookSecret"`
+	HasOpsgenieAPIKey         bool      `json:"hasOpsgenieApiKey"`
+	HasOpsgenieWebhookToken   bool      `json:"hasOpsgenieWebhookToken"`
+	OpsgenieEURegion          bool      `json:"opsgenieEuRegion"`
+	HasIngestionToken         bool      `json:"hasIngestionToken"`
+	IncidentGrouping          string    `json:"incidentGrouping"`
+	IncidentGroupingWindow    int       `json:"incidentGroupingWindow"`
+	CreatedAt                 time.Time `json:"createdAt"`
+	UpdatedAt                 time.Time `json:"updatedAt"`
 }
 
 func (h *Handlers) GetTeam(c hs.AuthenticatedContext) error {
@@ -51,17 +64,29 @@ func (h *Handlers) GetTeam(c hs.AuthenticatedContext) error {
 
 func newGetTeamResponse(t *entities.Team, teamService team.Service) GetTeamResponse {
 	team := GetTeamResponse{
-		ID:                 t.ID,
-		Name:               t.Name,
-		DisplayName:        t.DisplayName,
-		PaymentPlan:        string(t.PaymentPlan),
-		SlackWebhookURL:    t.SlackWebhookURL,
-		DiscordWebhookURL:  t.DiscordWebhookURL,
-		TelegramChatID:     t.TelegramChatID,
-		DatadogWebhookURL:  t.DatadogWebhookURL,
-		NewRelicWebhookURL: t.NewRelicWebhookURL,
-		CreatedAt:          t.CreatedAt,
-		UpdatedAt:          t.UpdatedAt,
+		ID:                        t.ID,
+		Name:                      t.Name,
+		DisplayName:               t.DisplayName,
+		PaymentPlan:               string(t.PaymentPlan),
+		SlackWebhookURL:           t.SlackWebhookURL,
+		SlackWorkspaceID:          t.SlackWorkspaceID,
+		DiscordWebhookURL:         t.DiscordWebhookURL,
+		TelegramChatID:            t.TelegramChatID,
+		DatadogWebhookURL:         t.DatadogWebhookURL,
+		NewRelicWebhookURL:        t.NewRelicWebhookURL,
+		MSTeamsWebhookURL:         t.MSTeamsWebhookURL,
+		MattermostWebhookURL:      t.MattermostWebhookURL,
+		GoogleChatWebhookURL:      t.GoogleChatWebhookURL,
+		PagerDutyRoutingKey:       t.PagerDutyRoutingKey,
+		HasPagerDutyWebhookSecret: isSet(t.PagerDutyWebhookSecret),
+		HasOpsgenieAPIKey:         isSet(t.OpsgenieAPIKey),
+		HasOpsgenieWebhookToken:   isSet(t.OpsgenieWebhookToken),
+		OpsgenieEURegion:          t.OpsgenieEURegion,
+		HasIngestionToken:         t.HasIngestionToken(),
+		IncidentGrouping:          string(t.IncidentGrouping),
+		IncidentGroupingWindow:    t.IncidentGroupingWindow,
+		CreatedAt:                 t.CreatedAt,
+		UpdatedAt:                 t.UpdatedAt,
 	}
 
 	if t.HasAvatar {
@@ -71,14 +96,30 @@ func newGetTeamResponse(t *entities.Team, teamService team.Service) GetTeamRespo
 	return team
 }
 
+func isSet(s *string) bool {
+	return s != nil && *s != ""
+}
+
 type PutTeamRequest struct {
-	TeamID             uint    `param:"teamId" validate:"required,numeric,gt=0"`
-	DisplayName        string  `json:"displayName" validate:"max=255"`
-	SlackWebhookURL    *string `json:"slackWebhookUrl"`
-	DiscordWebhookURL  *string `json:"discordWebhookUrl"`
-	TelegramChatID     *string `json:"telegramChatId"`
-	DatadogWebhookURL  *string `json:"datadogWebhookUrl"`
-	NewRelicWebhookURL *string `json:"newRelicWebhookUrl"`
+	TeamID                 uint    `param:"teamId" validate:"required,numeric,gt=0"`
+	DisplayName            string  `json:"displayName" validate:"max=255"`
+	SlackWebhookURL        *string `json:"slackWebhookUrl"`
+	SlackWorkspaceID       *string `json:"slackWorkspaceId"`
+	DiscordWebhookURL      *string `json:"discordWebhookUrl"`
+	TelegramChatID         *string `json:"telegramChatId"`
+	DatadogWebhookURL      *string `json:"datadogWebhookUrl"`
+	NewRelicWebhookURL     *string `json:"newRelicWebhookUrl"`
+	MSTeamsWebhookURL      *string `json:"msTeamsWebhookUrl"`
+	MattermostWebhookURL   *string `json:"mattermostWebhookUrl"`
+	GoogleChatWebhookURL   *string `json:"googleChatWebhookUrl"`
+	PagerDutyRoutingKey    *string `json:"pagerDutyRoutingKey"`
+	PagerDutyWebhookSecret *string `json:"pagerDutyWebhookSecret"`
+	OpsgenieAPIKey         *string `json:"opsgenieApiKey"`
+	OpsgenieWebhookToken   *string `json:"opsgenieWebhookToken"`
+	OpsgenieEURegion       *bool   `json:"opsgenieEuRegion"`
+	IncidentGrouping       *string `json:"incidentGrouping" validate:"omitempty,oneof=NONE TIME_WINDOW DOMAIN TAG DEPENDENCY"`
+	// Seconds, at most a day
+	IncidentGroupingWindow *int `json:"incidentGroupingWindow" validate:"omitempty,min=60,max=86400"`
 }
 
 func (h *Handlers) PutTeam(c hs.AuthenticatedContext) error {
@@ -99,6 +140,9 @@ func (h *Handlers) PutTeam(c hs.AuthenticatedContext) error {
 	if req.SlackWebhookURL != nil {
 		team.SlackWebhookURL = req.SlackWebhookURL
 	}
+	if req.SlackWorkspaceID != nil {
+		team.SlackWorkspaceID = req.SlackWorkspaceID
+	}
 	if req.DiscordWebhookURL != nil {
 		team.DiscordWebhookURL = req.DiscordWebhookURL
 	}
@@ -111,6 +155,36 @@ func (h *Handlers) PutTeam(c hs.AuthenticatedContext) error {
 	if req.NewRelicWebhookURL != nil {
 		team.NewRelicWebhookURL = req.NewRelicWebhookURL
 	}
+	if req.MSTeamsWebhookURL != nil {
+		team.MSTeamsWebhookURL = req.MSTeamsWebhookURL
+	}
+	if req.MattermostWebhookURL != nil {
+		team.MattermostWebhookURL = req.MattermostWebhookURL
+	}
+	if req.GoogleChatWebhookURL != nil {
+		team.GoogleChatWebhookURL = req.GoogleChatWebhookURL
+	}
+	if req.PagerDutyRoutingKey != nil {
+		team.PagerDutyRoutingKey = req.PagerDutyRoutingKey
+	}
+	if req.PagerDutyWebhookSecret != nil {
+		team.PagerDutyWebhookSecret = req.PagerDutyWebhookSecret
+	}
+	if req.OpsgenieAPIKey != nil {
+		team.OpsgenieAPIKey = req.OpsgenieAPIKey
+	}
+	if req.OpsgenieWebhookToken != nil {
+		team.OpsgenieWebhookToken = req.OpsgenieWebhookToken
+	}
+	if req.OpsgenieEURegion != nil {
+		team.OpsgenieEURegion = *req.OpsgenieEURegion
+	}
+	if req.IncidentGrouping != nil {
+		team.IncidentGrouping = entities.IncidentGroupingStrategy(*req.IncidentGrouping)
+	}
+	if req.IncidentGroupingWindow != nil {
+		team.IncidentGroupingWindow = *req.IncidentGroupingWindow
+	}
 
 	if err = h.TeamService.UpdateTeam(
 		c.Request().Context(),
@@ -33,6 +33,7 @@ type GetTeamUsersResponseUser struct {
 	Email       string            `json:"email"`
 	AvatarURL   *string           `json:"avatarUrl"`
 	Role        entities.TeamRole `json:"role"`
+	SlackUserID *string           `json:"slackUserId"`
 }
 
 func (h *Handlers) GetTeamUsers(c hs.AuthenticatedContext) error {
@@ -63,6 +64,7 @@ func newGetTeamUsersResponse(users *[]team.TeamUser, userService user.Service) G
 			DisplayName: u.DisplayName,
 			Name:        u.Name,
 			Role:        u.Role,
+			SlackUserID: u.SlackUserID,
 		}
 
 		if u.HasAvatar {
@@ -119,6 +121,9 @@ type PutTeamUserRequest struct {
 	TeamID uint              `param:"teamId" validate:"required,numeric,gt=0"`
 	UserID uint              `param:"userId" validate:"required,numeric,gt=0"`
 	Role   entities.TeamRole `json:"role" validate:"required,teamRole"`
+	// ID of the user in the Slack workspace of the team, set to act on alerts
+	// from Slack
+	SlackUserID *string `json:"slackUserId" validate:"omitempty,max=255"`
 }
 
 func (h *Handlers) PutTeamUser(c hs.AuthenticatedContext) error {
@@ -152,5 +157,13 @@ func (h *Handlers) PutTeamUser(c hs.AuthenticatedContext) error {
 		return echo.ErrInternalServerError
 	}
 
+	if req.SlackUserID != nil {
+		if err := h.TeamService.UpdateUserSlackUserID(c.Request().Context(), req.TeamID, req.UserID, req.SlackUserID); err != nil {
+			c.Log.WithError(err).Debug("failed to update slack user id of team user")
+
+			return echo.ErrInternalServerError
+		}
+	}
+
 	return c.NoContent(http.StatusNoContent)
 }
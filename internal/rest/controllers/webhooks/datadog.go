@@ -21,28 +21,34 @@ func (h *Handlers) PostDatadogWebhook(c echo.Context) error {
 		return c.String(http.StatusBadRequest, "invalid team id")
 	}
 
-	// Verify team exists
-	_, err = h.TeamService.GetByID(c.Request().Context(), uint(teamID))
+	team, err := h.TeamService.GetByID(c.Request().Context(), uint(teamID))
 	if err != nil {
 		c.Logger().Errorf("failed to fetch team %d: %v", teamID, err)
 		return c.String(http.StatusNotFound, "team not found")
 	}
 
+	if !team.VerifyIngestionToken(ingestionToken(c.Request())) {
+		return c.String(http.StatusUnauthorized, "invalid token")
+	}
+
 	var payload DatadogWebhookPayload
 	if err := json.NewDecoder(c.Request().Body).Decode(&payload); err != nil {
 		c.Logger().Errorf("failed to decode datadog payload: %v", err)
 		return c.String(http.StatusBadRequest, "invalid payload")
 	}
 
-	incidents := []entities.Incident{
-		{
-			TeamID:      uint(teamID),
-			Title:       "Datadog Alert: " + payload.Title,
-			Description: &payload.Body,
-		},
+	fingerprint := entities.ExternalIncidentFingerprint(entities.IncidentSourceDatadog, payload.Title)
+	incident := &entities.Incident{
+		TeamID:      uint(teamID),
+		Title:       "Datadog Alert: " + payload.Title,
+		Description: &payload.Body,
+		Source:      entities.IncidentSourceDatadog,
+		Kind:        entities.IncidentKindExternal,
+		Fingerprint: &fingerprint,
+		Severity:    entities.IncidentSeverityMajor,
 	}
 
-	if err := h.IncidentService.Create(c.Request().Context(), &incidents); err != nil {
+	if _, _, err := h.IncidentService.Open(c.Request().Context(), incident); err != nil {
 		c.Logger().Errorf("failed to create incident for datadog alert: %v", err)
 		return c.String(http.StatusInternalServerError, "failed to create incident")
 	}
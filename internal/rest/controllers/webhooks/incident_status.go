@@ -0,0 +1,63 @@
+package webhooks
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/incident"
+)
+
+type incidentStatus int
+
+const (
+	incidentStatusAcknowledged incidentStatus = iota
+	incidentStatusResolved
+)
+
+// syncIncidentStatus applies a status change made in an external incident
+// management tool to the incident. The user is who made the change, if known.
+func (h *Handlers) syncIncidentStatus(c echo.Context, teamID, incidentID uint, status incidentStatus, userID *uint) error {
+	ctx := c.Request().Context()
+
+	in, err := h.IncidentService.GetByID(ctx, incidentID)
+	if err != nil {
+		if errors.Is(err, incident.ErrNotFound) {
+			return c.String(http.StatusOK, "ok")
+		}
+
+		c.Logger().Errorf("failed to fetch incident %d: %v", incidentID, err)
+		return c.String(http.StatusInternalServerError, "error fetching incident")
+	}
+
+	// The key could have been crafted to reference an incident of another team
+	if in.TeamID != teamID {
+		return c.String(http.StatusOK, "ok")
+	}
+
+	switch status {
+	case incidentStatusAcknowledged:
+		if in.Acknowledged {
+			return c.String(http.StatusOK, "ok")
+		}
+
+		in.Acknowledged = true
+		now := time.Now()
+		in.AcknowledgedAt = &now
+		in.AcknowledgedBy = userID
+	case incidentStatusResolved:
+		if in.Resolved {
+			return c.String(http.StatusOK, "ok")
+		}
+
+		in.Resolved = true
+	}
+
+	if err := h.IncidentService.Update(ctx, in); err != nil {
+		c.Logger().Errorf("failed to update incident %d: %v", incidentID, err)
+		return c.String(http.StatusInternalServerError, "error updating incident")
+	}
+
+	return c.String(http.StatusOK, "ok")
+}
@@ -0,0 +1,20 @@
+package webhooks
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Header carrying the team token for tools that cannot set the Authorization
+// header on their webhooks.
+const tokenHeader = "X-Opsway-Token"
+
+// ingestionToken returns the token an alert integration authenticated with,
+// either as a bearer token or in the token header.
+func ingestionToken(req *http.Request) string {
+	if typ, token, ok := strings.Cut(req.Header.Get("Authorization"), " "); ok && typ == "Bearer" {
+		return token
+	}
+
+	return req.Header.Get(tokenHeader)
+}
@@ -21,28 +21,34 @@ func (h *Handlers) PostNewRelicWebhook(c echo.Context) error {
 		return c.String(http.StatusBadRequest, "invalid team id")
 	}
 
-	// Verify team exists
-	_, err = h.TeamService.GetByID(c.Request().Context(), uint(teamID))
+	team, err := h.TeamService.GetByID(c.Request().Context(), uint(teamID))
 	if err != nil {
 		c.Logger().Errorf("failed to fetch team %d: %v", teamID, err)
 		return c.String(http.StatusNotFound, "team not found")
 	}
 
+	if !team.VerifyIngestionToken(ingestionToken(c.Request())) {
+		return c.String(http.StatusUnauthorized, "invalid token")
+	}
+
 	var payload NewRelicWebhookPayload
 	if err := json.NewDecoder(c.Request().Body).Decode(&payload); err != nil {
 		c.Logger().Errorf("failed to decode new relic payload: %v", err)
 		return c.String(http.StatusBadRequest, "invalid payload")
 	}
 
-	incidents := []entities.Incident{
-		{
-			TeamID:      uint(teamID),
-			Title:       "New Relic Alert: " + payload.ConditionName,
-			Description: &payload.Details,
-		},
+	fingerprint := entities.ExternalIncidentFingerprint(entities.IncidentSourceNewRelic, payload.ConditionName)
+	incident := &entities.Incident{
+		TeamID:      uint(teamID),
+		Title:       "New Relic Alert: " + payload.ConditionName,
+		Description: &payload.Details,
+		Source:      entities.IncidentSourceNewRelic,
+		Kind:        entities.IncidentKindExternal,
+		Fingerprint: &fingerprint,
+		Severity:    entities.IncidentSeverityMajor,
 	}
 
-	if err := h.IncidentService.Create(c.Request().Context(), &incidents); err != nil {
+	if _, _, err := h.IncidentService.Open(c.Request().Context(), incident); err != nil {
 		c.Logger().Errorf("failed to create incident for new relic alert: %v", err)
 		return c.String(http.StatusInternalServerError, "failed to create incident")
 	}
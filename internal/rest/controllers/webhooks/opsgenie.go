@@ -0,0 +1,66 @@
+package webhooks
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/entities"
+)
+
+type OpsgenieWebhookPayload struct {
+	Action string `json:"action"`
+	Alert  struct {
+		Alias string `json:"alias"`
+	} `json:"alert"`
+}
+
+// PostOpsgenieWebhook receives the outgoing webhooks of Opsgenie and
+// acknowledges or resolves the incident the alert was opened for. Opsgenie
+// does not sign webhooks, instead the team token is sent in a custom header
+// configured on the webhook integration.
+func (h *Handlers) PostOpsgenieWebhook(c echo.Context) error {
+	teamIDStr := c.Param("teamId")
+	teamID, err := strconv.ParseUint(teamIDStr, 10, 32)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "invalid team id")
+	}
+
+	team, err := h.TeamService.GetByID(c.Request().Context(), uint(teamID))
+	if err != nil {
+		c.Logger().Errorf("failed to fetch team %d: %v", teamID, err)
+		return c.String(http.StatusNotFound, "team not found")
+	}
+
+	token := c.Request().Header.Get(tokenHeader)
+	if team.OpsgenieWebhookToken == nil || *team.OpsgenieWebhookToken == "" ||
+		subtle.ConstantTimeCompare([]byte(token), []byte(*team.OpsgenieWebhookToken)) != 1 {
+		return c.String(http.StatusUnauthorized, "invalid token")
+	}
+
+	var payload OpsgenieWebhookPayload
+	if err := json.NewDecoder(c.Request().Body).Decode(&payload); err != nil {
+		c.Logger().Errorf("failed to decode opsgenie payload: %v", err)
+		return c.String(http.StatusBadRequest, "invalid payload")
+	}
+
+	var status incidentStatus
+	switch payload.Action {
+	case "Acknowledge":
+		status = incidentStatusAcknowledged
+	case "Close":
+		status = incidentStatusResolved
+	default:
+		return c.String(http.StatusOK, "ok")
+	}
+
+	incidentID, ok := entities.IncidentIDFromDedupKey(payload.Alert.Alias)
+	if !ok {
+		// Not opened by opsway
+		return c.String(http.StatusOK, "ok")
+	}
+
+	return h.syncIncidentStatus(c, uint(teamID), incidentID, status, nil)
+}
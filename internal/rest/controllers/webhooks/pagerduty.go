@@ -0,0 +1,95 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/entities"
+)
+
+type PagerDutyWebhookPayload struct {
+	Event struct {
+		EventType string `json:"event_type"`
+		Data      struct {
+			Type        string `json:"type"`
+			IncidentKey string `json:"incident_key"`
+		} `json:"data"`
+	} `json:"event"`
+}
+
+// PostPagerDutyWebhook receives V3 webhooks of PagerDuty and acknowledges or
+// resolves the incident the PagerDuty incident was opened for.
+func (h *Handlers) PostPagerDutyWebhook(c echo.Context) error {
+	teamIDStr := c.Param("teamId")
+	teamID, err := strconv.ParseUint(teamIDStr, 10, 32)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "invalid team id")
+	}
+
+	team, err := h.TeamService.GetByID(c.Request().Context(), uint(teamID))
+	if err != nil {
+		c.Logger().Errorf("failed to fetch team %d: %v", teamID, err)
+		return c.String(http.StatusNotFound, "team not found")
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "invalid payload")
+	}
+
+	if team.PagerDutyWebhookSecret == nil || *team.PagerDutyWebhookSecret == "" ||
+		!verifyPagerDutySignature(*team.PagerDutyWebhookSecret, body, c.Request().Header.Get("X-PagerDuty-Signature")) {
+		return c.String(http.StatusUnauthorized, "invalid signature")
+	}
+
+	var payload PagerDutyWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		c.Logger().Errorf("failed to decode pagerduty payload: %v", err)
+		return c.String(http.StatusBadRequest, "invalid payload")
+	}
+
+	if payload.Event.Data.Type != "incident" {
+		return c.String(http.StatusOK, "ok")
+	}
+
+	var status incidentStatus
+	switch payload.Event.EventType {
+	case "incident.acknowledged":
+		status = incidentStatusAcknowledged
+	case "incident.resolved":
+		status = incidentStatusResolved
+	default:
+		return c.String(http.StatusOK, "ok")
+	}
+
+	incidentID, ok := entities.IncidentIDFromDedupKey(payload.Event.Data.IncidentKey)
+	if !ok {
+		// Not opened by opsway
+		return c.String(http.StatusOK, "ok")
+	}
+
+	return h.syncIncidentStatus(c, uint(teamID), incidentID, status, nil)
+}
+
+// PagerDuty sends one signature per active secret, formatted as
+// v1=<hex>,v1=<hex>
+func verifyPagerDutySignature(secret string, body []byte, header string) bool {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := "v1=" + hex.EncodeToString(mac.Sum(nil))
+
+	for _, signature := range strings.Split(header, ",") {
+		if hmac.Equal([]byte(strings.TrimSpace(signature)), []byte(expected)) {
+			return true
+		}
+	}
+
+	return false
+}
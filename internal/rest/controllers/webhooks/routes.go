@@ -5,6 +5,7 @@ import (
 	"github.com/opsway-io/backend/internal/authentication"
 	"github.com/opsway-io/backend/internal/billing"
 	"github.com/opsway-io/backend/internal/incident"
+	"github.com/opsway-io/backend/internal/notification/phone"
 	"github.com/opsway-io/backend/internal/rest/handlers"
 	"github.com/opsway-io/backend/internal/rest/middleware"
 	"github.com/opsway-io/backend/internal/team"
@@ -16,6 +17,7 @@ type Handlers struct {
 	BillingService        billing.Service
 	TeamService           team.Service
 	IncidentService       incident.Service
+	PhoneConfig           phone.Config
 }
 
 func Register(
@@ -24,11 +26,14 @@ func Register(
 	billingService billing.Service,
 	teamService team.Service,
 	incidentService incident.Service,
+	phoneConfig phone.Config,
+	slackConfig SlackConfig,
 ) {
 	h := &Handlers{
 		BillingService:  billingService,
 		TeamService:     teamService,
 		IncidentService: incidentService,
+		PhoneConfig:     phoneConfig,
 	}
 
 	root := e.Group(
@@ -43,9 +48,19 @@ func Register(
 	root.POST("/stripe", StripeHandler(h.handleWebhook), StripeGuard())
 	
 	// Slack
-	root.POST("/slack/interactive", h.PostSlackInteractive)
+
+	SlackGuard := middleware.SlackGuardFactory(logger, slackConfig.SigningSecret)
+
+	root.POST("/slack/interactive", h.PostSlackInteractive, SlackGuard())
 	
 	// APM Integrations
 	root.POST("/datadog/:teamId", h.PostDatadogWebhook)
 	root.POST("/new_relic/:teamId", h.PostNewRelicWebhook)
+
+	// Incident management
+	root.POST("/pagerduty/:teamId", h.PostPagerDutyWebhook)
+	root.POST("/opsgenie/:teamId", h.PostOpsgenieWebhook)
+
+	// Voice calls
+	root.POST("/voice/:incidentId", h.PostVoiceCallback)
 }
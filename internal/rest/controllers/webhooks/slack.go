@@ -2,14 +2,19 @@ package webhooks
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/team"
 )
 
+type SlackConfig struct {
+	SigningSecret string `mapstructure:"signing_secret"`
+}
+
 type SlackAction struct {
 	ActionID string `json:"action_id"`
 	Value    string `json:"value"`
@@ -17,10 +22,25 @@ type SlackAction struct {
 
 type SlackPayload struct {
 	Type        string        `json:"type"`
+	Team        SlackTeam     `json:"team"`
+	User        SlackUser     `json:"user"`
 	Actions     []SlackAction `json:"actions"`
 	ResponseURL string        `json:"response_url"`
 }
 
+type SlackTeam struct {
+	ID string `json:"id"`
+}
+
+type SlackUser struct {
+	ID string `json:"id"`
+}
+
+// PostSlackInteractive handles the acknowledge and resolve buttons of the
+// alert messages. The request signature is verified by the slack guard, which
+// only proves the click came from some workspace with the app installed. The
+// click must come from the workspace of the team, by a user linked to a member
+// of it.
 func (h *Handlers) PostSlackInteractive(c echo.Context) error {
 	payloadStr := c.FormValue("payload")
 	if payloadStr == "" {
@@ -37,46 +57,68 @@ func (h *Handlers) PostSlackInteractive(c echo.Context) error {
 		return c.String(http.StatusOK, "ok")
 	}
 
-	action := payload.Actions[0]
-	var incidentIDStr string
-	var isAck bool
-
-	if strings.HasPrefix(action.Value, "ack_") {
-		incidentIDStr = strings.TrimPrefix(action.Value, "ack_")
-		isAck = true
-	} else if strings.HasPrefix(action.Value, "resolve_") {
-		incidentIDStr = strings.TrimPrefix(action.Value, "resolve_")
-		isAck = false
-	} else {
+	status, teamID, incidentID, ok := parseSlackActionValue(payload.Actions[0].Value)
+	if !ok {
 		return c.String(http.StatusOK, "ok")
 	}
 
-	incidentID, err := strconv.ParseUint(incidentIDStr, 10, 32)
+	ctx := c.Request().Context()
+
+	t, err := h.TeamService.GetByID(ctx, teamID)
 	if err != nil {
-		return c.String(http.StatusBadRequest, "invalid incident id")
+		if errors.Is(err, team.ErrNotFound) {
+			return c.String(http.StatusOK, "ok")
+		}
+
+		c.Logger().Errorf("failed to fetch team %d: %v", teamID, err)
+		return c.String(http.StatusInternalServerError, "error fetching team")
 	}
 
-	// Fetch incident
-	incident, err := h.IncidentService.GetByID(c.Request().Context(), uint(incidentID))
-	if err != nil {
-		c.Logger().Errorf("failed to fetch incident %d: %v", incidentID, err)
-		return c.String(http.StatusInternalServerError, "error fetching incident")
+	if t.SlackWorkspaceID == nil || *t.SlackWorkspaceID == "" || *t.SlackWorkspaceID != payload.Team.ID {
+		return c.String(http.StatusOK, "ok")
 	}
 
-	if isAck {
-		incident.Acknowledged = true
-		now := time.Now()
-		incident.AcknowledgedAt = &now
-		if err := h.IncidentService.Update(c.Request().Context(), incident); err != nil {
-			c.Logger().Errorf("failed to update incident %d: %v", incidentID, err)
-		}
-	} else {
-		incident.Resolved = true
-		if err := h.IncidentService.Update(c.Request().Context(), incident); err != nil {
-			c.Logger().Errorf("failed to update incident %d: %v", incidentID, err)
+	userID, err := h.TeamService.GetUserIDBySlackUserID(ctx, teamID, payload.User.ID)
+	if err != nil {
+		if errors.Is(err, team.ErrUserNotFound) {
+			return c.String(http.StatusOK, "ok")
 		}
+
+		c.Logger().Errorf("failed to fetch user of slack user %s: %v", payload.User.ID, err)
+		return c.String(http.StatusInternalServerError, "error fetching user")
 	}
 
 	// In a real app we'd post back to payload.ResponseURL to update the message visually
-	return c.String(http.StatusOK, "ok")
+	return h.syncIncidentStatus(c, teamID, incidentID, status, &userID)
+}
+
+// parseSlackActionValue parses button values on the form
+// "<ack|resolve>_<teamId>_<incidentId>".
+func parseSlackActionValue(value string) (incidentStatus, uint, uint, bool) {
+	parts := strings.Split(value, "_")
+	if len(parts) != 3 {
+		return 0, 0, 0, false
+	}
+
+	var status incidentStatus
+	switch parts[0] {
+	case "ack":
+		status = incidentStatusAcknowledged
+	case "resolve":
+		status = incidentStatusResolved
+	default:
+		return 0, 0, 0, false
+	}
+
+	teamID, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+
+	incidentID, err := strconv.ParseUint(parts[2], 10, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+
+	return status, uint(teamID), uint(incidentID), true
 }
@@ -0,0 +1,80 @@
+package webhooks_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/entities"
+	incidentMocks "github.com/opsway-io/backend/internal/incident/mocks"
+	"github.com/opsway-io/backend/internal/rest/controllers/webhooks"
+	"github.com/opsway-io/backend/internal/team"
+	teamMocks "github.com/opsway-io/backend/internal/team/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostSlackInteractive(t *testing.T) {
+	workspaceID := "T0001"
+
+	post := func(t *testing.T, h *webhooks.Handlers, payload string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/webhooks/slack/interactive", strings.NewReader(url.Values{"payload": {payload}}.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, h.PostSlackInteractive(echo.New().NewContext(req, rec)))
+
+		return rec
+	}
+
+	ack := `{"type":"block_actions","team":{"id":"%s"},"user":{"id":"U0001"},"actions":[{"action_id":"acknowledge_incident","value":"ack_1_42"}]}`
+
+	t.Run("acknowledges the incident as the linked user", func(t *testing.T) {
+		teams := teamMocks.NewService(t)
+		incidents := incidentMocks.NewService(t)
+		h := &webhooks.Handlers{TeamService: teams, IncidentService: incidents}
+
+		teams.On("GetByID", mock.Anything, uint(1)).Return(&entities.Team{ID: 1, SlackWorkspaceID: &workspaceID}, nil).Once()
+		teams.On("GetUserIDBySlackUserID", mock.Anything, uint(1), "U0001").Return(uint(3), nil).Once()
+		incidents.On("GetByID", mock.Anything, uint(42)).Return(&entities.Incident{ID: 42, TeamID: 1}, nil).Once()
+		incidents.On("Update", mock.Anything, mock.MatchedBy(func(i *entities.Incident) bool {
+			return i.Acknowledged && i.AcknowledgedBy != nil && *i.AcknowledgedBy == 3
+		})).Return(nil).Once()
+
+		rec := post(t, h, fmt.Sprintf(ack, workspaceID))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("ignores clicks from another workspace", func(t *testing.T) {
+		teams := teamMocks.NewService(t)
+		incidents := incidentMocks.NewService(t)
+		h := &webhooks.Handlers{TeamService: teams, IncidentService: incidents}
+
+		teams.On("GetByID", mock.Anything, uint(1)).Return(&entities.Team{ID: 1, SlackWorkspaceID: &workspaceID}, nil).Once()
+
+		rec := post(t, h, fmt.Sprintf(ack, "T0002"))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("ignores clicks of users not linked to a member", func(t *testing.T) {
+		teams := teamMocks.NewService(t)
+		incidents := incidentMocks.NewService(t)
+		h := &webhooks.Handlers{TeamService: teams, IncidentService: incidents}
+
+		teams.On("GetByID", mock.Anything, uint(1)).Return(&entities.Team{ID: 1, SlackWorkspaceID: &workspaceID}, nil).Once()
+		teams.On("GetUserIDBySlackUserID", mock.Anything, uint(1), "U0001").Return(uint(0), team.ErrUserNotFound).Once()
+
+		rec := post(t, h, fmt.Sprintf(ack, workspaceID))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -0,0 +1,63 @@
+package webhooks
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/notification/phone"
+)
+
+type PostVoiceCallbackRequest struct {
+	IncidentID uint   `param:"incidentId"`
+	Digits     string `form:"Digits"`
+}
+
+// PostVoiceCallback receives the key pressed by the responder during a voice
+// alert, pressing 1 acknowledges the incident.
+func (h *Handlers) PostVoiceCallback(c echo.Context) error {
+	var req PostVoiceCallbackRequest
+	if err := c.Bind(&req); err != nil {
+		return c.String(http.StatusBadRequest, "invalid request")
+	}
+
+	// Echo only binds query parameters of GET and DELETE requests
+	userID, err := strconv.ParseUint(c.QueryParam("user"), 10, 32)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "invalid user")
+	}
+
+	ack := phone.Acknowledgement{IncidentID: req.IncidentID, UserID: uint(userID)}
+	if !phone.VerifyCallback(h.PhoneConfig, ack, c.QueryParam("token")) {
+		return c.String(http.StatusForbidden, "invalid token")
+	}
+
+	if req.Digits != "1" {
+		return voiceResponse(c, "No action taken. Goodbye.")
+	}
+
+	ctx := c.Request().Context()
+	incident, err := h.IncidentService.GetByID(ctx, req.IncidentID)
+	if err != nil {
+		c.Logger().Errorf("failed to fetch incident %d: %v", req.IncidentID, err)
+		return c.String(http.StatusInternalServerError, "error fetching incident")
+	}
+
+	if !incident.Acknowledged {
+		incident.Acknowledged = true
+		now := time.Now()
+		incident.AcknowledgedAt = &now
+		incident.AcknowledgedBy = &ack.UserID
+		if err := h.IncidentService.Update(ctx, incident); err != nil {
+			c.Logger().Errorf("failed to update incident %d: %v", req.IncidentID, err)
+			return c.String(http.StatusInternalServerError, "error updating incident")
+		}
+	}
+
+	return voiceResponse(c, "The incident has been acknowledged. Goodbye.")
+}
+
+func voiceResponse(c echo.Context, message string) error {
+	return c.XMLBlob(http.StatusOK, []byte(phone.SayTwiML(message)))
+}
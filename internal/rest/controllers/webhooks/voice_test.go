@@ -0,0 +1,91 @@
+package webhooks_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/opsway-io/backend/internal/entities"
+	"github.com/opsway-io/backend/internal/incident/mocks"
+	"github.com/opsway-io/backend/internal/notification/phone"
+	"github.com/opsway-io/backend/internal/rest/controllers/webhooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostVoiceCallback(t *testing.T) {
+	config := phone.Config{
+		CallbackBaseURL: "https://api.opsway.eu",
+		CallbackSecret:  "callback-secret",
+	}
+
+	// post sends the digits to the callback URL issued for the acknowledgement
+	post := func(t *testing.T, h *webhooks.Handlers, callbackURL string, digits string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		u, err := url.Parse(callbackURL)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, u.RequestURI(), strings.NewReader(url.Values{"Digits": {digits}}.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := httptest.NewRecorder()
+
+		c := echo.New().NewContext(req, rec)
+		c.SetParamNames("incidentId")
+		c.SetParamValues(strings.TrimPrefix(u.Path, "/v1/webhooks/voice/"))
+
+		require.NoError(t, h.PostVoiceCallback(c))
+
+		return rec
+	}
+
+	ack := phone.Acknowledgement{IncidentID: 42, UserID: 3}
+
+	t.Run("acknowledges the incident as the called user", func(t *testing.T) {
+		incidents := mocks.NewService(t)
+		h := &webhooks.Handlers{IncidentService: incidents, PhoneConfig: config}
+
+		incidents.On("GetByID", mock.Anything, uint(42)).Return(&entities.Incident{ID: 42}, nil).Once()
+		incidents.On("Update", mock.Anything, mock.MatchedBy(func(i *entities.Incident) bool {
+			return i.Acknowledged && i.AcknowledgedAt != nil && i.AcknowledgedBy != nil && *i.AcknowledgedBy == 3
+		})).Return(nil).Once()
+
+		rec := post(t, h, phone.CallbackURL(config, ack), "1")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "The incident has been acknowledged.")
+	})
+
+	t.Run("takes no action on other digits", func(t *testing.T) {
+		incidents := mocks.NewService(t)
+		h := &webhooks.Handlers{IncidentService: incidents, PhoneConfig: config}
+
+		rec := post(t, h, phone.CallbackURL(config, ack), "2")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "No action taken.")
+	})
+
+	t.Run("rejects tokens of another user", func(t *testing.T) {
+		incidents := mocks.NewService(t)
+		h := &webhooks.Handlers{IncidentService: incidents, PhoneConfig: config}
+
+		callbackURL := strings.Replace(phone.CallbackURL(config, ack), "user=3", "user=4", 1)
+		rec := post(t, h, callbackURL, "1")
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("rejects tokens of another secret", func(t *testing.T) {
+		incidents := mocks.NewService(t)
+		h := &webhooks.Handlers{IncidentService: incidents, PhoneConfig: config}
+
+		rec := post(t, h, phone.CallbackURL(phone.Config{CallbackSecret: "other"}, ack), "1")
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
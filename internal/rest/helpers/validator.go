@@ -4,6 +4,7 @@ import (
 	"reflect"
 
 	"github.com/go-playground/validator"
+	"github.com/opsway-io/backend/internal/entities"
 	"github.com/opsway-io/backend/internal/probes/http/asserter"
 	"github.com/pkg/errors"
 )
@@ -21,6 +22,7 @@ func NewValidator() *Validator {
 	_ = v.RegisterValidation("monitorBodyType", BodyTypeValidator)
 	_ = v.RegisterValidation("monitorState", MonitorStateValidator)
 	_ = v.RegisterValidation("monitorAssertions", MonitorAssertionsValidator)
+	_ = v.RegisterValidation("incidentWindow", IncidentWindowValidator)
 
 	return &Validator{
 		validator: v,
@@ -123,3 +125,22 @@ func MonitorAssertionsValidator(fl validator.FieldLevel) bool {
 	}
 	return false
 }
+
+// IncidentWindowValidator requires the window of an incident policy to hold at
+// least as many checks as its failure threshold, which is the default one when
+// omitted. The threshold could never be met otherwise.
+func IncidentWindowValidator(fl validator.FieldLevel) bool {
+	window := fl.Field().Uint()
+	if window == 0 {
+		return true
+	}
+
+	parent := reflect.Indirect(fl.Parent())
+
+	threshold := parent.FieldByName("FailureThreshold").Uint()
+	if threshold == 0 {
+		threshold = entities.DefaultFailureThreshold
+	}
+
+	return window >= threshold
+}
@@ -0,0 +1,40 @@
+package helpers_test
+
+import (
+	"testing"
+
+	"github.com/opsway-io/backend/internal/rest/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncidentWindowValidator(t *testing.T) {
+	t.Parallel()
+
+	type policy struct {
+		FailureThreshold uint `validate:"omitempty,max=100"`
+		WindowSize       uint `validate:"omitempty,max=100,incidentWindow"`
+	}
+
+	tests := []struct {
+		name    string
+		policy  policy
+		wantErr bool
+	}{
+		{name: "no window", policy: policy{}, wantErr: false},
+		{name: "window of the default threshold", policy: policy{WindowSize: 3}, wantErr: false},
+		{name: "window below the default threshold", policy: policy{WindowSize: 2}, wantErr: true},
+		{name: "window below the threshold", policy: policy{FailureThreshold: 5, WindowSize: 4}, wantErr: true},
+		{name: "window above a lower threshold", policy: policy{FailureThreshold: 1, WindowSize: 2}, wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := helpers.NewValidator().Validate(&tt.policy)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// Requests signed longer ago than this are rejected to prevent replays
+const slackRequestMaxAge = 5 * time.Minute
+
+// Allows only requests signed by Slack with the app signing secret
+func SlackGuardFactory(logger *logrus.Entry, signingSecret string) func() func(next echo.HandlerFunc) echo.HandlerFunc {
+	l := logger.WithField("middleware", "slack_guard")
+
+	if signingSecret == "" {
+		l.Warn("slack signing secret is not configured, all slack requests will be rejected")
+	}
+
+	return func() func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				if signingSecret == "" {
+					return echo.ErrUnauthorized
+				}
+
+				timestamp := c.Request().Header.Get("X-Slack-Request-Timestamp")
+				signature := c.Request().Header.Get("X-Slack-Signature")
+				if timestamp == "" || signature == "" {
+					l.Debug("missing slack signature headers")
+
+					return echo.ErrUnauthorized
+				}
+
+				ts, err := strconv.ParseInt(timestamp, 10, 64)
+				if err != nil {
+					l.Debug("invalid slack request timestamp")
+
+					return echo.ErrUnauthorized
+				}
+
+				age := time.Since(time.Unix(ts, 0))
+				if age > slackRequestMaxAge || age < -slackRequestMaxAge {
+					l.Debug("slack request timestamp outside of allowed window")
+
+					return echo.ErrUnauthorized
+				}
+
+				body, err := io.ReadAll(c.Request().Body)
+				if err != nil {
+					return echo.ErrBadRequest
+				}
+
+				mac := hmac.New(sha256.New, []byte(signingSecret))
+				mac.Write([]byte("v0:" + timestamp + ":"))
+				mac.Write(body)
+				expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+				if !hmac.Equal([]byte(expected), []byte(signature)) {
+					l.Debug("invalid slack signature")
+
+					return echo.ErrUnauthorized
+				}
+
+				// The handler parses the form from the body we just consumed
+				c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+				return next(c)
+			}
+		}
+	}
+}
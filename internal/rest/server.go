@@ -17,15 +17,20 @@ import (
 	"github.com/opsway-io/backend/internal/incident"
 	"github.com/opsway-io/backend/internal/maintenance"
 	"github.com/opsway-io/backend/internal/monitor"
+	"github.com/opsway-io/backend/internal/notification/delivery"
 	"github.com/opsway-io/backend/internal/notification/email"
+	"github.com/opsway-io/backend/internal/notification/phone"
+	"github.com/opsway-io/backend/internal/postmortem"
 	"github.com/opsway-io/backend/internal/report"
 	"github.com/opsway-io/backend/internal/rest/controllers"
 	"github.com/opsway-io/backend/internal/rest/controllers/authentication"
+	"github.com/opsway-io/backend/internal/rest/controllers/webhooks"
 	"github.com/opsway-io/backend/internal/rest/helpers"
 	"github.com/opsway-io/backend/internal/apikey"
 	"github.com/opsway-io/backend/internal/statuspage"
 	"github.com/opsway-io/backend/internal/team"
 	"github.com/opsway-io/backend/internal/user"
+	"github.com/opsway-io/backend/internal/webhook"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -60,6 +65,9 @@ func NewServer(
 	reportsService report.Service,
 	statusPageService statuspage.Service,
 	escalationService escalation.Service,
+	deliveryService delivery.Service,
+	webhookService webhook.Service,
+	postmortemService postmortem.Service,
 	eventService event.Service,
 	apiKeyService apikey.Service,
 	emailSender email.Sender,
@@ -67,6 +75,8 @@ func NewServer(
 	db *gorm.DB,
 	ch *gorm.DB,
 	statusPageBaseURL string,
+	phoneConfig phone.Config,
+	slackConfig webhooks.SlackConfig,
 ) (*Server, error) {
 	cookieService := helpers.NewCookieService(authConfig)
 
@@ -117,6 +127,9 @@ func NewServer(
 		reportsService,
 		statusPageService,
 		escalationService,
+		deliveryService,
+		webhookService,
+		postmortemService,
 		eventService,
 		apiKeyService,
 		emailSender,
@@ -124,6 +137,8 @@ func NewServer(
 		db,
 		ch,
 		statusPageBaseURL,
+		phoneConfig,
+		slackConfig,
 	)
 
 	return &Server{
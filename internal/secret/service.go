@@ -0,0 +1,93 @@
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+)
+
+var (
+	ErrMissingKey        = errors.New("no secret key configured")
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+)
+
+type Config struct {
+	// Key the secrets stored in the database are encrypted with, such as the
+	// connection strings of database monitors. Changing it makes the secrets
+	// stored before unreadable.
+	Key string `mapstructure:"key"`
+}
+
+type Service interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+}
+
+type ServiceImpl struct {
+	config Config
+}
+
+func NewService(config Config) Service {
+	return &ServiceImpl{
+		config: config,
+	}
+}
+
+// Encrypt seals the plaintext with AES-256-GCM, the result is the base64
+// encoded nonce followed by the ciphertext
+func (s *ServiceImpl) Encrypt(plaintext string) (string, error) {
+	aead, err := s.aead()
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (s *ServiceImpl) Decrypt(ciphertext string) (string, error) {
+	aead, err := s.aead()
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+
+	nonce, sealed := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+
+	return string(plaintext), nil
+}
+
+// The configured key can be any string, it is hashed to the 32 bytes AES-256
+// requires
+func (s *ServiceImpl) aead() (cipher.AEAD, error) {
+	if s.config.Key == "" {
+		return nil, ErrMissingKey
+	}
+
+	key := sha256.Sum256([]byte(s.config.Key))
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -284,6 +284,34 @@ func (_m *Repository) GetTeamsAndRoleByUserID(ctx context.Context, userID uint)
 	return r0, r1
 }
 
+// GetUserIDBySlackUserID provides a mock function with given fields: ctx, teamID, slackUserID
+func (_m *Repository) GetUserIDBySlackUserID(ctx context.Context, teamID uint, slackUserID string) (uint, error) {
+	ret := _m.Called(ctx, teamID, slackUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserIDBySlackUserID")
+	}
+
+	var r0 uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) (uint, error)); ok {
+		return rf(ctx, teamID, slackUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) uint); ok {
+		r0 = rf(ctx, teamID, slackUserID)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, teamID, slackUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserRole provides a mock function with given fields: ctx, teamID, userID
 func (_m *Repository) GetUserRole(ctx context.Context, teamID uint, userID uint) (*entities.TeamRole, error) {
 	ret := _m.Called(ctx, teamID, userID)
@@ -508,6 +536,24 @@ func (_m *Repository) UpdateUserRole(ctx context.Context, teamID uint, userID ui
 	return r0
 }
 
+// UpdateUserSlackUserID provides a mock function with given fields: ctx, teamID, userID, slackUserID
+func (_m *Repository) UpdateUserSlackUserID(ctx context.Context, teamID uint, userID uint, slackUserID *string) error {
+	ret := _m.Called(ctx, teamID, userID, slackUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserSlackUserID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, *string) error); ok {
+		r0 = rf(ctx, teamID, userID, slackUserID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepository(t interface {
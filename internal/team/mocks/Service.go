@@ -302,6 +302,34 @@ func (_m *Service) GetTeamsAndRoleByUserID(ctx context.Context, userID uint) (*[
 	return r0, r1
 }
 
+// GetUserIDBySlackUserID provides a mock function with given fields: ctx, teamID, slackUserID
+func (_m *Service) GetUserIDBySlackUserID(ctx context.Context, teamID uint, slackUserID string) (uint, error) {
+	ret := _m.Called(ctx, teamID, slackUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserIDBySlackUserID")
+	}
+
+	var r0 uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) (uint, error)); ok {
+		return rf(ctx, teamID, slackUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) uint); ok {
+		r0 = rf(ctx, teamID, slackUserID)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, teamID, slackUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserRole provides a mock function with given fields: ctx, teamID, userID
 func (_m *Service) GetUserRole(ctx context.Context, teamID uint, userID uint) (*entities.TeamRole, error) {
 	ret := _m.Called(ctx, teamID, userID)
@@ -426,6 +454,34 @@ func (_m *Service) RemoveUser(ctx context.Context, teamID uint, userID uint) err
 	return r0
 }
 
+// RotateIngestionToken provides a mock function with given fields: ctx, teamID
+func (_m *Service) RotateIngestionToken(ctx context.Context, teamID uint) (string, error) {
+	ret := _m.Called(ctx, teamID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateIngestionToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (string, error)); ok {
+		return rf(ctx, teamID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) string); ok {
+		r0 = rf(ctx, teamID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateBilling provides a mock function with given fields: ctx, teamID, customerID, plan
 func (_m *Service) UpdateBilling(ctx context.Context, teamID uint, customerID string, plan string) error {
 	ret := _m.Called(ctx, teamID, customerID, plan)
@@ -498,6 +554,24 @@ func (_m *Service) UpdateUserRole(ctx context.Context, teamID uint, userID uint,
 	return r0
 }
 
+// UpdateUserSlackUserID provides a mock function with given fields: ctx, teamID, userID, slackUserID
+func (_m *Service) UpdateUserSlackUserID(ctx context.Context, teamID uint, userID uint, slackUserID *string) error {
+	ret := _m.Called(ctx, teamID, userID, slackUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserSlackUserID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, *string) error); ok {
+		r0 = rf(ctx, teamID, userID, slackUserID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UploadAvatar provides a mock function with given fields: ctx, teamID, file
 func (_m *Service) UploadAvatar(ctx context.Context, teamID uint, file io.Reader) error {
 	ret := _m.Called(ctx, teamID, file)
@@ -20,6 +20,7 @@ var (
 type Repository interface {
 	GetByID(ctx context.Context, teamId uint) (*entities.Team, error)
 	GetByStripeID(ctx context.Context, stripeID string) (*entities.Team, error)
+	GetUserIDBySlackUserID(ctx context.Context, teamID uint, slackUserID string) (uint, error)
 	GetUsersByID(ctx context.Context, teamId uint, offset *int, limit *int, query *string, role *entities.TeamRole) (*[]TeamUser, error)
 	GetTeamUserCount(ctx context.Context, teamId uint) (int64, error)
 	GetUserRole(ctx context.Context, teamID, userID uint) (*entities.TeamRole, error)
@@ -27,6 +28,7 @@ type Repository interface {
 
 	Update(ctx context.Context, team *entities.Team) error
 	UpdateUserRole(ctx context.Context, teamID, userID uint, role entities.TeamRole) error
+	UpdateUserSlackUserID(ctx context.Context, teamID, userID uint, slackUserID *string) error
 	UpdateDisplayName(ctx context.Context, teamID uint, displayName string) error
 
 	UpdateBilling(ctx context.Context, teamID uint, customerID string, plan string) error
@@ -82,17 +84,31 @@ func (s *RepositoryImpl) GetByStripeID(ctx context.Context, stripeID string) (*e
 	return &team, nil
 }
 
+func (s *RepositoryImpl) GetUserIDBySlackUserID(ctx context.Context, teamID uint, slackUserID string) (uint, error) {
+	var teamUser entities.TeamUser
+	if err := s.db.WithContext(ctx).Where("team_id = ? AND slack_user_id = ?", teamID, slackUserID).First(&teamUser).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrUserNotFound
+		}
+
+		return 0, err
+	}
+
+	return teamUser.UserID, nil
+}
+
 type TeamUser struct {
 	entities.User
-	Role       entities.TeamRole
-	TotalCount int
+	Role        entities.TeamRole
+	SlackUserID *string
+	TotalCount  int
 }
 
 func (s *RepositoryImpl) GetUsersByID(ctx context.Context, teamId uint, offset *int, limit *int, query *string, role *entities.TeamRole) (*[]TeamUser, error) {
 	var users []TeamUser
 
 	q := s.db.WithContext(ctx).
-		Select("u.*, tu.role").
+		Select("u.*, tu.role, tu.slack_user_id").
 		Table("team_users as tu").
 		Joins("INNER JOIN users as u ON u.id = tu.user_id AND tu.team_id = ?", teamId).
 		Order("u.name ASC").
@@ -217,6 +233,21 @@ func (s *RepositoryImpl) UpdateUserRole(ctx context.Context, teamID, userID uint
 	return err
 }
 
+func (s *RepositoryImpl) UpdateUserSlackUserID(ctx context.Context, teamID, userID uint, slackUserID *string) error {
+	result := s.db.WithContext(ctx).Model(&entities.TeamUser{}).Where(entities.TeamUser{
+		TeamID: teamID,
+		UserID: userID,
+	}).Update("slack_user_id", slackUserID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
 func (s *RepositoryImpl) Delete(ctx context.Context, id uint) error {
 	result := s.db.WithContext(ctx).Select(clause.Associations).Delete(&entities.Team{
 		ID: id,
@@ -2,6 +2,8 @@ package team
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"time"
@@ -32,15 +34,18 @@ type Service interface {
 	GetByID(ctx context.Context, teamId uint) (*entities.Team, error)
 
 	GetByStripeID(ctx context.Context, stripeID string) (*entities.Team, error)
+	GetUserIDBySlackUserID(ctx context.Context, teamID uint, slackUserID string) (uint, error)
 
 	GetTeamsAndRoleByUserID(ctx context.Context, userID uint) (*[]TeamAndRole, error)
 	GetUsersByID(ctx context.Context, teamId uint, offset *int, limit *int, query *string, role *entities.TeamRole) (*[]TeamUser, error)
 	GetTeamUserCount(ctx context.Context, teamId uint) (int64, error)
 	GetUserRole(ctx context.Context, teamID, userID uint) (*entities.TeamRole, error)
 	UpdateUserRole(ctx context.Context, teamID, userID uint, role entities.TeamRole) error
+	UpdateUserSlackUserID(ctx context.Context, teamID, userID uint, slackUserID *string) error
 
 	UpdateBilling(ctx context.Context, teamID uint, customerID string, plan string) error
 	UpdateTeam(ctx context.Context, team *entities.Team) error
+	RotateIngestionToken(ctx context.Context, teamID uint) (plaintextToken string, err error)
 
 	RemoveUser(ctx context.Context, teamID, userID uint) error
 	UpdateDisplayName(ctx context.Context, teamID uint, displayName string) error
@@ -92,6 +97,10 @@ func (s *ServiceImpl) GetByStripeID(ctx context.Context, stripeID string) (*enti
 	return s.repository.GetByStripeID(ctx, stripeID)
 }
 
+func (s *ServiceImpl) GetUserIDBySlackUserID(ctx context.Context, teamID uint, slackUserID string) (uint, error) {
+	return s.repository.GetUserIDBySlackUserID(ctx, teamID, slackUserID)
+}
+
 func (s *ServiceImpl) CreateWithOwnerUserID(ctx context.Context, team *entities.Team, ownerUserID uint) error {
 	return s.repository.CreateWithOwnerUserID(ctx, team, ownerUserID)
 }
@@ -196,6 +205,10 @@ func (s *ServiceImpl) UpdateUserRole(ctx context.Context, teamID, userID uint, r
 	return s.repository.UpdateUserRole(ctx, teamID, userID, role)
 }
 
+func (s *ServiceImpl) UpdateUserSlackUserID(ctx context.Context, teamID, userID uint, slackUserID *string) error {
+	return s.repository.UpdateUserSlackUserID(ctx, teamID, userID, slackUserID)
+}
+
 func (s *ServiceImpl) UpdateBilling(ctx context.Context, teamID uint, customerID string, plan string) error {
 	err := s.repository.UpdateBilling(ctx, teamID, customerID, plan)
 	if err == nil {
@@ -212,6 +225,27 @@ func (s *ServiceImpl) UpdateTeam(ctx context.Context, team *entities.Team) error
 	return err
 }
 
+func (s *ServiceImpl) RotateIngestionToken(ctx context.Context, teamID uint) (string, error) {
+	team, err := s.repository.GetByID(ctx, teamID)
+	if err != nil {
+		return "", err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b)
+
+	team.SetIngestionToken(token)
+
+	if err := s.UpdateTeam(ctx, team); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
 func (s *ServiceImpl) GetTeamsAndRoleByUserID(ctx context.Context, userID uint) (*[]TeamAndRole, error) {
 	return s.repository.GetTeamsAndRoleByUserID(ctx, userID)
 }
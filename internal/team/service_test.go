@@ -88,3 +88,32 @@ func TestService_UpdateDisplayName(t *testing.T) {
 		cache.AssertExpectations(t)
 	})
 }
+
+func TestService_RotateIngestionToken(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores the hash of a new token and invalidates cache", func(t *testing.T) {
+		repo := new(teamMocks.Repository)
+		cache := new(teamMocks.Cache)
+
+		svc := team.NewService(team.Config{}, repo, nil, nil, cache)
+
+		teamEntity := &entities.Team{
+			ID:   1,
+			Name: "test-team",
+		}
+
+		repo.On("GetByID", ctx, uint(1)).Return(teamEntity, nil)
+		repo.On("UpdateTeam", ctx, teamEntity).Return(nil)
+		cache.On("DeleteTeam", ctx, uint(1)).Return(nil)
+
+		token, err := svc.RotateIngestionToken(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, token)
+		assert.NotEqual(t, token, *teamEntity.IngestionTokenHash)
+		assert.True(t, teamEntity.VerifyIngestionToken(token))
+		repo.AssertExpectations(t)
+		cache.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,115 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/opsway-io/backend/internal/entities"
+)
+
+// PayloadVersion is bumped whenever a breaking change is made to Payload
+const PayloadVersion = "1"
+
+const (
+	EventIncidentCreated      = "incident.created"
+	EventIncidentAcknowledged = "incident.acknowledged"
+	EventIncidentResolved     = "incident.resolved"
+	EventIncidentReopened     = "incident.reopened"
+	EventMaintenanceCreated   = "maintenance.created"
+	EventMaintenanceUpdated   = "maintenance.updated"
+	EventMaintenanceCompleted = "maintenance.completed"
+)
+
+var Events = []string{
+	EventIncidentCreated,
+	EventIncidentAcknowledged,
+	EventIncidentResolved,
+	EventIncidentReopened,
+	EventMaintenanceCreated,
+	EventMaintenanceUpdated,
+	EventMaintenanceCompleted,
+}
+
+type Payload struct {
+	Version     string              `json:"version"`
+	Event       string              `json:"event"`
+	Timestamp   time.Time           `json:"timestamp"`
+	TeamID      uint                `json:"teamId"`
+	Incident    *PayloadIncident    `json:"incident,omitempty"`
+	Maintenance *PayloadMaintenance `json:"maintenance,omitempty"`
+}
+
+type PayloadIncident struct {
+	ID              uint       `json:"id"`
+	Title           string     `json:"title"`
+	Description     *string    `json:"description"`
+	Source          string     `json:"source"`
+	Severity        string     `json:"severity"`
+	Status          string     `json:"status"`
+	MonitorID       *uint      `json:"monitorId"`
+	HeartbeatID     *uint      `json:"heartbeatId"`
+	FailedLocations []string   `json:"failedLocations"`
+	Acknowledged    bool       `json:"acknowledged"`
+	AcknowledgedAt  *time.Time `json:"acknowledgedAt"`
+	Resolved        bool       `json:"resolved"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+type PayloadMaintenance struct {
+	ID          uint      `json:"id"`
+	Title       string    `json:"title"`
+	Description *string   `json:"description"`
+	StartAt     time.Time `json:"startAt"`
+	EndAt       time.Time `json:"endAt"`
+	MonitorIDs  []uint    `json:"monitorIds"`
+}
+
+func NewIncidentPayload(event string, incident *entities.Incident, now time.Time) Payload {
+	return Payload{
+		Version:   PayloadVersion,
+		Event:     event,
+		Timestamp: now,
+		TeamID:    incident.TeamID,
+		Incident: &PayloadIncident{
+			ID:              incident.ID,
+			Title:           incident.Title,
+			Description:     incident.Description,
+			Source:          string(incident.Source),
+			Severity:        string(incident.Severity),
+			Status:          string(incident.Status),
+			MonitorID:       incident.MonitorID,
+			HeartbeatID:     incident.HeartbeatID,
+			FailedLocations: incident.FailedLocations,
+			Acknowledged:    incident.Acknowledged,
+			AcknowledgedAt:  incident.AcknowledgedAt,
+			Resolved:        incident.Resolved,
+			CreatedAt:       incident.CreatedAt,
+			UpdatedAt:       incident.UpdatedAt,
+		},
+	}
+}
+
+// NewMaintenancePayload creates a payload for a maintenance event, the action
+// is one of created, updated or completed.
+func NewMaintenancePayload(action string, maintenance *entities.Maintenance, now time.Time) Payload {
+	m := &PayloadMaintenance{
+		ID:          maintenance.ID,
+		Title:       maintenance.Title,
+		Description: maintenance.Description,
+		StartAt:     maintenance.Settings.StartAt,
+		EndAt:       maintenance.Settings.EndAt,
+		MonitorIDs:  []uint{},
+	}
+
+	for _, mon := range maintenance.Monitors {
+		m.MonitorIDs = append(m.MonitorIDs, mon.ID)
+	}
+
+	return Payload{
+		Version:     PayloadVersion,
+		Event:       "maintenance." + action,
+		Timestamp:   now,
+		TeamID:      maintenance.TeamID,
+		Maintenance: m,
+	}
+}
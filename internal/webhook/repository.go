@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"github.com/opsway-io/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+var ErrNotFound = errors.New("webhook endpoint not found")
+
+type Repository interface {
+	GetByTeamID(ctx context.Context, teamID uint) (*[]entities.WebhookEndpoint, error)
+	GetByIDAndTeamID(ctx context.Context, teamID, id uint) (*entities.WebhookEndpoint, error)
+	Create(ctx context.Context, endpoint *entities.WebhookEndpoint) error
+	Update(ctx context.Context, endpoint *entities.WebhookEndpoint) error
+	Delete(ctx context.Context, teamID, id uint) error
+}
+
+type RepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &RepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *RepositoryImpl) GetByTeamID(ctx context.Context, teamID uint) (*[]entities.WebhookEndpoint, error) {
+	var endpoints []entities.WebhookEndpoint
+	err := r.db.WithContext(ctx).Where("team_id = ?", teamID).Order("id asc").Find(&endpoints).Error
+	if err != nil {
+		return nil, err
+	}
+	return &endpoints, nil
+}
+
+func (r *RepositoryImpl) GetByIDAndTeamID(ctx context.Context, teamID, id uint) (*entities.WebhookEndpoint, error) {
+	var endpoint entities.WebhookEndpoint
+	err := r.db.WithContext(ctx).Where("team_id = ? AND id = ?", teamID, id).First(&endpoint).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+func (r *RepositoryImpl) Create(ctx context.Context, endpoint *entities.WebhookEndpoint) error {
+	return r.db.WithContext(ctx).Create(endpoint).Error
+}
+
+func (r *RepositoryImpl) Update(ctx context.Context, endpoint *entities.WebhookEndpoint) error {
+	result := r.db.WithContext(ctx).
+		Model(&entities.WebhookEndpoint{}).
+		Where("team_id = ? AND id = ?", endpoint.TeamID, endpoint.ID).
+		Select("Name", "URL", "Secret", "Events", "BodyTemplate", "Enabled").
+		Updates(endpoint)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *RepositoryImpl) Delete(ctx context.Context, teamID, id uint) error {
+	return r.db.WithContext(ctx).Where("team_id = ? AND id = ?", teamID, id).Delete(&entities.WebhookEndpoint{}).Error
+}
@@ -0,0 +1,301 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/opsway-io/backend/internal/entities"
+	"github.com/opsway-io/backend/internal/notification/delivery"
+)
+
+var (
+	ErrInvalidEvent    = errors.New("invalid webhook event")
+	ErrInvalidTemplate = errors.New("invalid body template")
+)
+
+const requestTimeout = 10 * time.Second
+
+const channel = "webhook"
+
+// Keys of the retry context stored with every attempt, so a retry sends the
+// same body to the endpoint
+const (
+	retryEndpointID = "endpoint_id"
+	retryEvent      = "event"
+	retryBody       = "body"
+)
+
+type Service interface {
+	GetByTeamID(ctx context.Context, teamID uint) (*[]entities.WebhookEndpoint, error)
+	GetByIDAndTeamID(ctx context.Context, teamID, id uint) (*entities.WebhookEndpoint, error)
+	Create(ctx context.Context, endpoint *entities.WebhookEndpoint) error
+	Update(ctx context.Context, endpoint *entities.WebhookEndpoint) error
+	Delete(ctx context.Context, teamID, id uint) error
+	RotateSecret(ctx context.Context, teamID, id uint) (string, error)
+	Dispatch(ctx context.Context, payload Payload) error
+}
+
+type ServiceImpl struct {
+	repository      Repository
+	deliveryService delivery.Service
+	client          *http.Client
+}
+
+func NewService(repository Repository, deliveryService delivery.Service) Service {
+	s := &ServiceImpl{
+		repository:      repository,
+		deliveryService: deliveryService,
+		client:          &http.Client{Timeout: requestTimeout},
+	}
+
+	deliveryService.RegisterResender(channel, s.resend)
+
+	return s
+}
+
+func (s *ServiceImpl) GetByTeamID(ctx context.Context, teamID uint) (*[]entities.WebhookEndpoint, error) {
+	return s.repository.GetByTeamID(ctx, teamID)
+}
+
+func (s *ServiceImpl) GetByIDAndTeamID(ctx context.Context, teamID, id uint) (*entities.WebhookEndpoint, error) {
+	return s.repository.GetByIDAndTeamID(ctx, teamID, id)
+}
+
+// Create validates the endpoint and generates its signing secret
+func (s *ServiceImpl) Create(ctx context.Context, endpoint *entities.WebhookEndpoint) error {
+	if err := validate(endpoint); err != nil {
+		return err
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return err
+	}
+	endpoint.Secret = secret
+
+	return s.repository.Create(ctx, endpoint)
+}
+
+func (s *ServiceImpl) Update(ctx context.Context, endpoint *entities.WebhookEndpoint) error {
+	if err := validate(endpoint); err != nil {
+		return err
+	}
+
+	current, err := s.repository.GetByIDAndTeamID(ctx, endpoint.TeamID, endpoint.ID)
+	if err != nil {
+		return err
+	}
+
+	// The secret is only changed by rotating it
+	endpoint.Secret = current.Secret
+
+	return s.repository.Update(ctx, endpoint)
+}
+
+func (s *ServiceImpl) Delete(ctx context.Context, teamID, id uint) error {
+	return s.repository.Delete(ctx, teamID, id)
+}
+
+func (s *ServiceImpl) RotateSecret(ctx context.Context, teamID, id uint) (string, error) {
+	endpoint, err := s.repository.GetByIDAndTeamID(ctx, teamID, id)
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return "", err
+	}
+	endpoint.Secret = secret
+
+	if err := s.repository.Update(ctx, endpoint); err != nil {
+		return "", err
+	}
+
+	return secret, nil
+}
+
+// Dispatch sends the payload to every enabled endpoint of the team that is
+// subscribed to the event.
+func (s *ServiceImpl) Dispatch(ctx context.Context, payload Payload) error {
+	endpoints, err := s.repository.GetByTeamID(ctx, payload.TeamID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, endpoint := range *endpoints {
+		if !endpoint.Enabled || (len(endpoint.Events) > 0 && !slices.Contains(endpoint.Events, payload.Event)) {
+			continue
+		}
+
+		if err := s.send(ctx, endpoint, payload); err != nil {
+			errs = append(errs, fmt.Errorf("endpoint %d: %w", endpoint.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *ServiceImpl) send(ctx context.Context, endpoint entities.WebhookEndpoint, payload Payload) error {
+	d := delivery.Delivery{
+		TeamID:    endpoint.TeamID,
+		Channel:   channel,
+		Recipient: endpointRecipient(endpoint),
+	}
+
+	if payload.Incident != nil {
+		d.IncidentID = &payload.Incident.ID
+	}
+
+	if payload.Maintenance != nil {
+		d.MaintenanceID = &payload.Maintenance.ID
+	}
+
+	body, err := RenderBody(endpoint.BodyTemplate, payload)
+	if err != nil {
+		// Record the failure so the team can see why nothing arrived
+		return s.deliveryService.Deliver(ctx, d, func(ctx context.Context) (int, error) {
+			return 0, fmt.Errorf("%w: %w", delivery.ErrPermanent, err)
+		})
+	}
+
+	d.RetryContext = map[string]string{
+		retryEndpointID: strconv.FormatUint(uint64(endpoint.ID), 10),
+		retryEvent:      payload.Event,
+		retryBody:       string(body),
+	}
+
+	return s.deliveryService.Deliver(ctx, d, s.post(endpoint, payload.Event, body))
+}
+
+// resend posts the body of a failed attempt again, as long as the endpoint
+// still exists and is enabled.
+func (s *ServiceImpl) resend(ctx context.Context, a *entities.NotificationAttempt) (delivery.SendFunc, error) {
+	id, err := strconv.ParseUint(a.RetryContext[retryEndpointID], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid endpoint: %w", delivery.ErrPermanent, err)
+	}
+
+	endpoint, err := s.repository.GetByIDAndTeamID(ctx, a.TeamID, uint(id))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("%w: %w", delivery.ErrPermanent, err)
+		}
+
+		return nil, err
+	}
+
+	if !endpoint.Enabled {
+		return nil, fmt.Errorf("%w: endpoint %d is disabled", delivery.ErrPermanent, endpoint.ID)
+	}
+
+	return s.post(*endpoint, a.RetryContext[retryEvent], []byte(a.RetryContext[retryBody])), nil
+}
+
+func (s *ServiceImpl) post(endpoint entities.WebhookEndpoint, event string, body []byte) delivery.SendFunc {
+	return func(ctx context.Context) (int, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+		if err != nil {
+			return 0, err
+		}
+
+		// Signed per attempt so retries carry a fresh timestamp
+		timestamp := time.Now().Unix()
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(HeaderEvent, event)
+		req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(HeaderSignature, Sign(endpoint.Secret, timestamp, body))
+
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		return resp.StatusCode, nil
+	}
+}
+
+// RenderBody renders the body template with the payload, the payload is
+// encoded as JSON when there is no template.
+func RenderBody(bodyTemplate *string, payload Payload) ([]byte, error) {
+	if bodyTemplate == nil || *bodyTemplate == "" {
+		return json.Marshal(payload)
+	}
+
+	tmpl, err := parseTemplate(*bodyTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, payload); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+
+	return b.Bytes(), nil
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("body").Funcs(template.FuncMap{
+		// Encodes a value as JSON, strings are quoted and escaped
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+
+	return tmpl, nil
+}
+
+func validate(endpoint *entities.WebhookEndpoint) error {
+	for _, event := range endpoint.Events {
+		if !slices.Contains(Events, event) {
+			return fmt.Errorf("%w: %s", ErrInvalidEvent, event)
+		}
+	}
+
+	if endpoint.BodyTemplate != nil && *endpoint.BodyTemplate != "" {
+		if _, err := parseTemplate(*endpoint.BodyTemplate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// endpointRecipient identifies an endpoint by name and host, as the full URL
+// may contain a token.
+func endpointRecipient(endpoint entities.WebhookEndpoint) string {
+	host := "webhook"
+	if u, err := url.Parse(endpoint.URL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	return fmt.Sprintf("%s (%s)", endpoint.Name, host)
+}
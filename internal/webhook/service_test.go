@@ -0,0 +1,212 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/opsway-io/backend/internal/entities"
+	"github.com/opsway-io/backend/internal/notification/delivery"
+	deliveryMocks "github.com/opsway-io/backend/internal/notification/delivery/mocks"
+	"github.com/opsway-io/backend/internal/webhook"
+	"github.com/opsway-io/backend/internal/webhook/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Dispatch(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	payload := webhook.NewIncidentPayload(webhook.EventIncidentResolved, &entities.Incident{
+		ID:       100,
+		TeamID:   1,
+		Title:    "API is down",
+		Resolved: true,
+	}, now)
+
+	type request struct {
+		header http.Header
+		body   []byte
+	}
+
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, request{header: r.Header, body: body})
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	template := `{"text": {{ json .Incident.Title }}, "event": "{{ .Event }}"}`
+
+	endpoints := []entities.WebhookEndpoint{
+		{ID: 1, TeamID: 1, Name: "all", URL: server.URL, Secret: "secret-1", Enabled: true},
+		{ID: 2, TeamID: 1, Name: "templated", URL: server.URL, Secret: "secret-2", Enabled: true, Events: pq.StringArray{webhook.EventIncidentResolved}, BodyTemplate: &template},
+		{ID: 3, TeamID: 1, Name: "other events", URL: server.URL, Secret: "secret-3", Enabled: true, Events: pq.StringArray{webhook.EventIncidentCreated}},
+		{ID: 4, TeamID: 1, Name: "disabled", URL: server.URL, Secret: "secret-4", Enabled: false},
+	}
+
+	repo := mocks.NewRepository(t)
+	deliverySvc := newDeliveryService(t)
+	svc := webhook.NewService(repo, deliverySvc)
+
+	repo.On("GetByTeamID", ctx, uint(1)).Return(&endpoints, nil)
+	deliverySvc.On("Deliver", ctx, mock.MatchedBy(func(d delivery.Delivery) bool {
+		return d.Channel == "webhook" && d.IncidentID != nil && *d.IncidentID == 100
+	}), mock.Anything).Return(func(ctx context.Context, d delivery.Delivery, send delivery.SendFunc) error {
+		code, err := send(ctx)
+		assert.Equal(t, http.StatusNoContent, code)
+		return err
+	}).Twice()
+
+	err := svc.Dispatch(ctx, payload)
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+
+	for i, secret := range []string{"secret-1", "secret-2"} {
+		r := requests[i]
+
+		timestamp, err := strconv.ParseInt(r.header.Get(webhook.HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+
+		assert.Equal(t, webhook.EventIncidentResolved, r.header.Get(webhook.HeaderEvent))
+		assert.True(t, webhook.Verify(secret, timestamp, r.body, r.header.Get(webhook.HeaderSignature)))
+	}
+
+	assert.JSONEq(t, `{
+		"version": "1",
+		"event": "incident.resolved",
+		"timestamp": "2024-01-01T12:00:00Z",
+		"teamId": 1,
+		"incident": {
+			"id": 100,
+			"title": "API is down",
+			"description": null,
+			"source": "",
+			"severity": "",
+			"status": "",
+			"monitorId": null,
+			"heartbeatId": null,
+			"failedLocations": null,
+			"acknowledged": false,
+			"acknowledgedAt": null,
+			"resolved": true,
+			"createdAt": "0001-01-01T00:00:00Z",
+			"updatedAt": "0001-01-01T00:00:00Z"
+		}
+	}`, string(requests[0].body))
+	assert.JSONEq(t, `{"text": "API is down", "event": "incident.resolved"}`, string(requests[1].body))
+}
+
+func TestService_Resend(t *testing.T) {
+	ctx := context.Background()
+
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		assert.Equal(t, webhook.EventIncidentCreated, r.Header.Get(webhook.HeaderEvent))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	attempt := &entities.NotificationAttempt{
+		TeamID:  1,
+		Channel: "webhook",
+		RetryContext: map[string]string{
+			"endpoint_id": "7",
+			"event":       webhook.EventIncidentCreated,
+			"body":        `{"text": "API is down"}`,
+		},
+	}
+
+	newService := func(t *testing.T, endpoint *entities.WebhookEndpoint, err error) delivery.Resender {
+		repo := mocks.NewRepository(t)
+		repo.On("GetByIDAndTeamID", ctx, uint(1), uint(7)).Return(endpoint, err)
+
+		var resend delivery.Resender
+		deliverySvc := deliveryMocks.NewService(t)
+		deliverySvc.On("RegisterResender", "webhook", mock.Anything).Run(func(args mock.Arguments) {
+			resend = args.Get(1).(delivery.Resender)
+		}).Once()
+
+		webhook.NewService(repo, deliverySvc)
+
+		return resend
+	}
+
+	t.Run("posts the stored body again", func(t *testing.T) {
+		resend := newService(t, &entities.WebhookEndpoint{ID: 7, TeamID: 1, URL: server.URL, Secret: "secret", Enabled: true}, nil)
+
+		send, err := resend(ctx, attempt)
+		require.NoError(t, err)
+
+		code, err := send(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.JSONEq(t, `{"text": "API is down"}`, string(received))
+	})
+
+	t.Run("gives up on disabled endpoints", func(t *testing.T) {
+		resend := newService(t, &entities.WebhookEndpoint{ID: 7, TeamID: 1, URL: server.URL, Enabled: false}, nil)
+
+		_, err := resend(ctx, attempt)
+
+		assert.ErrorIs(t, err, delivery.ErrPermanent)
+	})
+
+	t.Run("gives up on deleted endpoints", func(t *testing.T) {
+		resend := newService(t, nil, webhook.ErrNotFound)
+
+		_, err := resend(ctx, attempt)
+
+		assert.ErrorIs(t, err, delivery.ErrPermanent)
+	})
+}
+
+func TestService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("generates a secret", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		svc := webhook.NewService(repo, newDeliveryService(t))
+
+		repo.On("Create", ctx, mock.MatchedBy(func(e *entities.WebhookEndpoint) bool {
+			return len(e.Secret) > len("whsec_")
+		})).Return(nil)
+
+		err := svc.Create(ctx, &entities.WebhookEndpoint{TeamID: 1, URL: "https://example.com", Events: pq.StringArray{webhook.EventMaintenanceCreated}})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects unknown events", func(t *testing.T) {
+		svc := webhook.NewService(mocks.NewRepository(t), newDeliveryService(t))
+
+		err := svc.Create(ctx, &entities.WebhookEndpoint{TeamID: 1, Events: pq.StringArray{"incident.deleted"}})
+
+		assert.ErrorIs(t, err, webhook.ErrInvalidEvent)
+	})
+
+	t.Run("rejects malformed templates", func(t *testing.T) {
+		svc := webhook.NewService(mocks.NewRepository(t), newDeliveryService(t))
+		template := `{"text": {{ .Incident.Title }`
+
+		err := svc.Create(ctx, &entities.WebhookEndpoint{TeamID: 1, BodyTemplate: &template})
+
+		assert.ErrorIs(t, err, webhook.ErrInvalidTemplate)
+	})
+}
+
+func newDeliveryService(t *testing.T) *deliveryMocks.Service {
+	deliverySvc := deliveryMocks.NewService(t)
+	deliverySvc.On("RegisterResender", "webhook", mock.Anything).Once()
+
+	return deliverySvc
+}
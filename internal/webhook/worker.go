@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/opsway-io/backend/internal/event"
+	"github.com/opsway-io/backend/internal/event/events"
+	"github.com/sirupsen/logrus"
+)
+
+type Worker interface {
+	Start(ctx context.Context) error
+}
+
+type worker struct {
+	eventService event.Service
+	webhookSvc   Service
+	logger       *logrus.Entry
+}
+
+func NewWorker(
+	eventService event.Service,
+	webhookSvc Service,
+	logger *logrus.Entry,
+) Worker {
+	return &worker{
+		eventService: eventService,
+		webhookSvc:   webhookSvc,
+		logger:       logger.WithField("component", "webhook_worker"),
+	}
+}
+
+func (w *worker) Start(ctx context.Context) error {
+	w.logger.Info("Starting webhook worker...")
+
+	incidentEvents := map[events.EventType]string{
+		events.EventTypeIncidentCreated:      EventIncidentCreated,
+		events.EventTypeIncidentAcknowledged: EventIncidentAcknowledged,
+		events.EventTypeIncidentResolved:     EventIncidentResolved,
+		events.EventTypeIncidentReopened:     EventIncidentReopened,
+	}
+
+	for eventType, event := range incidentEvents {
+		messages, err := w.eventService.Subscribe(ctx, string(eventType))
+		if err != nil {
+			return fmt.Errorf("failed to subscribe to %s stream: %w", eventType, err)
+		}
+
+		go func() {
+			for msg := range messages {
+				w.processIncidentMessage(ctx, event, msg.Payload)
+				msg.Ack()
+			}
+		}()
+	}
+
+	maintenanceMessages, err := w.eventService.Subscribe(ctx, string(events.EventTypeMaintenance))
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to maintenance stream: %w", err)
+	}
+
+	go func() {
+		for msg := range maintenanceMessages {
+			w.processMaintenanceMessage(ctx, msg.Payload)
+			msg.Ack()
+		}
+	}()
+
+	<-ctx.Done()
+	return nil
+}
+
+func (w *worker) processIncidentMessage(ctx context.Context, event string, payload []byte) {
+	// Incident events share the same payload
+	var ev events.IncidentCreatedEvent
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		w.logger.WithError(err).Error("failed to unmarshal event")
+		return
+	}
+
+	if ev.Incident == nil {
+		return
+	}
+
+	if err := w.webhookSvc.Dispatch(ctx, NewIncidentPayload(event, ev.Incident, time.Now())); err != nil {
+		w.logger.WithError(err).WithField("incident_id", ev.Incident.ID).Error("failed to dispatch webhooks")
+	}
+}
+
+func (w *worker) processMaintenanceMessage(ctx context.Context, payload []byte) {
+	var ev events.MaintenanceEvent
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		w.logger.WithError(err).Error("failed to unmarshal maintenance event")
+		return
+	}
+
+	if ev.Maintenance == nil {
+		return
+	}
+
+	if err := w.webhookSvc.Dispatch(ctx, NewMaintenancePayload(ev.Action, ev.Maintenance, time.Now())); err != nil {
+		w.logger.WithError(err).WithField("maintenance_id", ev.Maintenance.ID).Error("failed to dispatch webhooks")
+	}
+}